@@ -0,0 +1,14 @@
+-- Migration 0007: User-defined species
+-- Keepers can add species missing from the built-in catalogue. They stay
+-- private to the owner unless proposed for the shared catalogue and approved
+-- by an admin.
+
+ALTER TABLE spider_bot.tarantula_species
+    ADD COLUMN IF NOT EXISTS owner_user_id BIGINT REFERENCES spider_bot.telegram_users (telegram_id),
+    ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'approved';
+
+CREATE INDEX IF NOT EXISTS idx_tarantula_species_owner ON spider_bot.tarantula_species (owner_user_id);
+CREATE INDEX IF NOT EXISTS idx_tarantula_species_status ON spider_bot.tarantula_species (status);
+
+COMMENT ON COLUMN spider_bot.tarantula_species.owner_user_id IS 'Keeper who added the species (NULL for the built-in catalogue)';
+COMMENT ON COLUMN spider_bot.tarantula_species.status IS 'approved (shared), pending (proposed for sharing) or private (owner only)';
@@ -0,0 +1,25 @@
+-- Migration 0008: Species synonyms
+-- Many species have been moved between genera; keepers still search for them
+-- by their old names, so store alternative names for species search
+
+ALTER TABLE spider_bot.tarantula_species
+    ADD COLUMN IF NOT EXISTS synonyms TEXT NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN spider_bot.tarantula_species.synonyms IS 'Comma-separated former or alternative scientific names';
+
+UPDATE spider_bot.tarantula_species AS ts
+SET synonyms = s.synonyms
+FROM (VALUES ('Brachypelma hamorii', 'Brachypelma smithi'),
+             ('Tliltocatl albopilosus', 'Brachypelma albopilosum, Brachypelma albopilosus'),
+             ('Tliltocatl vagans', 'Brachypelma vagans'),
+             ('Tliltocatl verdezi', 'Brachypelma verdezi'),
+             ('Caribena versicolor', 'Avicularia versicolor'),
+             ('Chromatopelma cyaneopubescens', 'GBB'),
+             ('Grammostola pulchripes', 'Grammostola aureostriata'),
+             ('Grammostola rosea', 'Grammostola porteri'),
+             ('Cyriopagopus lividus', 'Haplopelma lividum'),
+             ('Omothymus violaceopes', 'Lampropelma violaceopes'),
+             ('Ybyrapora diversipes', 'Avicularia diversipes'),
+             ('Neoholothele incei', 'Holothele incei')) AS s(scientific_name, synonyms)
+WHERE ts.scientific_name = s.scientific_name
+  AND ts.synonyms = '';
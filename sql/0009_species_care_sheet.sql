@@ -0,0 +1,75 @@
+-- Migration 0009: Species care sheet fields
+-- Adds habitat type, urticating hairs, venom potency, growth rate and origin
+-- to tarantula_species for the care sheet view
+
+ALTER TABLE spider_bot.tarantula_species
+    ADD COLUMN IF NOT EXISTS habitat_type     VARCHAR(20) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS urticating_hairs BOOLEAN     NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS venom_potency    VARCHAR(20) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS growth_rate      VARCHAR(20) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS origin           TEXT        NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN spider_bot.tarantula_species.habitat_type IS 'Terrestrial, Arboreal or Fossorial';
+COMMENT ON COLUMN spider_bot.tarantula_species.urticating_hairs IS 'True for New World species that kick urticating setae';
+COMMENT ON COLUMN spider_bot.tarantula_species.venom_potency IS 'Mild, Moderate or Strong';
+COMMENT ON COLUMN spider_bot.tarantula_species.growth_rate IS 'Slow, Medium or Fast';
+
+UPDATE spider_bot.tarantula_species AS ts
+SET habitat_type     = s.habitat_type,
+    urticating_hairs = s.urticating_hairs,
+    venom_potency    = s.venom_potency,
+    growth_rate      = s.growth_rate,
+    origin           = s.origin
+FROM (VALUES ('Brachypelma hamorii', 'Terrestrial', TRUE, 'Mild', 'Slow', 'Mexico'),
+             ('Grammostola pulchra', 'Terrestrial', TRUE, 'Mild', 'Slow', 'Brazil, Uruguay'),
+             ('Aphonopelma chalcodes', 'Terrestrial', TRUE, 'Mild', 'Slow', 'USA, Mexico'),
+             ('Tliltocatl albopilosus', 'Terrestrial', TRUE, 'Mild', 'Medium', 'Nicaragua, Costa Rica'),
+             ('Chromatopelma cyaneopubescens', 'Terrestrial', TRUE, 'Mild', 'Fast', 'Venezuela'),
+             ('Grammostola pulchripes', 'Terrestrial', TRUE, 'Mild', 'Medium', 'Paraguay, Argentina'),
+             ('Caribena versicolor', 'Arboreal', TRUE, 'Mild', 'Medium', 'Martinique'),
+             ('Grammostola rosea', 'Terrestrial', TRUE, 'Mild', 'Slow', 'Chile'),
+             ('Acanthoscurria geniculata', 'Terrestrial', TRUE, 'Mild', 'Fast', 'Brazil'),
+             ('Tliltocatl vagans', 'Terrestrial', TRUE, 'Mild', 'Medium', 'Mexico, Central America'),
+             ('Aphonopelma seemanni', 'Terrestrial', TRUE, 'Mild', 'Medium', 'Costa Rica'),
+             ('Brachypelma emilia', 'Terrestrial', TRUE, 'Mild', 'Slow', 'Mexico'),
+             ('Nhandu chromatus', 'Terrestrial', TRUE, 'Mild', 'Fast', 'Brazil'),
+             ('Psalmopoeus irminia', 'Arboreal', FALSE, 'Moderate', 'Fast', 'Venezuela'),
+             ('Lasiodora parahybana', 'Terrestrial', TRUE, 'Mild', 'Fast', 'Brazil'),
+             ('Eupalaestrus campestratus', 'Terrestrial', TRUE, 'Mild', 'Slow', 'Brazil, Paraguay, Argentina'),
+             ('Grammostola iheringi', 'Terrestrial', TRUE, 'Mild', 'Medium', 'Argentina, Brazil'),
+             ('Homoeomma chilensis', 'Terrestrial', TRUE, 'Mild', 'Slow', 'Chile'),
+             ('Thrixopelma cyaneolum', 'Terrestrial', TRUE, 'Mild', 'Medium', 'Peru'),
+             ('Tliltocatl verdezi', 'Terrestrial', TRUE, 'Mild', 'Medium', 'Mexico'),
+             ('Poecilotheria regalis', 'Arboreal', FALSE, 'Strong', 'Fast', 'India'),
+             ('Pterinochilus murinus', 'Fossorial', FALSE, 'Strong', 'Fast', 'East and Central Africa'),
+             ('Ceratogyrus marshalli', 'Fossorial', FALSE, 'Strong', 'Medium', 'Zimbabwe, Mozambique'),
+             ('Heteroscodra maculata', 'Arboreal', FALSE, 'Strong', 'Fast', 'Togo, Ghana'),
+             ('Cyriopagopus lividus', 'Fossorial', FALSE, 'Strong', 'Fast', 'Myanmar, Thailand'),
+             ('Avicularia avicularia', 'Arboreal', TRUE, 'Mild', 'Medium', 'Northern South America'),
+             ('Brachypelma boehmei', 'Terrestrial', TRUE, 'Mild', 'Medium', 'Mexico'),
+             ('Davus pentaloris', 'Terrestrial', TRUE, 'Mild', 'Fast', 'Guatemala, Mexico'),
+             ('Grammostola actaeon', 'Terrestrial', TRUE, 'Mild', 'Medium', 'Brazil'),
+             ('Harpactira pulchripes', 'Fossorial', FALSE, 'Strong', 'Medium', 'South Africa'),
+             ('Monocentropus balfouri', 'Terrestrial', FALSE, 'Moderate', 'Medium', 'Socotra, Yemen'),
+             ('Pamphobeteus sp. machala', 'Terrestrial', TRUE, 'Mild', 'Fast', 'Ecuador'),
+             ('Phormictopus sp. purple', 'Terrestrial', TRUE, 'Moderate', 'Fast', 'Dominican Republic'),
+             ('Xenesthis immanis', 'Terrestrial', TRUE, 'Mild', 'Fast', 'Colombia, Ecuador'),
+             ('Ybyrapora diversipes', 'Arboreal', TRUE, 'Mild', 'Medium', 'Brazil'),
+             ('Neoholothele incei', 'Fossorial', FALSE, 'Mild', 'Fast', 'Trinidad, Venezuela'),
+             ('Aphonopelma hentzi', 'Terrestrial', TRUE, 'Mild', 'Slow', 'USA'),
+             ('Euathlus sp. red', 'Terrestrial', TRUE, 'Mild', 'Slow', 'Chile'),
+             ('Homoeomma sp. blue', 'Terrestrial', TRUE, 'Mild', 'Medium', 'Peru'),
+             ('Thrixopelma ockerti', 'Terrestrial', TRUE, 'Mild', 'Medium', 'Peru'),
+             ('Haploclastus devamatha', 'Fossorial', FALSE, 'Strong', 'Medium', 'India'),
+             ('Chilobrachys fimbriatus', 'Fossorial', FALSE, 'Strong', 'Fast', 'India'),
+             ('Poecilotheria metallica', 'Arboreal', FALSE, 'Strong', 'Fast', 'India'),
+             ('Cyriopagopus sp. hati hati', 'Fossorial', FALSE, 'Strong', 'Fast', 'Malaysia'),
+             ('Omothymus violaceopes', 'Arboreal', FALSE, 'Strong', 'Fast', 'Malaysia, Singapore'),
+             ('Hapalopus sp. Colombia', 'Terrestrial', TRUE, 'Mild', 'Fast', 'Colombia'),
+             ('Cyriocosmus elegans', 'Terrestrial', TRUE, 'Mild', 'Medium', 'Trinidad, Venezuela'),
+             ('Neoholothele incei gold', 'Fossorial', FALSE, 'Mild', 'Fast', 'Trinidad, Venezuela'),
+             ('Homoeomma sp. peru', 'Terrestrial', TRUE, 'Mild', 'Medium', 'Peru'),
+             ('Kochiana brunnipes', 'Terrestrial', TRUE, 'Mild', 'Medium', 'Brazil'))
+         AS s(scientific_name, habitat_type, urticating_hairs, venom_potency, growth_rate, origin)
+WHERE ts.scientific_name = s.scientific_name
+  AND ts.habitat_type = '';
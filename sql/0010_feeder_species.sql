@@ -0,0 +1,66 @@
+-- Migration 0010: Feeder species
+-- Generalises cricket colonies and feedings to any feeder insect: adds a
+-- feeder_species catalogue with typical masses per size class, a species for
+-- each feeder colony, and prey type/size/count/pre-killed on feeding events.
+-- Feedings without a feeder colony are prey that was bought in.
+
+CREATE TABLE IF NOT EXISTS spider_bot.feeder_species
+(
+    id                 SERIAL PRIMARY KEY,
+    name               VARCHAR(50) NOT NULL UNIQUE,
+    scientific_name    TEXT,
+    pinhead_mass_grams DOUBLE PRECISION NOT NULL DEFAULT 0,
+    small_mass_grams   DOUBLE PRECISION NOT NULL DEFAULT 0,
+    medium_mass_grams  DOUBLE PRECISION NOT NULL DEFAULT 0,
+    large_mass_grams   DOUBLE PRECISION NOT NULL DEFAULT 0,
+    adult_mass_grams   DOUBLE PRECISION NOT NULL DEFAULT 0,
+    unit_cost          DOUBLE PRECISION NOT NULL DEFAULT 0
+);
+
+COMMENT ON COLUMN spider_bot.feeder_species.unit_cost IS 'Typical price per insect, used for cost estimates';
+
+INSERT INTO spider_bot.feeder_species (id, name, scientific_name, pinhead_mass_grams, small_mass_grams,
+                                       medium_mass_grams, large_mass_grams, adult_mass_grams, unit_cost)
+VALUES (1, 'Cricket', 'Acheta domesticus', 0.005, 0.03, 0.12, 0.30, 0.45, 0.10),
+       (2, 'Dubia roach', 'Blaptica dubia', 0.02, 0.10, 0.50, 1.50, 2.50, 0.35),
+       (3, 'Red runner roach', 'Shelfordella lateralis', 0.01, 0.05, 0.20, 0.50, 0.80, 0.15),
+       (4, 'Mealworm', 'Tenebrio molitor', 0.01, 0.03, 0.08, 0.12, 0.15, 0.02),
+       (5, 'Superworm', 'Zophobas morio', 0.05, 0.20, 0.50, 0.80, 1.00, 0.10),
+       (6, 'Fruit fly', 'Drosophila hydei', 0.001, 0.001, 0.002, 0.002, 0.002, 0.01),
+       (7, 'Moth', NULL, 0.01, 0.03, 0.05, 0.08, 0.10, 0.15)
+ON CONFLICT (id) DO NOTHING;
+
+SELECT setval('spider_bot.feeder_species_id_seq', (SELECT MAX(id) FROM spider_bot.feeder_species));
+
+ALTER TABLE spider_bot.cricket_colonies
+    ADD COLUMN IF NOT EXISTS feeder_species_id INTEGER NOT NULL DEFAULT 1 REFERENCES spider_bot.feeder_species (id);
+
+CREATE INDEX IF NOT EXISTS idx_cricket_colonies_feeder_species ON spider_bot.cricket_colonies (feeder_species_id);
+
+DO
+$$
+    BEGIN
+        IF EXISTS (SELECT 1
+                   FROM information_schema.columns
+                   WHERE table_schema = 'spider_bot'
+                     AND table_name = 'feeding_events'
+                     AND column_name = 'number_of_crickets') THEN
+            ALTER TABLE spider_bot.feeding_events
+                RENAME COLUMN number_of_crickets TO prey_count;
+        END IF;
+    END
+$$;
+
+ALTER TABLE spider_bot.feeding_events
+    ADD COLUMN IF NOT EXISTS feeder_species_id INTEGER NOT NULL DEFAULT 1 REFERENCES spider_bot.feeder_species (id),
+    ADD COLUMN IF NOT EXISTS prey_size         VARCHAR(20),
+    ADD COLUMN IF NOT EXISTS pre_killed        BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS prey_mass_grams   DOUBLE PRECISION;
+
+CREATE INDEX IF NOT EXISTS idx_feeding_events_feeder_species ON spider_bot.feeding_events (feeder_species_id);
+
+-- Existing feedings were medium crickets from a colony
+UPDATE spider_bot.feeding_events
+SET prey_size       = 'Medium',
+    prey_mass_grams = prey_count * 0.12
+WHERE prey_size IS NULL;
@@ -0,0 +1,50 @@
+-- Migration 0011: Feeder breeding cycles
+-- Adds development times to feeder_species and a feeder_clutches table that
+-- tracks egg-laying substrate in/out, hatching and growth of each cohort
+
+ALTER TABLE spider_bot.feeder_species
+    ADD COLUMN IF NOT EXISTS incubation_days     INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS days_to_small       INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS days_to_medium      INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS days_to_large       INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS days_to_adult       INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS typical_hatch_count INTEGER NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN spider_bot.feeder_species.incubation_days IS 'Days from laying to hatching (gestation for live-bearing roaches)';
+COMMENT ON COLUMN spider_bot.feeder_species.days_to_small IS 'Days after hatching until a cohort reaches the Small size class';
+
+-- Approximate values at 28-30 °C
+UPDATE spider_bot.feeder_species AS fs
+SET incubation_days     = v.incubation_days,
+    days_to_small       = v.days_to_small,
+    days_to_medium      = v.days_to_medium,
+    days_to_large       = v.days_to_large,
+    days_to_adult       = v.days_to_adult,
+    typical_hatch_count = v.typical_hatch_count
+FROM (VALUES ('Cricket', 12, 14, 28, 42, 56, 300),
+             ('Dubia roach', 30, 30, 90, 150, 180, 25),
+             ('Red runner roach', 30, 20, 45, 70, 90, 30),
+             ('Mealworm', 10, 20, 45, 70, 90, 200),
+             ('Superworm', 10, 30, 60, 100, 140, 100),
+             ('Fruit fly', 1, 4, 8, 12, 18, 300),
+             ('Moth', 7, 10, 20, 30, 45, 200))
+         AS v(name, incubation_days, days_to_small, days_to_medium, days_to_large, days_to_adult, typical_hatch_count)
+WHERE fs.name = v.name;
+
+CREATE TABLE IF NOT EXISTS spider_bot.feeder_clutches
+(
+    id                 SERIAL PRIMARY KEY,
+    colony_id          INTEGER NOT NULL REFERENCES spider_bot.cricket_colonies (id),
+    substrate_in_date  TIMESTAMP NOT NULL,
+    substrate_out_date TIMESTAMP,
+    hatch_date         TIMESTAMP,
+    hatchling_count    INTEGER   NOT NULL DEFAULT 0,
+    merged_date        TIMESTAMP,
+    notes              TEXT,
+    user_id            BIGINT REFERENCES spider_bot.telegram_users (telegram_id),
+    created_at         TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_feeder_clutches_colony ON spider_bot.feeder_clutches (colony_id);
+CREATE INDEX IF NOT EXISTS idx_feeder_clutches_merged ON spider_bot.feeder_clutches (merged_date);
+CREATE INDEX IF NOT EXISTS idx_feeder_clutches_user ON spider_bot.feeder_clutches (user_id);
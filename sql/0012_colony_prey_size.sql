@@ -0,0 +1,8 @@
+-- Migration 0012: Feeder colony size class
+-- Records which size class a feeder colony holds so demand forecasts can
+-- match stock to what each tarantula eats. Empty means a mixed-size colony.
+
+ALTER TABLE spider_bot.cricket_colonies
+    ADD COLUMN IF NOT EXISTS prey_size VARCHAR(20);
+
+COMMENT ON COLUMN spider_bot.cricket_colonies.prey_size IS 'Size class held (Pinhead, Small, Medium, Large, Adult), empty for mixed sizes';
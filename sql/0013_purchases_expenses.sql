@@ -0,0 +1,43 @@
+-- Migration 0013: Feeder purchases and expenses
+-- Records what was actually paid for feeders and other keeping costs, so
+-- reports price prey from real purchases instead of a typical unit cost
+
+CREATE TABLE IF NOT EXISTS spider_bot.feeder_purchases
+(
+    id                SERIAL PRIMARY KEY,
+    feeder_species_id INTEGER        NOT NULL REFERENCES spider_bot.feeder_species (id),
+    prey_size         VARCHAR(20),
+    quantity          INTEGER        NOT NULL CHECK (quantity > 0),
+    price             DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    shipping          DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    supplier          VARCHAR(100),
+    purchase_date     TIMESTAMP      NOT NULL,
+    colony_id         INTEGER REFERENCES spider_bot.cricket_colonies (id),
+    notes             TEXT,
+    user_id           BIGINT         NOT NULL REFERENCES spider_bot.telegram_users (telegram_id),
+    created_at        TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_feeder_purchases_species ON spider_bot.feeder_purchases (feeder_species_id);
+CREATE INDEX IF NOT EXISTS idx_feeder_purchases_date ON spider_bot.feeder_purchases (purchase_date);
+CREATE INDEX IF NOT EXISTS idx_feeder_purchases_colony ON spider_bot.feeder_purchases (colony_id);
+CREATE INDEX IF NOT EXISTS idx_feeder_purchases_user ON spider_bot.feeder_purchases (user_id);
+
+CREATE TABLE IF NOT EXISTS spider_bot.expenses
+(
+    id           SERIAL PRIMARY KEY,
+    category     VARCHAR(50)    NOT NULL,
+    description  TEXT,
+    amount       DECIMAL(10, 2) NOT NULL,
+    expense_date TIMESTAMP      NOT NULL,
+    tarantula_id INTEGER REFERENCES spider_bot.tarantulas (id),
+    user_id      BIGINT         NOT NULL REFERENCES spider_bot.telegram_users (telegram_id),
+    created_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON COLUMN spider_bot.expenses.category IS 'Enclosure, Substrate, Vet, Animal purchase, Equipment or Other';
+
+CREATE INDEX IF NOT EXISTS idx_expenses_category ON spider_bot.expenses (category);
+CREATE INDEX IF NOT EXISTS idx_expenses_date ON spider_bot.expenses (expense_date);
+CREATE INDEX IF NOT EXISTS idx_expenses_tarantula ON spider_bot.expenses (tarantula_id);
+CREATE INDEX IF NOT EXISTS idx_expenses_user ON spider_bot.expenses (user_id);
@@ -0,0 +1,44 @@
+-- Migration 0014: Communal colony census
+-- Adds headcounts of tarantula colonies, per-member events (seen, molted,
+-- missing, cannibalized, removed) and when each member was last seen
+
+ALTER TABLE spider_bot.tarantula_colony_members
+    ADD COLUMN IF NOT EXISTS last_seen_date TIMESTAMP;
+
+CREATE TABLE IF NOT EXISTS spider_bot.colony_census
+(
+    id             SERIAL PRIMARY KEY,
+    colony_id      INTEGER   NOT NULL REFERENCES spider_bot.tarantula_colonies (id),
+    census_date    TIMESTAMP NOT NULL,
+    visible_count  INTEGER   NOT NULL DEFAULT 0,
+    expected_count INTEGER   NOT NULL DEFAULT 0,
+    notes          TEXT,
+    user_id        BIGINT    NOT NULL REFERENCES spider_bot.telegram_users (telegram_id),
+    created_at     TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON COLUMN spider_bot.colony_census.expected_count IS 'Active members at the time of the census';
+
+CREATE INDEX IF NOT EXISTS idx_colony_census_colony ON spider_bot.colony_census (colony_id);
+CREATE INDEX IF NOT EXISTS idx_colony_census_date ON spider_bot.colony_census (census_date);
+CREATE INDEX IF NOT EXISTS idx_colony_census_user ON spider_bot.colony_census (user_id);
+
+CREATE TABLE IF NOT EXISTS spider_bot.colony_member_events
+(
+    id           SERIAL PRIMARY KEY,
+    colony_id    INTEGER     NOT NULL REFERENCES spider_bot.tarantula_colonies (id),
+    member_id    INTEGER     NOT NULL REFERENCES spider_bot.tarantula_colony_members (id),
+    tarantula_id INTEGER     NOT NULL REFERENCES spider_bot.tarantulas (id),
+    event_type   VARCHAR(20) NOT NULL,
+    event_date   TIMESTAMP   NOT NULL,
+    notes        TEXT,
+    user_id      BIGINT      NOT NULL REFERENCES spider_bot.telegram_users (telegram_id),
+    created_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON COLUMN spider_bot.colony_member_events.event_type IS 'Seen, Molted, Missing, Cannibalized or Removed';
+
+CREATE INDEX IF NOT EXISTS idx_colony_member_events_colony ON spider_bot.colony_member_events (colony_id);
+CREATE INDEX IF NOT EXISTS idx_colony_member_events_member ON spider_bot.colony_member_events (member_id);
+CREATE INDEX IF NOT EXISTS idx_colony_member_events_date ON spider_bot.colony_member_events (event_date);
+CREATE INDEX IF NOT EXISTS idx_colony_member_events_user ON spider_bot.colony_member_events (user_id);
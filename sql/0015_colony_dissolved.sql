@@ -0,0 +1,7 @@
+-- Migration 0015: Dissolved tarantula colonies
+-- A colony merged into another keeps its history but is no longer listed
+
+ALTER TABLE spider_bot.tarantula_colonies
+    ADD COLUMN IF NOT EXISTS dissolved_date TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_tarantula_colonies_dissolved ON spider_bot.tarantula_colonies (dissolved_date);
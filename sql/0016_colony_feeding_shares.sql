@@ -0,0 +1,21 @@
+-- Migration 0016: Colony feeding shares
+-- Splits each colony feeding between the members present, weighted by size
+
+CREATE TABLE IF NOT EXISTS spider_bot.colony_feeding_shares
+(
+    id               SERIAL PRIMARY KEY,
+    feeding_event_id INTEGER   NOT NULL REFERENCES spider_bot.feeding_events (id),
+    colony_id        INTEGER   NOT NULL REFERENCES spider_bot.tarantula_colonies (id),
+    tarantula_id     INTEGER   NOT NULL REFERENCES spider_bot.tarantulas (id),
+    feeding_date     TIMESTAMP NOT NULL,
+    prey_count       DECIMAL(8, 3) NOT NULL DEFAULT 0,
+    prey_mass_grams  DECIMAL(8, 3) NOT NULL DEFAULT 0,
+    user_id          BIGINT    NOT NULL REFERENCES spider_bot.telegram_users (telegram_id),
+    created_at       TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON COLUMN spider_bot.colony_feeding_shares.prey_count IS 'Fraction of the feeding''s prey credited to this member';
+
+CREATE INDEX IF NOT EXISTS idx_colony_feeding_shares_event ON spider_bot.colony_feeding_shares (feeding_event_id);
+CREATE INDEX IF NOT EXISTS idx_colony_feeding_shares_tarantula ON spider_bot.colony_feeding_shares (tarantula_id);
+CREATE INDEX IF NOT EXISTS idx_colony_feeding_shares_date ON spider_bot.colony_feeding_shares (feeding_date);
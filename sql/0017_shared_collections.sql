@@ -0,0 +1,47 @@
+-- Migration 0017: Shared collections
+-- Lets a keeper invite others into their collection as editors or viewers,
+-- and records who logged each feeding and molt
+
+ALTER TABLE spider_bot.telegram_users
+    ADD COLUMN IF NOT EXISTS active_collection BIGINT REFERENCES spider_bot.telegram_users (telegram_id);
+
+COMMENT ON COLUMN spider_bot.telegram_users.active_collection IS 'Owner whose collection the user is working in; NULL for their own';
+
+CREATE TABLE IF NOT EXISTS spider_bot.collection_members
+(
+    id         SERIAL PRIMARY KEY,
+    owner_id   BIGINT      NOT NULL REFERENCES spider_bot.telegram_users (telegram_id),
+    member_id  BIGINT      NOT NULL REFERENCES spider_bot.telegram_users (telegram_id),
+    role       VARCHAR(10) NOT NULL,
+    on_duty    BOOLEAN   DEFAULT FALSE,
+    joined_at  TIMESTAMP   NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON COLUMN spider_bot.collection_members.role IS 'editor or viewer; the owner has no member row';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_collection_member ON spider_bot.collection_members (owner_id, member_id);
+CREATE INDEX IF NOT EXISTS idx_collection_members_member ON spider_bot.collection_members (member_id);
+
+CREATE TABLE IF NOT EXISTS spider_bot.collection_invites
+(
+    id         SERIAL PRIMARY KEY,
+    code       VARCHAR(32) NOT NULL UNIQUE,
+    owner_id   BIGINT      NOT NULL REFERENCES spider_bot.telegram_users (telegram_id),
+    role       VARCHAR(10) NOT NULL,
+    expires_at TIMESTAMP   NOT NULL,
+    used_by    BIGINT REFERENCES spider_bot.telegram_users (telegram_id),
+    used_at    TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_collection_invites_owner ON spider_bot.collection_invites (owner_id);
+
+ALTER TABLE spider_bot.feeding_events
+    ADD COLUMN IF NOT EXISTS recorded_by BIGINT REFERENCES spider_bot.telegram_users (telegram_id);
+
+ALTER TABLE spider_bot.molt_records
+    ADD COLUMN IF NOT EXISTS recorded_by BIGINT REFERENCES spider_bot.telegram_users (telegram_id);
+
+CREATE INDEX IF NOT EXISTS idx_feeding_events_recorded_by ON spider_bot.feeding_events (recorded_by);
+CREATE INDEX IF NOT EXISTS idx_molt_records_recorded_by ON spider_bot.molt_records (recorded_by);
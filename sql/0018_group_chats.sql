@@ -0,0 +1,18 @@
+-- Migration 0018: Group chats
+-- Binds Telegram groups to a shared collection for group-wide care and reminders
+
+CREATE TABLE IF NOT EXISTS spider_bot.group_chats
+(
+    chat_id    BIGINT PRIMARY KEY,
+    owner_id   BIGINT      NOT NULL REFERENCES spider_bot.telegram_users (telegram_id),
+    title      VARCHAR(255),
+    role       VARCHAR(10) NOT NULL,
+    reminders  BOOLEAN   DEFAULT TRUE,
+    bound_by   BIGINT      NOT NULL REFERENCES spider_bot.telegram_users (telegram_id),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON COLUMN spider_bot.group_chats.role IS 'Role for group members who were not invited to the collection: editor or viewer';
+COMMENT ON COLUMN spider_bot.group_chats.reminders IS 'Send the collection''s reminders and alerts to the group instead of the person on duty';
+
+CREATE INDEX IF NOT EXISTS idx_group_chats_owner ON spider_bot.group_chats (owner_id);
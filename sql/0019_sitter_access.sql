@@ -0,0 +1,32 @@
+-- Migration 0019: Pet-sitter access
+-- Time-limited sitter memberships, and watering and observations logged between feedings
+
+ALTER TABLE spider_bot.collection_members
+    ADD COLUMN IF NOT EXISTS expires_at     TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS digest_sent_at TIMESTAMP;
+
+COMMENT ON COLUMN spider_bot.collection_members.expires_at IS 'When sitter access ends; NULL for editors and viewers';
+COMMENT ON COLUMN spider_bot.collection_members.digest_sent_at IS 'When the owner was sent the digest of what the sitter logged';
+
+CREATE INDEX IF NOT EXISTS idx_collection_members_expires_at ON spider_bot.collection_members (expires_at);
+
+ALTER TABLE spider_bot.collection_invites
+    ADD COLUMN IF NOT EXISTS access_until TIMESTAMP;
+
+CREATE TABLE IF NOT EXISTS spider_bot.care_logs
+(
+    id           SERIAL PRIMARY KEY,
+    tarantula_id INTEGER     NOT NULL REFERENCES spider_bot.tarantulas (id) ON DELETE CASCADE,
+    kind         VARCHAR(20) NOT NULL,
+    notes        TEXT,
+    logged_at    TIMESTAMP   NOT NULL,
+    created_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    user_id      BIGINT      NOT NULL REFERENCES spider_bot.telegram_users (telegram_id),
+    recorded_by  BIGINT REFERENCES spider_bot.telegram_users (telegram_id)
+);
+
+COMMENT ON COLUMN spider_bot.care_logs.kind IS 'water or observation';
+
+CREATE INDEX IF NOT EXISTS idx_care_logs_tarantula ON spider_bot.care_logs (tarantula_id);
+CREATE INDEX IF NOT EXISTS idx_care_logs_user ON spider_bot.care_logs (user_id);
+CREATE INDEX IF NOT EXISTS idx_care_logs_recorded_by ON spider_bot.care_logs (recorded_by);
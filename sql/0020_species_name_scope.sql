@@ -0,0 +1,16 @@
+-- Migration 0020: Scope species name uniqueness
+-- Keepers' private species are invisible to each other, so a scientific name
+-- only has to be unique in the shared catalogue and within each keeper's own
+-- species. A keeper may add a species another keeper keeps privately.
+
+ALTER TABLE spider_bot.tarantula_species
+    DROP CONSTRAINT IF EXISTS tarantula_species_scientific_name_key,
+    DROP CONSTRAINT IF EXISTS uni_tarantula_species_scientific_name;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tarantula_species_approved_name
+    ON spider_bot.tarantula_species (LOWER(scientific_name))
+    WHERE status = 'approved';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tarantula_species_owner_name
+    ON spider_bot.tarantula_species (owner_user_id, LOWER(scientific_name))
+    WHERE owner_user_id IS NOT NULL;
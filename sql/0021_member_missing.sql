@@ -0,0 +1,5 @@
+-- Migration 0021: Missing colony members
+-- A member reported missing stays flagged in census alerts until it's seen again
+
+ALTER TABLE spider_bot.tarantula_colony_members
+    ADD COLUMN IF NOT EXISTS missing_since TIMESTAMP;
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"log/slog"
 	"sync"
+	"tarantulago/config"
+	"tarantulago/metrics"
 	"tarantulago/models"
 	"time"
 
@@ -19,83 +21,89 @@ type TarantulaBot struct {
 	ctx           context.Context
 	cancelFunc    context.CancelFunc
 	sessions      *SessionManager
+	forms         map[FormState]*form
+	undos         *undoLog
+	admins        map[int64]bool
+	handlers      handlerTracker
+	features      config.Features
 }
 
-func NewTarantulaBot(token string, database TarantulaOperations) (*TarantulaBot, error) {
+// NewTarantulaBot builds the bot on the process context. Handlers keep their
+// context through a shutdown signal so they can finish; it's cancelled once
+// Shutdown is done waiting for them.
+func NewTarantulaBot(ctx context.Context, cfg *config.Config, database TarantulaOperations) (*TarantulaBot, error) {
 	bot, err := tele.NewBot(tele.Settings{
-		Token:  token,
-		Poller: &tele.LongPoller{Timeout: 10 * time.Second},
-		OnError: func(e error, ctx tele.Context) {
-			if err := sendError(ctx, e.Error()); err != nil {
-				slog.Error("Failed to send error message", "error", err)
-			}
-		},
+		Token:   cfg.TelegramToken,
+		Poller:  NewPoller(cfg.Updates),
+		OnError: handleError,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create bot: %w", err)
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
 	tarantulaBot := TarantulaBot{
 		bot:           bot,
-		notifications: NewNotificationSystem(bot, database),
+		notifications: NewNotificationSystem(ctx, bot, database, cfg.Notifications.TickInterval, cfg.Features.MoltPredictions),
 		db:            database,
 		ctx:           ctx,
 		cancelFunc:    cancel,
 		sessions:      NewSessionManager(),
+		undos:         newUndoLog(),
+		admins:        make(map[int64]bool, len(cfg.AdminUserIDs)),
+		features:      cfg.Features,
 	}
+	for _, id := range cfg.AdminUserIDs {
+		tarantulaBot.admins[id] = true
+	}
+	tarantulaBot.forms = tarantulaBot.formDefinitions()
 
+	metrics.RegisterActiveSessions(tarantulaBot.sessions.InForm)
 	tarantulaBot.setupHandlers()
 	return &tarantulaBot, nil
 }
 
-func (t *TarantulaBot) Start() {
-	t.notifications.Start()
-	t.bot.Start()
-}
-
-func (t *TarantulaBot) Stop() {
-	t.cancelFunc()
+func (t *TarantulaBot) isAdmin(userID int64) bool {
+	return t.admins[userID]
 }
 
-func (t *TarantulaBot) handleTarantulaDetailsEnhanced(c tele.Context) error {
-
-	callback := parseCallback(c.Callback().Data)
-
-	tarantula, err := t.db.GetTarantulaWithSpeciesData(t.ctx, int32(callback.TarantulaID), c.Sender().ID)
+func (t *TarantulaBot) handleTarantulaDetailsEnhanced(c tele.Context, tarantulaID int) error {
+	tarantula, err := t.db.GetTarantulaWithSpeciesData(t.reqCtx(c), int32(tarantulaID), ownerID(c))
 	if err != nil {
-		return SendError(c, fmt.Sprintf("Failed to get tarantula details: %v", err))
+		return fmt.Errorf("failed to get tarantula details: %w", err)
 	}
 
-	photos, err := t.db.GetTarantulaPhotos(t.ctx, int32(callback.TarantulaID), c.Sender().ID, 3)
+	photos, err := t.db.GetTarantulaPhotos(t.reqCtx(c), int32(tarantulaID), ownerID(c), 3)
 	if err != nil {
 		photos = nil
 	}
 
-	msg := FormatTarantulaDetailsEnhanced(tarantula, photos, nil)
+	lastFeeding, err := t.db.GetLastFeeding(t.reqCtx(c), int32(tarantulaID), ownerID(c))
+	if err != nil {
+		slog.ErrorContext(t.reqCtx(c), "Failed to get last feeding", "tarantula_id", tarantulaID, "error", err)
+	}
+
+	msg := FormatTarantulaDetailsEnhanced(tarantula, photos, lastFeeding, nil)
 
-	markup := BuildTarantulaActionsMarkup(int32(callback.TarantulaID))
+	markup := BuildTarantulaActionsMarkup(int32(tarantulaID))
 
 	return c.Send(msg, markup, tele.ModeMarkdown)
 }
 
-func (t *TarantulaBot) handleFeedingIntelligence(c tele.Context) error {
-
-	callback := parseCallback(c.Callback().Data)
-
-	tarantula, err := t.db.GetTarantulaWithSpeciesData(t.ctx, int32(callback.TarantulaID), c.Sender().ID)
+func (t *TarantulaBot) handleFeedingIntelligence(c tele.Context, tarantulaID int) error {
+	tarantula, err := t.db.GetTarantulaWithSpeciesData(t.reqCtx(c), int32(tarantulaID), ownerID(c))
 	if err != nil {
-		return SendError(c, fmt.Sprintf("Failed to get tarantula data: %v", err))
+		return fmt.Errorf("failed to get tarantula data: %w", err)
 	}
 
-	recentFeedings, err := t.db.GetRecentFeedingRecords(t.ctx, c.Sender().ID, 5)
+	recentFeedings, err := t.db.GetRecentFeedingRecords(t.reqCtx(c), ownerID(c), 5)
 	if err != nil {
-		return SendError(c, fmt.Sprintf("Failed to get feeding history: %v", err))
+		return fmt.Errorf("failed to get feeding history: %w", err)
 	}
 
 	daysSinceFeeding := 999.0
 	for _, feeding := range recentFeedings {
-		if feeding.TarantulaID != nil && *feeding.TarantulaID == callback.TarantulaID {
+		if feeding.TarantulaID != nil && *feeding.TarantulaID == tarantulaID {
 			daysSinceFeeding = time.Since(feeding.FeedingDate).Hours() / 24
 			break
 		}
@@ -114,18 +122,15 @@ func (t *TarantulaBot) handleFeedingIntelligence(c tele.Context) error {
 	return c.Send(msg, tele.ModeMarkdown)
 }
 
-func (t *TarantulaBot) handleIndividualMoltPrediction(c tele.Context) error {
-
-	callback := parseCallback(c.Callback().Data)
-
-	predictions, err := t.db.GetAllMoltPredictions(t.ctx, c.Sender().ID)
+func (t *TarantulaBot) handleIndividualMoltPrediction(c tele.Context, tarantulaID int) error {
+	predictions, err := t.db.GetAllMoltPredictions(t.reqCtx(c), ownerID(c))
 	if err != nil {
-		return SendError(c, fmt.Sprintf("Failed to get molt predictions: %v", err))
+		return fmt.Errorf("failed to get molt predictions: %w", err)
 	}
 
 	var targetPrediction *models.MoltPrediction
 	for _, pred := range predictions {
-		if pred.TarantulaID == int32(callback.TarantulaID) {
+		if pred.TarantulaID == int32(tarantulaID) {
 			targetPrediction = &pred
 			break
 		}
@@ -143,9 +148,9 @@ func (t *TarantulaBot) handleIndividualMoltPrediction(c tele.Context) error {
 
 func (t *TarantulaBot) handleMoltPredictionsOverview(c tele.Context) error {
 
-	predictions, err := t.db.GetAllMoltPredictions(t.ctx, c.Sender().ID)
+	predictions, err := t.db.GetAllMoltPredictions(t.reqCtx(c), ownerID(c))
 	if err != nil {
-		return SendError(c, fmt.Sprintf("Failed to get molt predictions: %v", err))
+		return fmt.Errorf("failed to get molt predictions: %w", err)
 	}
 
 	if len(predictions) == 0 {
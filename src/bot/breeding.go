@@ -0,0 +1,125 @@
+package bot
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"tarantulago/models"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+const defaultProjectionWeeks = 4
+
+func (t *TarantulaBot) handleBreedingOverview(c tele.Context, weeks int) error {
+	userID := ownerID(c)
+
+	colonies, err := t.db.GetColonyStatus(t.reqCtx(c), userID)
+	if err != nil {
+		return fmt.Errorf("failed to get feeder colonies: %w", err)
+	}
+	if len(colonies) == 0 {
+		return SendInfo(c, "Add a feeder colony before tracking breeding.")
+	}
+	projection, err := t.db.GetFeederProjection(t.reqCtx(c), userID, weeks)
+	if err != nil {
+		return fmt.Errorf("failed to project feeders: %w", err)
+	}
+
+	var rows [][]tele.InlineButton
+	for _, cp := range projection.Clutches {
+		clutch := cp.Clutch
+		label := fmt.Sprintf("#%d %s", clutch.ID, clutch.Colony.ColonyName)
+		switch clutch.Stage() {
+		case models.ClutchStageLaying:
+			rows = append(rows, []tele.InlineButton{clutchSubstrateOutAction.Button("📤 Substrate out: "+label, int32(clutch.ID))})
+		case models.ClutchStageIncubating:
+			rows = append(rows, []tele.InlineButton{clutchHatchAction.Button("🐣 Hatched: "+label, int32(clutch.ID))})
+		case models.ClutchStageGrowing:
+			rows = append(rows, []tele.InlineButton{clutchMergeAction.Button("✅ Add to colony: "+label, int32(clutch.ID))})
+		}
+	}
+	for _, colony := range colonies {
+		rows = append(rows, []tele.InlineButton{clutchStartAction.Button("🥚 Substrate in: "+colony.ColonyName, colony.ID)})
+	}
+	var horizons []tele.InlineButton
+	for _, w := range []int{2, 4, 8} {
+		horizons = append(horizons, breedingAction.Button(fmt.Sprintf("📈 %d weeks", w), w))
+	}
+	rows = append(rows, horizons)
+
+	return c.Send(FormatFeederProjection(*projection), &tele.ReplyMarkup{InlineKeyboard: rows}, tele.ModeMarkdown)
+}
+
+func (t *TarantulaBot) handleClutchStart(c tele.Context, colonyID int32) error {
+	if _, err := t.db.StartClutch(t.reqCtx(c), colonyID, ownerID(c)); err != nil {
+		return fmt.Errorf("failed to start clutch: %w", err)
+	}
+	if err := sendSuccess(c, "Egg-laying substrate recorded. Take it out after about a week to incubate."); err != nil {
+		return err
+	}
+	return t.handleBreedingOverview(c, defaultProjectionWeeks)
+}
+
+func (t *TarantulaBot) handleClutchSubstrateOut(c tele.Context, clutchID int32) error {
+	if err := t.db.RemoveClutchSubstrate(t.reqCtx(c), clutchID, ownerID(c)); err != nil {
+		return fmt.Errorf("failed to update clutch: %w", err)
+	}
+	if err := sendSuccess(c, "Substrate moved to incubation."); err != nil {
+		return err
+	}
+	return t.handleBreedingOverview(c, defaultProjectionWeeks)
+}
+
+func (t *TarantulaBot) handleClutchHatched(c tele.Context, clutchID int32) error {
+	session := t.sessions.GetSession(sessionKey(c))
+	session.reset()
+	session.CurrentState = StateRecordingHatch
+	session.CurrentField = FieldHatchCount
+	session.SelectedClutchID = int(clutchID)
+	t.sessions.UpdateSession(sessionKey(c), session)
+
+	return c.Send("🐣 Roughly how many hatchlings are there? (or 'skip' if you can't tell)")
+}
+
+func (t *TarantulaBot) handleHatchCountInput(c tele.Context, session *UserSession) error {
+	count := 0
+	text := strings.TrimSpace(c.Text())
+	if !strings.EqualFold(text, "skip") {
+		n, err := strconv.Atoi(text)
+		if err != nil || n < 0 {
+			return c.Send("Please enter the number of hatchlings, or 'skip'")
+		}
+		count = n
+	}
+
+	clutchID := int32(session.SelectedClutchID)
+	session.reset()
+	t.sessions.UpdateSession(sessionKey(c), session)
+
+	if err := t.db.RecordClutchHatch(t.reqCtx(c), clutchID, ownerID(c), count); err != nil {
+		return fmt.Errorf("failed to record hatch: %w", err)
+	}
+	if err := sendSuccess(c, "Hatch recorded!"); err != nil {
+		return err
+	}
+	return t.handleBreedingOverview(c, defaultProjectionWeeks)
+}
+
+func (t *TarantulaBot) handleClutchMerge(c tele.Context, clutchID int32) error {
+	if err := t.db.MergeClutch(t.reqCtx(c), clutchID, ownerID(c)); err != nil {
+		return fmt.Errorf("failed to add clutch to colony: %w", err)
+	}
+	if err := sendSuccess(c, "Cohort added to the colony count."); err != nil {
+		return err
+	}
+	return t.handleBreedingOverview(c, defaultProjectionWeeks)
+}
+
+func (t *TarantulaBot) handleFeederForecast(c tele.Context) error {
+	forecast, err := t.db.GetFeederForecast(t.reqCtx(c), ownerID(c))
+	if err != nil {
+		return fmt.Errorf("failed to forecast feeders: %w", err)
+	}
+	return c.Send(FormatFeederForecast(*forecast), tele.ModeMarkdown)
+}
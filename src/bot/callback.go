@@ -5,229 +5,287 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"tarantulago/models"
 	"time"
 
 	tele "gopkg.in/telebot.v4"
 )
 
-type CallbackData struct {
-	Action      string
-	TarantulaID int
-}
-
-func parseCallback(data string) CallbackData {
-	parts := strings.Split(data, ":")
-	if len(parts) != 2 {
-		return CallbackData{}
-	}
-	id, _ := strconv.Atoi(parts[1])
-	return CallbackData{
-		Action:      parts[0],
-		TarantulaID: id,
-	}
-}
+// Inline button actions. Names are part of the payload, so keep them short.
+var (
+	// Forms
+	formChoiceAction = newAction2[TarantulaFormField, string]("form_choice")
+	formBackAction   = newAction1[TarantulaFormField]("form_back")
+	formSkipAction   = newAction1[TarantulaFormField]("form_skip")
+	formCancelAction = newAction0("form_cancel")
+	formSaveAction   = newAction0("form_save")
+
+	// Tarantulas
+	selectAction         = newAction1[int]("select")
+	feedAction           = newAction1[int]("feed")
+	feedSchedulerAction  = newAction1[int]("feed_scheduler")
+	moltAction           = newAction1[int]("molt")
+	weightAction         = newAction1[int]("weight")
+	weightHistoryAction  = newAction1[int32]("weight_history")
+	addPhotoAction       = newAction1[int32]("add_photo")
+	viewPhotosAction     = newAction1[int32]("view_photos")
+	intelAction          = newAction1[int]("intel")
+	moltPredictionAction = newAction1[int]("molt_pred")
+	careAction           = newAction1[int32]("care")
+	careWaterAction      = newAction1[int]("carelog_water")
+	careNoteAction       = newAction1[int]("carelog_note")
+	quickFeedAction      = newAction1[int32]("quick_feed")
+	backToListAction     = newAction0("back_to_list")
+	undoAction           = newAction1[int64]("undo")
+
+	// Feeding round
+	roundStartAction   = newAction0("round_start")
+	roundCountAction   = newAction2[int32, int]("round_count") // tarantula, prey
+	roundOutcomeAction = newAction2[int32, models.FeedingStatusEnum]("round_outcome")
+	roundSkipAction    = newAction1[int32]("round_skip")
+	roundEndAction     = newAction0("round_end")
+
+	// Main menu and feeding dashboard
+	quickActionsAction     = newAction0("quick_actions")
+	feedingHistoryAction   = newAction0("feeding_history")
+	feedingDashboardAction = newAction0("feeding_dashboard")
+	backToMainAction       = newAction0("back_to_main")
+
+	// Notification settings
+	setNotificationTimeAction   = newAction0("set_notification_time")
+	setFeedingReminderAction    = newAction0("set_feeding_reminder")
+	setMoltPredictionDaysAction = newAction0("set_molt_prediction_days")
+	setPostMoltMuteDaysAction   = newAction0("set_post_molt_mute_days")
+	toggleNotificationsAction   = newAction0("toggle_notifications")
+	toggleMoltPredictionsAction = newAction0("toggle_molt_predictions")
+	pauseNotificationsAction    = newAction1[int]("pause_notifications") // hours, 0 for indefinitely
+	unpauseNotificationsAction  = newAction0("unpause_notifications")
+
+	// Tarantula colonies
+	colonyDetailsAction        = newAction1[int32]("colony_details")
+	colonyBulkAddAction        = newAction1[int32]("colony_bulk_add")
+	colonyBulkRemoveAction     = newAction1[int32]("colony_bulk_remove")
+	colonySplitAction          = newAction1[int32]("colony_split")
+	colonyMergeAction          = newAction1[int32]("colony_merge")
+	mergeColoniesAction        = newAction2[int32, int32]("merge_colony") // target, source
+	memberPickAction           = newAction1[int32]("member_pick")
+	memberPickDoneAction       = newAction0("member_pick_done")
+	memberPickCancelAction     = newAction0("member_pick_cancel")
+	splitEnclosureAction       = newAction1[int]("split_enclosure")
+	colonyCensusAction         = newAction1[int32]("colony_census")
+	colonyEventAction          = newAction1[int32]("colony_event")
+	memberEventAction          = newAction1[int]("member_event")
+	memberEventTypeAction      = newAction1[string]("member_event_type")
+	selectColonyForAddAction   = newAction1[int32]("select_colony_for_add")
+	addTarantulaToColonyAction = newAction1[int32]("add_tarantula_to_colony")
+	feedColonyAction           = newAction1[int32]("feed_colony")
+	quickFeedColonyAction      = newAction1[int32]("quick_feed_colony")
+
+	// Colony maintenance
+	maintainSelectAction  = newAction1[int]("colony_maintain_select")
+	maintainRecordAction  = newAction2[int32, int32]("colony_maintain_record") // colony, maintenance type
+	maintainHistoryAction = newAction1[int]("colony_maintain_history")
+	maintainBackAction    = newAction0("colony_maintain_back")
+
+	// Prey and feeder colonies
+	preyTypeAction           = newAction1[int]("prey_type")
+	preySizeAction           = newAction1[string]("prey_size")
+	preyKilledAction         = newAction1[bool]("prey_killed")
+	preySourceAction         = newAction1[int]("prey_source")
+	addFeederColonyAction    = newAction0("add_feeder_colony")
+	feederForecastAction     = newAction0("feeder_forecast")
+	breedingAction           = newAction1[int]("breeding") // weeks projected
+	clutchStartAction        = newAction1[int32]("clutch_start")
+	clutchSubstrateOutAction = newAction1[int32]("clutch_out")
+	clutchHatchAction        = newAction1[int32]("clutch_hatch")
+	clutchMergeAction        = newAction1[int32]("clutch_merge")
+
+	// Purchases and expenses
+	logPurchaseAction      = newAction0("log_purchase")
+	logExpenseAction       = newAction0("log_expense")
+	purchaseFeederAction   = newAction1[int]("purchase_feeder")
+	purchaseSizeAction     = newAction1[string]("purchase_size")
+	purchaseColonyAction   = newAction1[int]("purchase_colony")
+	expenseCategoryAction  = newAction1[string]("expense_category")
+	expenseTarantulaAction = newAction1[int]("expense_tarantula")
+
+	// Species catalogue and care sheets
+	addTarantulaSpeciesAction = newAction1[int]("add_tarantula_species")
+	addSpeciesAction          = newAction0("add_species")
+	careSheetAction           = newAction1[int32]("care_sheet")
+	careBrowseAction          = newAction2[int, string]("care_browse") // page, query
+	speciesBrowseAction       = newAction0("species_browse")
+	speciesPageAction         = newAction1[int]("species_page")
+	speciesShareAction        = newAction1[bool]("species_share") // propose for the shared catalogue
+	speciesEditAction         = newAction1[int32]("species_edit")
+	speciesFieldAction        = newAction2[int32, TarantulaFormField]("species_field")
+	speciesProposeAction      = newAction1[int32]("species_propose")
+	speciesReviewAction       = newAction2[int32, bool]("species_review") // species, approve
+
+	// Shared collections
+	shareInviteAction     = newAction1[string]("share_invite") // role
+	shareRoleAction       = newAction2[int64, string]("share_role")
+	shareRemoveAction     = newAction1[int64]("share_remove")
+	shareSwitchAction     = newAction1[int64]("share_switch")
+	shareSitterAction     = newAction0("share_sitter")
+	shareSitterDaysAction = newAction1[int]("share_sitter_days")
+	shareLeaveAction      = newAction0("share_leave")
+	shareDutyAction       = newAction0("share_duty")
+	shareSummaryAction    = newAction0("share_summary")
+)
 
-const selectCallback = "select"
-const feedCallback = "feed"
-const moltCallback = "molt"
-const backToListCallback = "back_to_list"
-const feedSchedulerCallback = "feed_scheduler"
+// sharingActionPrefix marks the actions turned off with the sharing feature
+const sharingActionPrefix = "share_"
 
 func (t *TarantulaBot) setupInlineKeyboards() {
+	router := t.callbackRoutes()
 	t.bot.Handle(tele.OnCallback, func(c tele.Context) error {
-		callbackData := c.Callback().Data
-		callbackData = strings.TrimLeft(callbackData, "\f\n\r\t ")
-
-		if len(callbackData) >= 15 && callbackData[:16] == "colony_maintain_" {
-			parts := strings.Split(callbackData, "_")
-			if len(parts) < 4 {
-				return c.Send("Invalid callback data")
-			}
-
-			action := parts[2]
-			idStr := parts[3]
-			colonyID, err := strconv.Atoi(idStr)
-			if err != nil {
-				return fmt.Errorf("invalid colony ID: %w", err)
-			}
-
-			switch action {
-			case "select":
-				return t.handleSelectColonyForMaintenance(c, colonyID)
-			case "record":
-				if len(parts) < 5 {
-					return c.Send("Invalid maintenance type")
-				}
-				typeIDStr := parts[4]
-				typeID, err := strconv.Atoi(typeIDStr)
-				if err != nil {
-					return fmt.Errorf("invalid maintenance type ID: %w", err)
-				}
-				return t.handleRecordColonyMaintenance(c, colonyID, typeID)
-			case "history":
-				return t.handleColonyMaintenanceHistory(c, colonyID)
-			case "back":
-				return t.handleColonyMaintenanceMenu(c)
-			}
-			return nil
-		}
-
-		switch callbackData {
-		case "set_notification_time":
-			return t.handleSetNotificationTime(c)
-		case "set_feeding_reminder":
-			return t.handleSetFeedingReminder(c)
-		case "toggle_molt_predictions":
-			return t.handleToggleMoltPredictions(c)
-		case "set_molt_prediction_days":
-			return t.handleSetMoltPredictionDays(c)
-		case "set_post_molt_mute_days":
-			return t.handleSetPostMoltMuteDays(c)
-		case "toggle_notifications":
-			return t.handleToggleNotifications(c)
-		case "pause_1_day":
-			return t.handlePauseNotifications(c, 24*time.Hour)
-		case "pause_3_days":
-			return t.handlePauseNotifications(c, 72*time.Hour)
-		case "pause_1_week":
-			return t.handlePauseNotifications(c, 168*time.Hour)
-		case "pause_indefinitely":
-			return t.handlePauseNotifications(c, 0)
-		case "unpause_notifications":
-			return t.handleUnpauseNotifications(c)
-		}
-
-		if strings.HasPrefix(callbackData, "quick_feed:") {
-			tarantulaIDStr := strings.TrimPrefix(callbackData, "quick_feed:")
-			tarantulaID, err := strconv.Atoi(tarantulaIDStr)
-			if err != nil {
-				return c.Send("Invalid tarantula ID")
-			}
-			return t.handleQuickFeed(c, int32(tarantulaID))
-		}
-
-		if strings.HasPrefix(callbackData, "quick_feed_colony:") {
-			colonyIDStr := strings.TrimPrefix(callbackData, "quick_feed_colony:")
-			colonyID, err := strconv.Atoi(colonyIDStr)
-			if err != nil {
-				return c.Send("Invalid colony ID")
-			}
-			return t.handleQuickFeedColony(c, int32(colonyID))
-		}
-
-		if strings.HasPrefix(callbackData, "add_photo:") || strings.HasPrefix(callbackData, "photo:") {
-			var tarantulaIDStr string
-			if strings.HasPrefix(callbackData, "add_photo:") {
-				tarantulaIDStr = strings.TrimPrefix(callbackData, "add_photo:")
-			} else {
-				tarantulaIDStr = strings.TrimPrefix(callbackData, "photo:")
-			}
-			tarantulaID, err := strconv.Atoi(tarantulaIDStr)
-			if err != nil {
-				return c.Send("Invalid tarantula ID")
-			}
-			return t.handleAddPhoto(c, int32(tarantulaID))
-		}
-
-		if strings.HasPrefix(callbackData, "view_photos:") {
-			tarantulaIDStr := strings.TrimPrefix(callbackData, "view_photos:")
-			tarantulaID, err := strconv.Atoi(tarantulaIDStr)
-			if err != nil {
-				return c.Send("Invalid tarantula ID")
-			}
-			return t.handleViewPhotos(c, int32(tarantulaID))
-		}
-
-		if strings.HasPrefix(callbackData, "intel:") {
-			return t.handleFeedingIntelligence(c)
-		}
-
-		if strings.HasPrefix(callbackData, "molt_pred:") {
-			return t.handleIndividualMoltPrediction(c)
-		}
-
-		if callbackData == "molt_predictions" {
-			return t.handleMoltPredictionsOverview(c)
-		}
-
-		if callbackData == "back_to_list" || strings.HasPrefix(callbackData, "back_to_list:") {
-			return t.showTarantulaList(c)
-		}
-
-		// Colony management callbacks
-		if strings.HasPrefix(callbackData, "colony_species:") {
-			speciesIDStr := strings.TrimPrefix(callbackData, "colony_species:")
-			speciesID, err := strconv.Atoi(speciesIDStr)
-			if err != nil {
-				return c.Send("Invalid species ID")
-			}
-			return t.handleColonySpeciesSelected(c, speciesID)
-		}
-
-		if strings.HasPrefix(callbackData, "colony_details:") {
-			colonyIDStr := strings.TrimPrefix(callbackData, "colony_details:")
-			colonyID, err := strconv.Atoi(colonyIDStr)
-			if err != nil {
-				return c.Send("Invalid colony ID")
-			}
-			return t.handleColonyDetails(c, int32(colonyID))
-		}
-
-		if strings.HasPrefix(callbackData, "select_colony_for_add:") {
-			colonyIDStr := strings.TrimPrefix(callbackData, "select_colony_for_add:")
-			colonyID, err := strconv.Atoi(colonyIDStr)
-			if err != nil {
-				return c.Send("Invalid colony ID")
-			}
-			return t.handleColonySelectedForAdd(c, int32(colonyID))
-		}
-
-		if strings.HasPrefix(callbackData, "add_tarantula_to_colony:") {
-			tarantulaIDStr := strings.TrimPrefix(callbackData, "add_tarantula_to_colony:")
-			tarantulaID, err := strconv.Atoi(tarantulaIDStr)
-			if err != nil {
-				return c.Send("Invalid tarantula ID")
-			}
-			return t.handleTarantulaSelectedForColony(c, int32(tarantulaID))
-		}
-
-		if strings.HasPrefix(callbackData, "feed_colony:") {
-			colonyIDStr := strings.TrimPrefix(callbackData, "feed_colony:")
-			colonyID, err := strconv.Atoi(colonyIDStr)
-			if err != nil {
-				return c.Send("Invalid colony ID")
-			}
-			return t.handleFeedColony(c, int32(colonyID))
+		if !t.features.Sharing && strings.HasPrefix(callbackAction(c.Callback().Data), sharingActionPrefix) {
+			return c.Respond(&tele.CallbackResponse{Text: sharingDisabledMessage, ShowAlert: true})
 		}
+		return router.handle(c)
+	})
+}
 
-		// Handle species selection during tarantula creation
-		if strings.HasPrefix(callbackData, "add_tarantula_species:") {
-			speciesIDStr := strings.TrimPrefix(callbackData, "add_tarantula_species:")
-			speciesID, err := strconv.Atoi(speciesIDStr)
-			if err != nil {
-				return c.Send("Invalid species ID")
-			}
-			return t.handleTarantulaSpeciesSelected(c, speciesID)
-		}
+// callbackRoutes ties each inline button action to its handler
+func (t *TarantulaBot) callbackRoutes() *callbackRouter {
+	r := newCallbackRouter()
+
+	route2(r, formChoiceAction, t.handleFormChoice)
+	route1(r, formBackAction, t.handleFormBack)
+	route1(r, formSkipAction, t.handleFormSkip)
+	route0(r, formCancelAction, t.handleFormCancel)
+	route0(r, formSaveAction, t.handleFormSave)
+
+	route1(r, selectAction, t.handleTarantulaDetailsEnhanced)
+	route1(r, feedAction, t.handleTarantulaFeed)
+	route1(r, feedSchedulerAction, t.handleFeedScheduler)
+	route1(r, moltAction, t.handleTarantulaMolt)
+	route1(r, weightAction, t.handleWeightStart)
+	route1(r, weightHistoryAction, t.handleWeightHistory)
+	route1(r, addPhotoAction, t.handleAddPhoto)
+	route1(r, viewPhotosAction, t.handleViewPhotos)
+	route1(r, intelAction, t.handleFeedingIntelligence)
+	route1(r, moltPredictionAction, t.handleIndividualMoltPrediction)
+	route1(r, careAction, t.handleTarantulaCareSheet)
+	route1(r, careWaterAction, t.handleCareLogWater)
+	route1(r, careNoteAction, t.handleCareNoteStart)
+	route1(r, quickFeedAction, t.handleQuickFeed)
+	route0(r, backToListAction, t.showTarantulaList)
+	route1(r, undoAction, t.handleUndo)
+
+	route0(r, roundStartAction, t.handleFeedingRoundStart)
+	route2(r, roundCountAction, t.handleRoundCount)
+	route2(r, roundOutcomeAction, t.handleRoundOutcome)
+	route1(r, roundSkipAction, t.handleRoundSkip)
+	route0(r, roundEndAction, t.handleRoundEnd)
+
+	route0(r, quickActionsAction, t.handleQuickActions)
+	route0(r, feedingHistoryAction, t.handleFeedingHistory)
+	route0(r, feedingDashboardAction, t.handleFeedingDashboard)
+	route0(r, backToMainAction, func(c tele.Context) error {
+		return c.Send("Main Menu:", menu.main)
+	})
 
-		callback := parseCallback(callbackData)
-		switch callback.Action {
-		case selectCallback:
-			return t.handleTarantulaDetailsEnhanced(c)
-		case feedCallback:
-			return t.handleTarantulaFeed(c, callback.TarantulaID)
-		case feedSchedulerCallback:
-			return t.handleFeedScheduler(c, callback.TarantulaID)
-		case moltCallback:
-			return t.handleTarantulaMolt(c, callback.TarantulaID)
+	route0(r, setNotificationTimeAction, t.handleSetNotificationTime)
+	route0(r, setFeedingReminderAction, t.handleSetFeedingReminder)
+	route0(r, setMoltPredictionDaysAction, t.handleSetMoltPredictionDays)
+	route0(r, setPostMoltMuteDaysAction, t.handleSetPostMoltMuteDays)
+	route0(r, toggleNotificationsAction, t.handleToggleNotifications)
+	route0(r, toggleMoltPredictionsAction, t.handleToggleMoltPredictions)
+	route1(r, pauseNotificationsAction, func(c tele.Context, hours int) error {
+		return t.handlePauseNotifications(c, time.Duration(hours)*time.Hour)
+	})
+	route0(r, unpauseNotificationsAction, t.handleUnpauseNotifications)
+
+	route1(r, colonyDetailsAction, t.handleColonyDetails)
+	for action, field := range map[action1[int32]]TarantulaFormField{
+		colonyBulkAddAction:    FieldMembersToAdd,
+		colonyBulkRemoveAction: FieldMembersToRemove,
+		colonySplitAction:      FieldMembersToSplit,
+	} {
+		route1(r, action, func(c tele.Context, colonyID int32) error {
+			return t.handleMemberSelectionStart(c, colonyID, field)
+		})
+	}
+	route1(r, colonyMergeAction, t.handleMergeStart)
+	route2(r, mergeColoniesAction, t.handleMergeColonies)
+	route1(r, memberPickAction, t.handleMemberPickToggle)
+	route0(r, memberPickDoneAction, t.handleMemberPickDone)
+	route0(r, memberPickCancelAction, t.handleMemberPickCancel)
+	route1(r, splitEnclosureAction, t.handleSplitEnclosureSelected)
+	route1(r, colonyCensusAction, t.handleColonyCensusStart)
+	route1(r, colonyEventAction, t.handleColonyMemberEventStart)
+	route1(r, memberEventAction, t.handleMemberSelectedForEvent)
+	route1(r, memberEventTypeAction, t.handleMemberEventTypeSelected)
+	route1(r, selectColonyForAddAction, t.handleColonySelectedForAdd)
+	route1(r, addTarantulaToColonyAction, t.handleTarantulaSelectedForColony)
+	route1(r, feedColonyAction, t.handleFeedColony)
+	route1(r, quickFeedColonyAction, t.handleQuickFeedColony)
+
+	route1(r, maintainSelectAction, t.handleSelectColonyForMaintenance)
+	route2(r, maintainRecordAction, t.handleRecordColonyMaintenance)
+	route1(r, maintainHistoryAction, t.handleColonyMaintenanceHistory)
+	route0(r, maintainBackAction, t.handleColonyMaintenanceMenu)
+
+	route1(r, preyTypeAction, t.handlePreyTypeSelected)
+	route1(r, preySizeAction, t.handlePreySizeSelected)
+	route1(r, preyKilledAction, t.handlePreKilledSelected)
+	route1(r, preySourceAction, t.handlePreySourceSelected)
+	route0(r, addFeederColonyAction, t.handleAddFeederColony)
+	route0(r, feederForecastAction, t.handleFeederForecast)
+	route1(r, breedingAction, func(c tele.Context, weeks int) error {
+		if weeks <= 0 {
+			return errStaleCallback
 		}
-
-		return nil
+		return t.handleBreedingOverview(c, weeks)
+	})
+	route1(r, clutchStartAction, t.handleClutchStart)
+	route1(r, clutchSubstrateOutAction, t.handleClutchSubstrateOut)
+	route1(r, clutchHatchAction, t.handleClutchHatched)
+	route1(r, clutchMergeAction, t.handleClutchMerge)
+
+	route0(r, logPurchaseAction, t.handleLogPurchase)
+	route0(r, logExpenseAction, t.handleLogExpense)
+	route1(r, purchaseFeederAction, t.handlePurchaseFeederSelected)
+	route1(r, purchaseSizeAction, t.handlePurchaseSizeSelected)
+	route1(r, purchaseColonyAction, t.handlePurchaseColonySelected)
+	route1(r, expenseCategoryAction, t.handleExpenseCategorySelected)
+	route1(r, expenseTarantulaAction, t.handleExpenseTarantulaSelected)
+
+	route1(r, addTarantulaSpeciesAction, t.handleTarantulaSpeciesSelected)
+	route0(r, addSpeciesAction, t.handleAddSpecies)
+	route1(r, careSheetAction, t.sendCareSheet)
+	route2(r, careBrowseAction, func(c tele.Context, page int, query string) error {
+		return t.sendCareSheetBrowser(c, query, page)
+	})
+	route0(r, speciesBrowseAction, func(c tele.Context) error {
+		return t.handleSpeciesPage(c, 0, true)
 	})
+	route1(r, speciesPageAction, func(c tele.Context, page int) error {
+		return t.handleSpeciesPage(c, page, false)
+	})
+	route1(r, speciesShareAction, t.handleSpeciesSharingSelected)
+	route1(r, speciesEditAction, t.handleEditSpecies)
+	route2(r, speciesFieldAction, t.handleEditSpeciesField)
+	route1(r, speciesProposeAction, t.handleProposeSpecies)
+	route2(r, speciesReviewAction, t.handleReviewSpecies)
+
+	route1(r, shareInviteAction, t.handleShareInvite)
+	route2(r, shareRoleAction, t.handleShareRole)
+	route1(r, shareRemoveAction, t.handleShareRemove)
+	route1(r, shareSwitchAction, t.handleShareSwitch)
+	route0(r, shareSitterAction, t.handleSitterStintChoice)
+	route1(r, shareSitterDaysAction, t.handleSitterInvite)
+	route0(r, shareLeaveAction, t.handleShareLeave)
+	route0(r, shareDutyAction, t.handleShareDuty)
+	route0(r, shareSummaryAction, t.handleCareSummary)
+
+	return r
 }
 
 func (t *TarantulaBot) handlePauseNotifications(c tele.Context, duration time.Duration) error {
-	settings, err := t.db.GetUserSettings(t.ctx, c.Sender().ID)
+	settings, err := t.db.GetUserSettings(t.reqCtx(c), c.Sender().ID)
 	if err != nil {
 		return fmt.Errorf("failed to get user settings: %w", err)
 	}
@@ -245,7 +303,7 @@ func (t *TarantulaBot) handlePauseNotifications(c tele.Context, duration time.Du
 		settings.PauseReason = "Paused indefinitely"
 	}
 
-	err = t.db.UpdateUserSettings(t.ctx, settings)
+	err = t.db.UpdateUserSettings(t.reqCtx(c), settings)
 	if err != nil {
 		return fmt.Errorf("failed to update user settings: %w", err)
 	}
@@ -261,7 +319,7 @@ func (t *TarantulaBot) handlePauseNotifications(c tele.Context, duration time.Du
 }
 
 func (t *TarantulaBot) handleUnpauseNotifications(c tele.Context) error {
-	settings, err := t.db.GetUserSettings(t.ctx, c.Sender().ID)
+	settings, err := t.db.GetUserSettings(t.reqCtx(c), c.Sender().ID)
 	if err != nil {
 		return fmt.Errorf("failed to get user settings: %w", err)
 	}
@@ -271,7 +329,7 @@ func (t *TarantulaBot) handleUnpauseNotifications(c tele.Context) error {
 	settings.PauseEndDate = nil
 	settings.PauseReason = ""
 
-	err = t.db.UpdateUserSettings(t.ctx, settings)
+	err = t.db.UpdateUserSettings(t.reqCtx(c), settings)
 	if err != nil {
 		return fmt.Errorf("failed to update user settings: %w", err)
 	}
@@ -280,26 +338,26 @@ func (t *TarantulaBot) handleUnpauseNotifications(c tele.Context) error {
 }
 
 func (t *TarantulaBot) handleQuickFeed(c tele.Context, tarantulaID int32) error {
-	err := t.db.QuickFeed(t.ctx, tarantulaID, c.Sender().ID)
+	err := t.db.QuickFeed(t.reqCtx(c), tarantulaID, ownerID(c), c.Sender().ID)
 	if err != nil {
-		return c.Send(fmt.Sprintf("❌ Failed to record feeding: %s", err.Error()))
+		return fmt.Errorf("failed to record feeding: %w", err)
 	}
 
-	tarantula, err := t.db.GetTarantulaByID(t.ctx, c.Sender().ID, tarantulaID)
+	tarantula, err := t.db.GetTarantulaByID(t.reqCtx(c), ownerID(c), tarantulaID)
 	if err != nil {
 		return c.Send("✅ Fed successfully! (Could not retrieve details)")
 	}
 
-	return c.Send(fmt.Sprintf("✅ %s fed with 1 cricket!", tarantula.Name))
+	return c.Send(fmt.Sprintf("✅ %s fed with 1 feeder!", tarantula.Name))
 }
 
 func (t *TarantulaBot) handleQuickFeedColony(c tele.Context, colonyID int32) error {
-	err := t.db.QuickFeedColony(t.ctx, colonyID, c.Sender().ID)
+	event, err := t.db.QuickFeedColony(t.reqCtx(c), colonyID, ownerID(c), c.Sender().ID)
 	if err != nil {
-		return c.Send(fmt.Sprintf("❌ Failed to record colony feeding: %s", err.Error()))
+		return fmt.Errorf("failed to record colony feeding: %w", err)
 	}
 
-	colony, err := t.db.GetColony(t.ctx, colonyID, c.Sender().ID)
+	colony, err := t.db.GetColony(t.reqCtx(c), colonyID, ownerID(c))
 	if err != nil {
 		return c.Send("✅ Colony fed successfully! (Could not retrieve details)")
 	}
@@ -311,28 +369,63 @@ func (t *TarantulaBot) handleQuickFeedColony(c tele.Context, colonyID int32) err
 		}
 	}
 
-	return c.Send(fmt.Sprintf("✅ Colony '%s' fed with 1 cricket! (%d members)", colony.ColonyName, activeMembers))
+	prey := strconv.Itoa(event.PreyCount)
+	if event.PreySize != "" {
+		prey += " " + strings.ToLower(event.PreySize)
+	}
+	return c.Send(fmt.Sprintf("✅ Colony '%s' fed with %s feeders! (%d members)", colony.ColonyName, prey, activeMembers))
 }
 
 func (t *TarantulaBot) handleAddPhoto(c tele.Context, tarantulaID int32) error {
-	session := t.sessions.GetSession(c.Sender().ID)
+	session := t.sessions.GetSession(sessionKey(c))
 	session.CurrentState = StateAddingPhoto
 	session.CurrentField = FieldPhoto
 	session.TarantulaData.ID = int(tarantulaID)
-	t.sessions.UpdateSession(c.Sender().ID, session)
+	t.sessions.UpdateSession(sessionKey(c), session)
 
 	return c.Send("📸 Send a photo of your tarantula:")
 }
 
+func (t *TarantulaBot) handleWeightStart(c tele.Context, tarantulaID int) error {
+	session := t.sessions.GetSession(sessionKey(c))
+	session.reset()
+	session.CurrentState = StateRecordingWeight
+	session.SelectedTarantulaID = tarantulaID
+	t.sessions.UpdateSession(sessionKey(c), session)
+
+	return c.Send("⚖️ How much does it weigh, in grams?")
+}
+
+func (t *TarantulaBot) handleWeightInput(c tele.Context, session *UserSession) error {
+	grams, err := strconv.ParseFloat(strings.Replace(strings.TrimSpace(c.Text()), ",", ".", 1), 64)
+	if err != nil || grams <= 0 {
+		return c.Send("Please enter the weight in grams, e.g. 2.4")
+	}
+
+	_, err = t.db.RecordWeight(t.reqCtx(c), models.WeightRecord{
+		TarantulaID: session.SelectedTarantulaID,
+		WeightGrams: grams,
+		WeighDate:   time.Now(),
+		UserID:      ownerID(c),
+	})
+	session.reset()
+	t.sessions.UpdateSession(sessionKey(c), session)
+	if err != nil {
+		return fmt.Errorf("failed to save weight: %w", err)
+	}
+
+	return sendSuccess(c, fmt.Sprintf("Weight of %.2fg recorded!", grams))
+}
+
 func (t *TarantulaBot) handleWeightHistory(c tele.Context, tarantulaID int32) error {
-	weights, err := t.db.GetWeightHistory(t.ctx, tarantulaID, c.Sender().ID, 10)
+	weights, err := t.db.GetWeightHistory(t.reqCtx(c), tarantulaID, ownerID(c), 10)
 	if err != nil {
-		return SendError(c, fmt.Sprintf("Failed to get weight history: %v", err))
+		return fmt.Errorf("failed to get weight history: %w", err)
 	}
 
-	tarantula, err := t.db.GetTarantulaByID(t.ctx, c.Sender().ID, tarantulaID)
+	tarantula, err := t.db.GetTarantulaByID(t.reqCtx(c), ownerID(c), tarantulaID)
 	if err != nil {
-		return SendError(c, fmt.Sprintf("Failed to get tarantula: %v", err))
+		return fmt.Errorf("failed to get tarantula: %w", err)
 	}
 
 	if len(weights) == 0 {
@@ -378,12 +471,12 @@ func (t *TarantulaBot) handleWeightHistory(c tele.Context, tarantulaID int32) er
 }
 
 func (t *TarantulaBot) handleViewPhotos(c tele.Context, tarantulaID int32) error {
-	photos, err := t.db.GetTarantulaPhotos(t.ctx, tarantulaID, c.Sender().ID, 5)
+	photos, err := t.db.GetTarantulaPhotos(t.reqCtx(c), tarantulaID, ownerID(c), 5)
 	if err != nil {
 		return fmt.Errorf("failed to get photos: %w", err)
 	}
 
-	tarantula, err := t.db.GetTarantulaByID(t.ctx, c.Sender().ID, tarantulaID)
+	tarantula, err := t.db.GetTarantulaByID(t.reqCtx(c), ownerID(c), tarantulaID)
 	if err != nil {
 		return fmt.Errorf("failed to get tarantula: %w", err)
 	}
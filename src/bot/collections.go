@@ -0,0 +1,356 @@
+package bot
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"tarantulago/models"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// Shared collections. Every update is resolved to the collection its sender
+// is working in, or the collection its group is bound to: data handlers use
+// ownerID(c), while sessions and personal settings stay keyed by the sender.
+
+const (
+	collectionAccessKey = "collection_access"
+	joinPayloadPrefix   = "join_"
+
+	sharingDisabledMessage = "Sharing collections is turned off on this bot."
+)
+
+// browseCallbacks are the actions open to every role: looking around, care
+// sheets, personal settings and the sharing screen itself. Entries ending in
+// "_" cover every action starting with them.
+var browseCallbacks = []string{
+	selectAction.name, backToListAction.name, viewPhotosAction.name, weightHistoryAction.name,
+	intelAction.name, moltPredictionAction.name, colonyDetailsAction.name,
+	careAction.name, careSheetAction.name, careBrowseAction.name, memberPickCancelAction.name,
+	quickActionsAction.name, feedingHistoryAction.name, feedingDashboardAction.name, backToMainAction.name,
+	"set_", "toggle_", "pause_", unpauseNotificationsAction.name, sharingActionPrefix,
+}
+
+// roleCallbacks are the actions each restricted role may use
+var roleCallbacks = map[string][]string{
+	models.CollectionRoleViewer: slices.Concat(browseCallbacks, []string{"species_", breedingAction.name, feederForecastAction.name}),
+	models.CollectionRoleSitter: slices.Concat(browseCallbacks, []string{
+		feedAction.name, feedColonyAction.name, quickFeedAction.name, quickFeedColonyAction.name, "prey_",
+		careWaterAction.name, careNoteAction.name, undoAction.name, "round_",
+	}),
+}
+
+// roleStates are the forms each restricted role may fill in
+var roleStates = map[string][]FormState{
+	models.CollectionRoleViewer: {StateIdle, "", StateNotificationSettings, StateAddingSpecies, StateEditingSpecies},
+	models.CollectionRoleSitter: {StateIdle, "", StateNotificationSettings, StateFeeding, StateCareNote, StateFeedingRound},
+}
+
+// roleHiddenButtons are menu buttons a restricted role can't open
+var roleHiddenButtons = map[string][]*tele.Btn{
+	models.CollectionRoleSitter: {&btnAnalytics, &btnFeedingPatterns, &btnGrowthCharts, &btnAnnualReports, &btnSpending},
+}
+
+var roleRefusals = map[string]string{
+	models.CollectionRoleViewer: "👀 You have view-only access to this collection.",
+	models.CollectionRoleSitter: "🧳 Sitters can record feedings, watering and observations only.",
+}
+
+// ownerID is the owner of the collection the sender is working in
+func ownerID(c tele.Context) int64 {
+	return collectionAccess(c).OwnerID
+}
+
+func collectionAccess(c tele.Context) *models.CollectionAccess {
+	if access, ok := c.Get(collectionAccessKey).(*models.CollectionAccess); ok {
+		return access
+	}
+	return &models.CollectionAccess{OwnerID: c.Sender().ID, Role: models.CollectionRoleOwner}
+}
+
+// mayUseCallback reports whether role may press the button with data.
+// Buttons that don't decode are let through to be answered as expired.
+func mayUseCallback(role, data string) bool {
+	action := callbackAction(data)
+	if action == "" {
+		return true
+	}
+	return slices.ContainsFunc(roleCallbacks[role], func(allowed string) bool {
+		if strings.HasSuffix(allowed, "_") {
+			return strings.HasPrefix(action, allowed)
+		}
+		return action == allowed
+	})
+}
+
+func mayUseButton(role, text string) bool {
+	return !slices.ContainsFunc(roleHiddenButtons[role], func(btn *tele.Btn) bool {
+		return btn.Text == text
+	})
+}
+
+// collectionMiddleware resolves the sender's collection and keeps viewers and
+// sitters to what their role allows
+func (t *TarantulaBot) collectionMiddleware(next tele.HandlerFunc) tele.HandlerFunc {
+	return func(c tele.Context) error {
+		if c.Sender() == nil {
+			return next(c)
+		}
+
+		own := &models.CollectionAccess{OwnerID: c.Sender().ID, Role: models.CollectionRoleOwner}
+		var access *models.CollectionAccess
+		if isGroupChat(c.Chat()) {
+			if !t.features.GroupChats {
+				if command(c) != "" {
+					return c.Send("Group chats are turned off on this bot; talk to me in a private chat.")
+				}
+				return nil
+			}
+
+			key := sessionKey(c)
+			c = groupContext{Context: c, inForm: func() bool {
+				return !slices.Contains([]FormState{StateIdle, ""}, t.sessions.GetSession(key).CurrentState)
+			}}
+
+			var err error
+			access, err = t.db.GetGroupAccess(t.reqCtx(c), c.Chat().ID, c.Sender().ID)
+			if err != nil {
+				return fmt.Errorf("failed to resolve group collection: %w", err)
+			}
+			if access == nil {
+				if command(c) != "/bind" && (c.Message() == nil || !c.Message().IsService()) {
+					return answerUnboundGroup(c)
+				}
+				access = own
+			}
+		} else {
+			var err error
+			access, err = t.db.GetCollectionAccess(t.reqCtx(c), c.Sender().ID)
+			if err != nil {
+				slog.ErrorContext(t.reqCtx(c), "Failed to resolve collection", "user_id", c.Sender().ID, "error", err)
+				access = own
+			}
+		}
+		c.Set(collectionAccessKey, access)
+
+		if access.CanEdit() {
+			return next(c)
+		}
+
+		refusal := roleRefusals[access.Role]
+		if cb := c.Callback(); cb != nil && !mayUseCallback(access.Role, cb.Data) {
+			return c.Respond(&tele.CallbackResponse{Text: refusal, ShowAlert: true})
+		}
+		if c.Callback() == nil && !mayUseButton(access.Role, c.Text()) {
+			return c.Send(refusal)
+		}
+
+		states := roleStates[access.Role]
+		session := t.sessions.GetSession(sessionKey(c))
+		if !slices.Contains(states, session.CurrentState) {
+			session.reset()
+			t.sessions.UpdateSession(sessionKey(c), session)
+			return c.Send(refusal)
+		}
+
+		if err := next(c); err != nil {
+			return err
+		}
+
+		// Menu buttons that open a form are only caught once the form starts
+		session = t.sessions.GetSession(sessionKey(c))
+		if !slices.Contains(states, session.CurrentState) {
+			session.reset()
+			t.sessions.UpdateSession(sessionKey(c), session)
+			return c.Send(refusal + " Nothing will be recorded.")
+		}
+		return nil
+	}
+}
+
+func (t *TarantulaBot) handleJoinCollection(c tele.Context, code string) error {
+	if !t.features.Sharing {
+		return SendInfo(c, sharingDisabledMessage)
+	}
+
+	access, err := t.db.AcceptCollectionInvite(t.reqCtx(c), code, c.Sender().ID)
+	if err != nil {
+		return fmt.Errorf("failed to join collection: %w", err)
+	}
+	c.Set(collectionAccessKey, access)
+
+	return sendSuccess(c, fmt.Sprintf("You joined %s's collection as %s! Switch collections any time under Settings → Sharing.",
+		access.OwnerName, access.Role))
+}
+
+func (t *TarantulaBot) handleSharing(c tele.Context) error {
+	if !t.features.Sharing {
+		return SendInfo(c, sharingDisabledMessage)
+	}
+
+	access := collectionAccess(c)
+	own := access.OwnerID == c.Sender().ID
+
+	var msg strings.Builder
+	msg.WriteString("👥 *Sharing*\n\n")
+	if own {
+		msg.WriteString("You're working in your own collection.\n")
+	} else {
+		msg.WriteString(fmt.Sprintf("You're working in *%s's* collection as %s.\n", access.OwnerName, access.Role))
+	}
+	if access.ExpiresAt != nil {
+		msg.WriteString(fmt.Sprintf("🧳 Your access ends %s.\n", access.ExpiresAt.Format("Mon Jan 2 15:04")))
+	}
+
+	var rows [][]tele.InlineButton
+	if own {
+		members, err := t.db.GetCollectionMembers(t.reqCtx(c), access.OwnerID)
+		if err != nil {
+			return fmt.Errorf("failed to get collection members: %w", err)
+		}
+
+		onDuty := "you"
+		if len(members) > 0 {
+			msg.WriteString("\n*People with access:*\n")
+		}
+		for _, member := range members {
+			name := member.Member.DisplayName()
+			line := fmt.Sprintf("• %s — %s", name, member.Role)
+			if member.ExpiresAt != nil {
+				if member.Expired(time.Now()) {
+					line += " (ended)"
+				} else {
+					line += " until " + member.ExpiresAt.Format("Jan 2")
+				}
+			}
+			if member.OnDuty && !member.Expired(time.Now()) {
+				line += " 🛎"
+				onDuty = name
+			}
+			msg.WriteString(line + "\n")
+
+			remove := shareRemoveAction.Button("🗑 Remove "+name, member.MemberID)
+			if member.Role == models.CollectionRoleSitter {
+				rows = append(rows, []tele.InlineButton{remove})
+				continue
+			}
+			toggle := models.CollectionRoleViewer
+			if member.Role == models.CollectionRoleViewer {
+				toggle = models.CollectionRoleEditor
+			}
+			rows = append(rows, []tele.InlineButton{
+				shareRoleAction.Button(fmt.Sprintf("🔁 %s → %s", name, toggle), member.MemberID, toggle),
+				remove,
+			})
+		}
+		msg.WriteString(fmt.Sprintf("\n🛎 Notifications go to %s.\n", onDuty))
+
+		rows = append(rows, []tele.InlineButton{
+			shareInviteAction.Button("➕ Invite Editor", models.CollectionRoleEditor),
+			shareInviteAction.Button("➕ Invite Viewer", models.CollectionRoleViewer),
+		})
+		rows = append(rows, []tele.InlineButton{shareSitterAction.Button("🧳 Invite Sitter")})
+	}
+
+	if access.CanEdit() || access.Role == models.CollectionRoleSitter {
+		rows = append(rows, []tele.InlineButton{
+			shareDutyAction.Button("🛎 I'm on duty"),
+			shareSummaryAction.Button("🖨 Care Summary"),
+		})
+	}
+
+	memberships, err := t.db.GetUserMemberships(t.reqCtx(c), c.Sender().ID)
+	if err != nil {
+		return fmt.Errorf("failed to get collections: %w", err)
+	}
+	if !own {
+		rows = append(rows, []tele.InlineButton{shareSwitchAction.Button("🏠 Back to my collection", c.Sender().ID)})
+	}
+	for _, membership := range memberships {
+		if membership.OwnerID == access.OwnerID {
+			continue
+		}
+		rows = append(rows, []tele.InlineButton{shareSwitchAction.Button(fmt.Sprintf("📂 Switch to %s's collection", membership.Owner.DisplayName()), membership.OwnerID)})
+	}
+	if !own {
+		rows = append(rows, []tele.InlineButton{shareLeaveAction.Button(fmt.Sprintf("🚪 Leave %s's collection", access.OwnerName))})
+	}
+
+	return c.Send(msg.String(), &tele.ReplyMarkup{InlineKeyboard: rows}, tele.ModeMarkdown)
+}
+
+func (t *TarantulaBot) handleShareInvite(c tele.Context, role string) error {
+	if ownerID(c) != c.Sender().ID {
+		return SendError(c, "Only the collection owner can invite people")
+	}
+
+	invite, err := t.db.CreateCollectionInvite(t.reqCtx(c), c.Sender().ID, role)
+	if err != nil {
+		return fmt.Errorf("failed to create invite: %w", err)
+	}
+
+	link := fmt.Sprintf("https://t.me/%s?start=%s%s", t.bot.Me.Username, joinPayloadPrefix, invite.Code)
+	return c.Send(fmt.Sprintf("📨 Send this link to the person joining as %s. It works once and expires on %s:\n\n%s",
+		role, invite.ExpiresAt.Format("Jan 2"), link))
+}
+
+func (t *TarantulaBot) handleShareRole(c tele.Context, memberID int64, role string) error {
+	if ownerID(c) != c.Sender().ID {
+		return SendError(c, "Only the collection owner can change roles")
+	}
+
+	if err := t.db.SetCollectionRole(t.reqCtx(c), c.Sender().ID, memberID, role); err != nil {
+		return fmt.Errorf("failed to change role: %w", err)
+	}
+	return t.handleSharing(c)
+}
+
+func (t *TarantulaBot) handleShareRemove(c tele.Context, memberID int64) error {
+	if ownerID(c) != c.Sender().ID {
+		return SendError(c, "Only the collection owner can remove people")
+	}
+
+	if err := t.db.RemoveCollectionMember(t.reqCtx(c), c.Sender().ID, memberID); err != nil {
+		return fmt.Errorf("failed to remove member: %w", err)
+	}
+	return t.handleSharing(c)
+}
+
+func (t *TarantulaBot) handleShareSwitch(c tele.Context, owner int64) error {
+	if err := t.db.SwitchCollection(t.reqCtx(c), c.Sender().ID, owner); err != nil {
+		return fmt.Errorf("failed to switch collection: %w", err)
+	}
+
+	access, err := t.db.GetCollectionAccess(t.reqCtx(c), c.Sender().ID)
+	if err != nil {
+		return fmt.Errorf("failed to switch collection: %w", err)
+	}
+	c.Set(collectionAccessKey, access)
+
+	session := t.sessions.GetSession(sessionKey(c))
+	session.reset()
+	t.sessions.UpdateSession(sessionKey(c), session)
+
+	return t.handleSharing(c)
+}
+
+func (t *TarantulaBot) handleShareLeave(c tele.Context) error {
+	access := collectionAccess(c)
+	if access.OwnerID == c.Sender().ID {
+		return SendError(c, "You can't leave your own collection")
+	}
+
+	if err := t.db.RemoveCollectionMember(t.reqCtx(c), access.OwnerID, c.Sender().ID); err != nil {
+		return fmt.Errorf("failed to leave collection: %w", err)
+	}
+	return t.handleShareSwitch(c, c.Sender().ID)
+}
+
+func (t *TarantulaBot) handleShareDuty(c tele.Context) error {
+	if err := t.db.TakeDuty(t.reqCtx(c), ownerID(c), c.Sender().ID); err != nil {
+		return fmt.Errorf("failed to take duty: %w", err)
+	}
+	return sendSuccess(c, "You're on duty: this collection's reminders and alerts now come to you.")
+}
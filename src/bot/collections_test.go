@@ -0,0 +1,68 @@
+package bot
+
+import (
+	"tarantulago/models"
+	"testing"
+)
+
+func TestMayUseCallback(t *testing.T) {
+	cases := []struct {
+		role string
+		data string
+		want bool
+	}{
+		{models.CollectionRoleViewer, selectAction.Button("", 4).Data, true},
+		{models.CollectionRoleViewer, careSheetAction.Button("", 12).Data, true},
+		{models.CollectionRoleViewer, feedAction.Button("", 4).Data, false},
+		{models.CollectionRoleViewer, careWaterAction.Button("", 4).Data, false},
+		{models.CollectionRoleSitter, feedAction.Button("", 4).Data, true},
+		{models.CollectionRoleSitter, preySizeAction.Button("", "Small").Data, true},
+		{models.CollectionRoleSitter, careNoteAction.Button("", 4).Data, true},
+		{models.CollectionRoleSitter, moltAction.Button("", 4).Data, false},
+		{models.CollectionRoleSitter, feedSchedulerAction.Button("", 4).Data, false},
+		{models.CollectionRoleSitter, purchaseFeederAction.Button("", 2).Data, false},
+		{models.CollectionRoleSitter, breedingAction.Button("", 4).Data, false},
+		{"unknown", selectAction.Button("", 4).Data, false},
+		// Stale buttons are left for the router to answer as expired
+		{models.CollectionRoleViewer, "feed:4", true},
+	}
+
+	for _, tc := range cases {
+		if got := mayUseCallback(tc.role, tc.data); got != tc.want {
+			t.Errorf("mayUseCallback(%q, %q) = %v, want %v", tc.role, tc.data, got, tc.want)
+		}
+	}
+
+	if mayUseButton(models.CollectionRoleSitter, btnSpending.Text) {
+		t.Error("sitter may open spending")
+	}
+	if !mayUseButton(models.CollectionRoleSitter, btnFeeding.Text) {
+		t.Error("sitter may not open feeding")
+	}
+}
+
+type memberContext struct {
+	fakeChatContext
+	access *models.CollectionAccess
+}
+
+func (c memberContext) Get(string) interface{} { return c.access }
+
+func TestSpeciesResolvedByCollectionOwner(t *testing.T) {
+	owner := int64(9)
+	species := &models.TarantulaSpecies{OwnerUserID: &owner, Status: models.SpeciesStatusPrivate}
+	bot := &TarantulaBot{}
+
+	editor := memberContext{access: &models.CollectionAccess{OwnerID: owner, Role: models.CollectionRoleEditor}}
+	if !speciesVisibleTo(species, ownerID(editor)) {
+		t.Error("owner's private species hidden from an editor")
+	}
+	if !bot.canEditSpecies(editor, species) {
+		t.Error("editor may not edit the collection's species")
+	}
+
+	viewer := memberContext{access: &models.CollectionAccess{OwnerID: owner, Role: models.CollectionRoleViewer}}
+	if bot.canEditSpecies(viewer, species) {
+		t.Error("viewer may edit the collection's species")
+	}
+}
@@ -0,0 +1,157 @@
+package bot
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"tarantulago/models"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+var memberEventEmoji = map[string]string{
+	models.MemberEventSeen:         "👀",
+	models.MemberEventMolted:       "🔄",
+	models.MemberEventMissing:      "❓",
+	models.MemberEventCannibalized: "💀",
+	models.MemberEventRemoved:      "📤",
+}
+
+func (t *TarantulaBot) handleColonyCensusStart(c tele.Context, colonyID int32) error {
+	colony, err := t.db.GetColony(t.reqCtx(c), colonyID, ownerID(c))
+	if err != nil {
+		return fmt.Errorf("failed to get colony: %w", err)
+	}
+
+	activeMembers := 0
+	for _, member := range colony.Members {
+		if member.IsActive {
+			activeMembers++
+		}
+	}
+
+	session := t.sessions.GetSession(sessionKey(c))
+	session.reset()
+	session.CurrentState = StateRecordingCensus
+	session.CurrentField = FieldCensusCount
+	session.SelectedColonyID = int(colonyID)
+	t.sessions.UpdateSession(sessionKey(c), session)
+
+	return c.Send(fmt.Sprintf("🔢 How many tarantulas in %s can you see right now? (%d expected)", colony.ColonyName, activeMembers))
+}
+
+func (t *TarantulaBot) handleCensusCountInput(c tele.Context, session *UserSession) error {
+	count, err := strconv.Atoi(strings.TrimSpace(c.Text()))
+	if err != nil || count < 0 {
+		return c.Send("Please enter the number of tarantulas you can see")
+	}
+
+	colonyID := int32(session.SelectedColonyID)
+	session.reset()
+	t.sessions.UpdateSession(sessionKey(c), session)
+
+	census := models.ColonyCensus{
+		ColonyID:     int(colonyID),
+		CensusDate:   time.Now(),
+		VisibleCount: count,
+		UserID:       ownerID(c),
+	}
+	if _, err := t.db.RecordColonyCensus(t.reqCtx(c), census); err != nil {
+		return fmt.Errorf("failed to record census: %w", err)
+	}
+
+	censuses, err := t.db.GetColonyCensuses(t.reqCtx(c), colonyID, ownerID(c), 2)
+	if err != nil || len(censuses) == 0 {
+		return sendSuccess(c, "Census recorded!")
+	}
+
+	latest := censuses[0]
+	msg := fmt.Sprintf("Census recorded: %d of %d visible", latest.VisibleCount, latest.ExpectedCount)
+	if len(censuses) > 1 && latest.VisibleCount < censuses[1].VisibleCount {
+		msg += fmt.Sprintf("\n⚠️ Down from %d at the last census", censuses[1].VisibleCount)
+	}
+	if latest.VisibleCount < latest.ExpectedCount {
+		msg += "\n💡 Log members as seen, missing or cannibalized to keep the roster accurate."
+	}
+	return sendSuccess(c, msg)
+}
+
+func (t *TarantulaBot) handleColonyMemberEventStart(c tele.Context, colonyID int32) error {
+	members, err := t.db.GetColonyMembers(t.reqCtx(c), colonyID, ownerID(c), true)
+	if err != nil {
+		return fmt.Errorf("failed to get colony members: %w", err)
+	}
+	if len(members) == 0 {
+		return SendInfo(c, "This colony has no members yet.")
+	}
+
+	var rows [][]tele.InlineButton
+	for _, member := range members {
+		rows = append(rows, []tele.InlineButton{memberEventAction.Button(fmt.Sprintf("%s (seen %s)", member.Tarantula.Name, formatLastSeen(member)), member.ID)})
+	}
+
+	return c.Send("📝 Which member is this about?", &tele.ReplyMarkup{InlineKeyboard: rows})
+}
+
+func (t *TarantulaBot) handleMemberSelectedForEvent(c tele.Context, memberID int) error {
+	session := t.sessions.GetSession(sessionKey(c))
+	session.reset()
+	session.SelectedMemberID = memberID
+	t.sessions.UpdateSession(sessionKey(c), session)
+
+	var rows [][]tele.InlineButton
+	for _, eventType := range models.MemberEventTypes {
+		rows = append(rows, []tele.InlineButton{memberEventTypeAction.Button(memberEventEmoji[eventType]+" "+eventType, eventType)})
+	}
+
+	return c.Send("What did you observe?", &tele.ReplyMarkup{InlineKeyboard: rows})
+}
+
+func (t *TarantulaBot) handleMemberEventTypeSelected(c tele.Context, eventType string) error {
+	session := t.sessions.GetSession(sessionKey(c))
+	memberID := session.SelectedMemberID
+	if memberID == 0 {
+		return SendError(c, "Session expired. Please try again.")
+	}
+
+	session.reset()
+	t.sessions.UpdateSession(sessionKey(c), session)
+
+	event := models.ColonyMemberEvent{
+		MemberID:  memberID,
+		EventType: eventType,
+		EventDate: time.Now(),
+		UserID:    ownerID(c),
+	}
+	if _, err := t.db.RecordColonyMemberEvent(t.reqCtx(c), event); err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+
+	switch eventType {
+	case models.MemberEventMolted:
+		return sendSuccess(c, "Molt recorded and added to its molt history!")
+	case models.MemberEventMissing:
+		return sendSuccess(c, "Recorded. The colony will be flagged until it's seen again.")
+	case models.MemberEventCannibalized, models.MemberEventRemoved:
+		return sendSuccess(c, "Recorded, and the member has left the colony.")
+	}
+	return sendSuccess(c, fmt.Sprintf("%s recorded!", eventType))
+}
+
+// formatLastSeen reports when a member was last seen, counting from when it
+// joined if it has never been seen since
+func formatLastSeen(member models.TarantulaColonyMember) string {
+	seen := member.JoinedDate
+	if member.LastSeenDate != nil {
+		seen = *member.LastSeenDate
+	}
+	days := int(time.Since(seen).Hours() / 24)
+	switch days {
+	case 0:
+		return "today"
+	case 1:
+		return "yesterday"
+	}
+	return fmt.Sprintf("%d days ago", days)
+}
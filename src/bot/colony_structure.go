@@ -0,0 +1,290 @@
+package bot
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"tarantulago/models"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// Bulk member changes, splits and merges start from the colony details. The
+// user ticks members on a toggle keyboard and confirms with Done.
+
+func (t *TarantulaBot) handleMemberSelectionStart(c tele.Context, colonyID int32, field TarantulaFormField) error {
+	session := t.sessions.GetSession(sessionKey(c))
+	session.reset()
+	session.CurrentState = StateSelectingMembers
+	session.CurrentField = field
+	session.SelectedColonyID = int(colonyID)
+	t.sessions.UpdateSession(sessionKey(c), session)
+
+	text, markup, err := t.buildMemberSelection(c, session)
+	if err != nil {
+		return err
+	}
+	return c.Send(text, markup)
+}
+
+// memberCandidates lists who can be picked: tarantulas of the colony's
+// species that aren't in any colony when adding, otherwise current members
+func (t *TarantulaBot) memberCandidates(c tele.Context, session *UserSession) (*models.TarantulaColony, []models.Tarantula, error) {
+	colony, err := t.db.GetColony(t.reqCtx(c), int32(session.SelectedColonyID), ownerID(c))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get colony: %w", err)
+	}
+
+	if session.CurrentField != FieldMembersToAdd {
+		var members []models.Tarantula
+		for _, member := range colony.Members {
+			if member.IsActive {
+				members = append(members, member.Tarantula)
+			}
+		}
+		return colony, members, nil
+	}
+
+	colonies, err := t.db.GetUserColonies(t.reqCtx(c), ownerID(c))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get colonies: %w", err)
+	}
+	inColony := make(map[int]bool)
+	for _, other := range colonies {
+		for _, member := range other.Members {
+			inColony[member.TarantulaID] = true
+		}
+	}
+
+	all, err := t.db.GetAllTarantulas(t.reqCtx(c), ownerID(c))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get tarantulas: %w", err)
+	}
+	var candidates []models.Tarantula
+	for _, tarantula := range all {
+		if tarantula.SpeciesID == int32(colony.SpeciesID) && !inColony[int(tarantula.ID)] {
+			candidates = append(candidates, models.Tarantula{ID: int(tarantula.ID), Name: tarantula.Name})
+		}
+	}
+	return colony, candidates, nil
+}
+
+func (t *TarantulaBot) buildMemberSelection(c tele.Context, session *UserSession) (string, *tele.ReplyMarkup, error) {
+	colony, candidates, err := t.memberCandidates(c, session)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(candidates) == 0 {
+		if session.CurrentField == FieldMembersToAdd {
+			return "", nil, fmt.Errorf("No %s outside a colony to add", colony.Species.CommonName)
+		}
+		return "", nil, fmt.Errorf("This colony has no members")
+	}
+
+	var text string
+	switch session.CurrentField {
+	case FieldMembersToAdd:
+		text = fmt.Sprintf("👥 Choose tarantulas to add to %s:", colony.ColonyName)
+	case FieldMembersToRemove:
+		text = fmt.Sprintf("➖ Choose members to remove from %s:", colony.ColonyName)
+	case FieldMembersToSplit:
+		text = fmt.Sprintf("✂️ Choose members to move out of %s into a new colony:", colony.ColonyName)
+	}
+
+	var rows [][]tele.InlineButton
+	var row []tele.InlineButton
+	for _, tarantula := range candidates {
+		mark := "▫️"
+		if slices.Contains(session.SelectedTarantulas, int32(tarantula.ID)) {
+			mark = "✅"
+		}
+		row = append(row, memberPickAction.Button(mark+" "+tarantula.Name, int32(tarantula.ID)))
+		if len(row) == 2 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+	rows = append(rows, []tele.InlineButton{
+		memberPickDoneAction.Button(fmt.Sprintf("✔️ Done (%d)", len(session.SelectedTarantulas))),
+		memberPickCancelAction.Button("❌ Cancel"),
+	})
+
+	return text, &tele.ReplyMarkup{InlineKeyboard: rows}, nil
+}
+
+func (t *TarantulaBot) handleMemberPickToggle(c tele.Context, tarantulaID int32) error {
+	session := t.sessions.GetSession(sessionKey(c))
+	if session.CurrentState != StateSelectingMembers {
+		return SendError(c, "Invalid session state. Please start over.")
+	}
+
+	if i := slices.Index(session.SelectedTarantulas, tarantulaID); i >= 0 {
+		session.SelectedTarantulas = slices.Delete(session.SelectedTarantulas, i, i+1)
+	} else {
+		session.SelectedTarantulas = append(session.SelectedTarantulas, tarantulaID)
+	}
+	t.sessions.UpdateSession(sessionKey(c), session)
+
+	text, markup, err := t.buildMemberSelection(c, session)
+	if err != nil {
+		return err
+	}
+	return c.Edit(text, markup)
+}
+
+func (t *TarantulaBot) handleMemberPickDone(c tele.Context) error {
+	session := t.sessions.GetSession(sessionKey(c))
+	if session.CurrentState != StateSelectingMembers {
+		return SendError(c, "Invalid session state. Please start over.")
+	}
+	if len(session.SelectedTarantulas) == 0 {
+		return SendInfo(c, "Tick at least one tarantula first.")
+	}
+
+	userID := ownerID(c)
+	colonyID := int32(session.SelectedColonyID)
+	selected := session.SelectedTarantulas
+
+	switch session.CurrentField {
+	case FieldMembersToAdd:
+		session.reset()
+		t.sessions.UpdateSession(sessionKey(c), session)
+		if err := t.db.AddMembersToColony(t.reqCtx(c), colonyID, selected, userID); err != nil {
+			return fmt.Errorf("failed to add members: %w", err)
+		}
+		return sendSuccess(c, fmt.Sprintf("Added %d tarantula(s) to the colony!", len(selected)))
+
+	case FieldMembersToRemove:
+		session.reset()
+		t.sessions.UpdateSession(sessionKey(c), session)
+		if err := t.db.RemoveMembersFromColony(t.reqCtx(c), colonyID, selected, userID); err != nil {
+			return fmt.Errorf("failed to remove members: %w", err)
+		}
+		return sendSuccess(c, fmt.Sprintf("Removed %d member(s). Their colony history is kept.", len(selected)))
+
+	case FieldMembersToSplit:
+		session.CurrentState = StateSplittingColony
+		session.CurrentField = FieldColonyName
+		t.sessions.UpdateSession(sessionKey(c), session)
+		return c.Send("What's the name of the new colony?")
+	}
+
+	return SendError(c, "Invalid session state. Please start over.")
+}
+
+func (t *TarantulaBot) handleMemberPickCancel(c tele.Context) error {
+	session := t.sessions.GetSession(sessionKey(c))
+	session.reset()
+	t.sessions.UpdateSession(sessionKey(c), session)
+	return c.Edit("Cancelled.")
+}
+
+func (t *TarantulaBot) handleSplitFormInput(c tele.Context, session *UserSession) error {
+	text := strings.TrimSpace(c.Text())
+
+	switch session.CurrentField {
+	case FieldColonyName:
+		if text == "" {
+			return c.Send("Please enter a name for the new colony")
+		}
+		session.TarantulaColony.ColonyName = text
+		session.CurrentField = FieldSplitEnclosure
+		t.sessions.UpdateSession(sessionKey(c), session)
+
+		enclosures, err := t.db.GetEnclosures(t.reqCtx(c), ownerID(c))
+		if err != nil {
+			return fmt.Errorf("failed to load enclosures: %w", err)
+		}
+		var rows [][]tele.InlineButton
+		for _, enclosure := range enclosures {
+			rows = append(rows, []tele.InlineButton{splitEnclosureAction.Button("🏠 "+enclosure.Name, enclosure.ID)})
+		}
+		rows = append(rows, []tele.InlineButton{splitEnclosureAction.Button("⏭️ No enclosure", 0)})
+
+		return c.Send("Which enclosure does the new colony go in? Pick one, or type a name to add a new enclosure.",
+			&tele.ReplyMarkup{InlineKeyboard: rows})
+
+	case FieldSplitEnclosure:
+		if text == "" {
+			return c.Send("Please type a name for the new enclosure")
+		}
+		id, err := t.db.CreateEnclosure(t.reqCtx(c), models.Enclosure{Name: text, UserID: ownerID(c)})
+		if err != nil {
+			return fmt.Errorf("failed to create enclosure: %w", err)
+		}
+		return t.finishSplit(c, session, int(id))
+	}
+
+	return nil
+}
+
+func (t *TarantulaBot) handleSplitEnclosureSelected(c tele.Context, enclosureID int) error {
+	session := t.sessions.GetSession(sessionKey(c))
+	if session.CurrentState != StateSplittingColony || session.CurrentField != FieldSplitEnclosure {
+		return SendError(c, "Invalid session state. Please start over.")
+	}
+	return t.finishSplit(c, session, enclosureID)
+}
+
+func (t *TarantulaBot) finishSplit(c tele.Context, session *UserSession, enclosureID int) error {
+	newColony := models.TarantulaColony{
+		ColonyName: session.TarantulaColony.ColonyName,
+		UserID:     ownerID(c),
+	}
+	if enclosureID > 0 {
+		newColony.EnclosureID = &enclosureID
+	}
+	colonyID := int32(session.SelectedColonyID)
+	selected := session.SelectedTarantulas
+
+	session.reset()
+	t.sessions.UpdateSession(sessionKey(c), session)
+
+	if _, err := t.db.SplitColony(t.reqCtx(c), colonyID, selected, newColony); err != nil {
+		return fmt.Errorf("failed to split colony: %w", err)
+	}
+	return sendSuccess(c, fmt.Sprintf("Colony split! %d member(s) moved to %s.", len(selected), newColony.ColonyName))
+}
+
+func (t *TarantulaBot) handleMergeStart(c tele.Context, colonyID int32) error {
+	colonies, err := t.db.GetUserColonies(t.reqCtx(c), ownerID(c))
+	if err != nil {
+		return fmt.Errorf("failed to get colonies: %w", err)
+	}
+
+	var target *models.TarantulaColony
+	for i := range colonies {
+		if colonies[i].ID == int(colonyID) {
+			target = &colonies[i]
+		}
+	}
+	if target == nil {
+		return SendError(c, "Colony not found")
+	}
+
+	var rows [][]tele.InlineButton
+	for _, source := range colonies {
+		if source.ID == target.ID || source.SpeciesID != target.SpeciesID {
+			continue
+		}
+		rows = append(rows, []tele.InlineButton{mergeColoniesAction.Button(fmt.Sprintf("🔗 Merge %s (%d) into %s", source.ColonyName, len(source.Members), target.ColonyName), int32(target.ID), int32(source.ID))})
+	}
+	if len(rows) == 0 {
+		return SendInfo(c, fmt.Sprintf("You have no other %s colonies to merge.", target.Species.CommonName))
+	}
+
+	return c.Send("Which colony should join this one? Its members move over and it is closed.", &tele.ReplyMarkup{InlineKeyboard: rows})
+}
+
+func (t *TarantulaBot) handleMergeColonies(c tele.Context, targetID, sourceID int32) error {
+	if err := t.db.MergeColonies(t.reqCtx(c), targetID, sourceID, ownerID(c)); err != nil {
+		return fmt.Errorf("failed to merge colonies: %w", err)
+	}
+	if err := sendSuccess(c, "Colonies merged!"); err != nil {
+		return err
+	}
+	return t.handleColonyDetails(c, targetID)
+}
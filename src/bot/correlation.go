@@ -0,0 +1,49 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"tarantulago/logging"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+const requestContextKey = "request_context"
+
+// correlateUpdates gives each update its own context, tagged with the update
+// ID, so everything logged while handling it (database statements included)
+// can be tied together
+func (t *TarantulaBot) correlateUpdates(next tele.HandlerFunc) tele.HandlerFunc {
+	return func(c tele.Context) error {
+		id := fmt.Sprintf("upd-%d", c.Update().ID)
+		c.Set(requestContextKey, logging.WithCorrelationID(t.ctx, id))
+		return next(c)
+	}
+}
+
+// reqCtx is the context for work done on behalf of the update in c
+func (t *TarantulaBot) reqCtx(c tele.Context) context.Context {
+	if ctx, ok := c.Get(requestContextKey).(context.Context); ok {
+		return ctx
+	}
+	return t.ctx
+}
+
+// requestContext is the context of the update in c, for code without a bot
+func requestContext(c tele.Context) context.Context {
+	if ctx, ok := c.Get(requestContextKey).(context.Context); ok {
+		return ctx
+	}
+	return context.Background()
+}
+
+// handleError reports errors that reach telebot's OnError: poller errors,
+// which come without a context, and anything returned outside reportErrors
+func handleError(err error, c tele.Context) {
+	if c == nil {
+		slog.Error("Telegram error", "error", err)
+		return
+	}
+	reportError(c, err)
+}
@@ -0,0 +1,149 @@
+package bot
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"tarantulago/models"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// errorCatalog is what users are told when something fails, in one language
+type errorCatalog struct {
+	kinds     map[error]string
+	internal  string
+	reference string
+	// details adds the domain error's own message after the kind's text.
+	// Those messages are written in English, so other languages leave them out.
+	details bool
+}
+
+// errorCatalogs are keyed by Telegram language code; English is the fallback
+var errorCatalogs = map[string]errorCatalog{
+	"en": {
+		kinds: map[error]string{
+			models.ErrNotFound:          "I couldn't find that. It may have been removed, or it isn't in your collection.",
+			models.ErrAccessDenied:      "You don't have access to that.",
+			models.ErrValidation:        "That can't be done",
+			models.ErrInsufficientStock: "There isn't enough stock for that",
+			models.ErrConflict:          "That clashes with what's already there",
+		},
+		internal:  "Something went wrong on our side. Please try again in a moment.",
+		reference: "Error reference: %s",
+		details:   true,
+	},
+	"de": {
+		kinds: map[error]string{
+			models.ErrNotFound:          "Das habe ich nicht gefunden. Vielleicht wurde es gelöscht oder es gehört nicht zu deiner Sammlung.",
+			models.ErrAccessDenied:      "Darauf hast du keinen Zugriff.",
+			models.ErrValidation:        "Das geht so nicht",
+			models.ErrInsufficientStock: "Dafür ist nicht genug Vorrat da",
+			models.ErrConflict:          "Das kollidiert mit vorhandenen Daten",
+		},
+		internal:  "Bei uns ist etwas schiefgelaufen. Bitte versuche es gleich noch einmal.",
+		reference: "Fehlerreferenz: %s",
+	},
+	"es": {
+		kinds: map[error]string{
+			models.ErrNotFound:          "No lo encontré. Puede que se haya eliminado o que no esté en tu colección.",
+			models.ErrAccessDenied:      "No tienes acceso a eso.",
+			models.ErrValidation:        "Eso no se puede hacer",
+			models.ErrInsufficientStock: "No hay existencias suficientes para eso",
+			models.ErrConflict:          "Eso choca con lo que ya existe",
+		},
+		internal:  "Algo salió mal de nuestro lado. Inténtalo de nuevo en un momento.",
+		reference: "Referencia del error: %s",
+	},
+	"ru": {
+		kinds: map[error]string{
+			models.ErrNotFound:          "Не удалось это найти. Возможно, запись удалена или не относится к вашей коллекции.",
+			models.ErrAccessDenied:      "У вас нет к этому доступа.",
+			models.ErrValidation:        "Так сделать нельзя",
+			models.ErrInsufficientStock: "Для этого не хватает запаса",
+			models.ErrConflict:          "Это конфликтует с уже существующими данными",
+		},
+		internal:  "У нас что-то пошло не так. Попробуйте ещё раз чуть позже.",
+		reference: "Код ошибки: %s",
+	},
+}
+
+// detailedKinds are failures whose own message tells the user how to fix
+// their request, so it's shown after the catalog text
+var detailedKinds = map[error]bool{
+	models.ErrValidation:        true,
+	models.ErrInsufficientStock: true,
+	models.ErrConflict:          true,
+}
+
+// reportErrors answers a failed handler with a friendly message and a
+// reference ID; the error itself only goes to the logs, under that ID
+func reportErrors(next tele.HandlerFunc) tele.HandlerFunc {
+	return func(c tele.Context) error {
+		if err := next(c); err != nil {
+			reportError(c, err)
+		}
+		return nil
+	}
+}
+
+func reportError(c tele.Context, err error) {
+	ctx := requestContext(c)
+	ref := newErrorRef()
+
+	attrs := []any{"error", err, "ref", ref}
+	if c.Sender() != nil {
+		attrs = append(attrs, "user_id", c.Sender().ID)
+	}
+	var domainErr *models.DomainError
+	if errors.As(err, &domainErr) {
+		slog.InfoContext(ctx, "Request refused", attrs...)
+	} else {
+		slog.ErrorContext(ctx, "Handler failed", attrs...)
+	}
+
+	var lang string
+	if c.Sender() != nil {
+		lang = c.Sender().LanguageCode
+	}
+	if err := SendError(c, userErrorMessage(err, lang, ref)); err != nil {
+		slog.ErrorContext(ctx, "Failed to send error message", "error", err, "ref", ref)
+	}
+}
+
+// userErrorMessage explains err in the user's language without revealing
+// anything about the bot's internals
+func userErrorMessage(err error, lang, ref string) string {
+	lang, _, _ = strings.Cut(strings.ToLower(lang), "-")
+	catalog, ok := errorCatalogs[lang]
+	if !ok {
+		catalog = errorCatalogs["en"]
+	}
+
+	msg := catalog.internal
+	var domainErr *models.DomainError
+	if errors.As(err, &domainErr) {
+		if kindMsg, ok := catalog.kinds[domainErr.Kind]; ok {
+			msg = kindMsg
+			switch {
+			case detailedKinds[domainErr.Kind] && catalog.details:
+				msg += ": " + domainErr.Message
+			case detailedKinds[domainErr.Kind]:
+				msg += "."
+			}
+		}
+	}
+	return msg + "\n" + fmt.Sprintf(catalog.reference, ref)
+}
+
+// newErrorRef is a short ID users can quote to find their error in the logs
+func newErrorRef() string {
+	b := make([]byte, 3)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,54 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"tarantulago/models"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestUserErrorMessage(t *testing.T) {
+	internal := fmt.Errorf("failed to get colonies: %w", errors.New(`pq: relation "spider_bot.colonies" does not exist`))
+	msg := userErrorMessage(internal, "en", "a1b2c3")
+	if strings.Contains(msg, "spider_bot") || strings.Contains(msg, "pq:") {
+		t.Errorf("internal error leaked: %q", msg)
+	}
+	if !strings.Contains(msg, errorCatalogs["en"].internal) || !strings.HasSuffix(msg, "a1b2c3") {
+		t.Errorf("internal error message = %q", msg)
+	}
+
+	notFound := fmt.Errorf("failed to get colony: %w",
+		&models.DomainError{Kind: models.ErrNotFound, Message: "colony not found", Err: gorm.ErrRecordNotFound})
+	msg = userErrorMessage(notFound, "de-DE", "a1b2c3")
+	if !strings.HasPrefix(msg, errorCatalogs["de"].kinds[models.ErrNotFound]) || strings.Contains(msg, "record not found") {
+		t.Errorf("not found message = %q", msg)
+	}
+
+	invalid := fmt.Errorf("failed to split colony: %w", models.Invalid("choose at least one member to move"))
+	msg = userErrorMessage(invalid, "xx", "a1b2c3")
+	if !strings.HasPrefix(msg, "That can't be done: choose at least one member to move\n") {
+		t.Errorf("validation message = %q", msg)
+	}
+
+	// The detail is English, so it isn't mixed into other languages
+	stock := models.InsufficientStock("no feeders available in colony %q", "Dubia")
+	msg = userErrorMessage(stock, "de", "a1b2c3")
+	if !strings.HasPrefix(msg, errorCatalogs["de"].kinds[models.ErrInsufficientStock]+".\n") || strings.Contains(msg, "no feeders") {
+		t.Errorf("German stock message = %q", msg)
+	}
+}
+
+func TestDomainErrorKinds(t *testing.T) {
+	err := fmt.Errorf("failed to feed: %w", models.InsufficientStock("no feeders available in colony %q", "Dubia"))
+	if !errors.Is(err, models.ErrInsufficientStock) || errors.Is(err, models.ErrNotFound) {
+		t.Errorf("kind of %v not matched", err)
+	}
+
+	cause := &models.DomainError{Kind: models.ErrNotFound, Message: "tarantula not found", Err: gorm.ErrRecordNotFound}
+	if !errors.Is(cause, gorm.ErrRecordNotFound) {
+		t.Error("cause not reachable through the domain error")
+	}
+}
@@ -0,0 +1,413 @@
+package bot
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"tarantulago/models"
+	"time"
+	"unicode"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// A feeding round walks through every tarantula due a feeding in enclosure
+// order, one message at a time, so a rack can be fed from one end to the
+// other. Each answer is recorded straight away, taking the prey from the
+// feeder colony used last time, and the round ends with a summary.
+
+// roundMaxPrey is the most prey the count buttons offer
+const roundMaxPrey = 5
+
+type roundOutcome struct {
+	status models.FeedingStatusEnum
+	label  string
+}
+
+// roundOutcomes are the answers offered for each tarantula
+var roundOutcomes = []roundOutcome{
+	{models.FeedingStatusAccepted, "✅ Ate"},
+	{models.FeedingStatusRejected, "❌ Refused"},
+	{models.FeedingStatusPreMolt, "🌀 Pre-molt"},
+}
+
+type roundStop struct {
+	tarantulaID int32
+	name        string
+	speciesName string
+	enclosure   string
+	days        int
+}
+
+type roundResult struct {
+	stop      roundStop
+	status    models.FeedingStatusEnum // zero when skipped
+	preyCount int
+	colonyID  *int
+}
+
+type feedingRound struct {
+	stops   []roundStop
+	current int
+	count   int // prey picked for the current stop
+	results []roundResult
+}
+
+// newFeedingRound orders the due tarantulas by enclosure, so "Rack A-2" comes
+// before "Rack A-10", with those not in an enclosure last
+func newFeedingRound(due []models.TarantulaListItem) *feedingRound {
+	round := &feedingRound{}
+	for _, item := range due {
+		round.stops = append(round.stops, roundStop{
+			tarantulaID: item.ID,
+			name:        item.Name,
+			speciesName: item.SpeciesName,
+			enclosure:   item.EnclosureName,
+			days:        int(item.DaysSinceFeeding),
+		})
+	}
+	slices.SortStableFunc(round.stops, func(a, b roundStop) int {
+		switch {
+		case a.enclosure == "" && b.enclosure != "":
+			return 1
+		case a.enclosure != "" && b.enclosure == "":
+			return -1
+		}
+		if c := compareNatural(a.enclosure, b.enclosure); c != 0 {
+			return c
+		}
+		return compareNatural(a.name, b.name)
+	})
+	return round
+}
+
+// compareNatural compares names ignoring case, with runs of digits compared
+// as numbers
+func compareNatural(a, b string) int {
+	a, b = strings.ToLower(a), strings.ToLower(b)
+	for a != "" && b != "" {
+		ra, rb := []rune(a)[0], []rune(b)[0]
+		if unicode.IsDigit(ra) && unicode.IsDigit(rb) {
+			na, restA := leadingNumber(a)
+			nb, restB := leadingNumber(b)
+			if c := cmp.Compare(na, nb); c != 0 {
+				return c
+			}
+			a, b = restA, restB
+			continue
+		}
+		if c := cmp.Compare(ra, rb); c != 0 {
+			return c
+		}
+		a, b = a[len(string(ra)):], b[len(string(rb)):]
+	}
+	return cmp.Compare(len(a), len(b))
+}
+
+func leadingNumber(s string) (int, string) {
+	end := strings.IndexFunc(s, func(r rune) bool { return !unicode.IsDigit(r) })
+	if end < 0 {
+		end = len(s)
+	}
+	n, _ := strconv.Atoi(s[:end])
+	return n, s[end:]
+}
+
+func (r *feedingRound) stop() (roundStop, bool) {
+	if r.current >= len(r.stops) {
+		return roundStop{}, false
+	}
+	return r.stops[r.current], true
+}
+
+// answer records what happened at the current stop and moves on to the next
+func (r *feedingRound) answer(result roundResult) {
+	r.results = append(r.results, result)
+	r.current++
+	r.count = 0
+}
+
+// summary tells how the round went and what it took from each feeder colony,
+// using colonies for their names and what's left
+func (r *feedingRound) summary(colonies []models.ColonyStatus) string {
+	var fed, refused, premolt, skipped []string
+	used := make(map[int]int)
+	bought := 0
+	for _, result := range r.results {
+		switch result.status {
+		case models.FeedingStatusAccepted:
+			fed = append(fed, result.stop.name)
+			if result.colonyID != nil {
+				used[*result.colonyID] += result.preyCount
+			} else {
+				bought += result.preyCount
+			}
+		case models.FeedingStatusRejected:
+			refused = append(refused, result.stop.name)
+		case models.FeedingStatusPreMolt:
+			premolt = append(premolt, result.stop.name)
+		default:
+			skipped = append(skipped, result.stop.name)
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("🏁 Feeding round summary\n\n")
+	sb.WriteString(fmt.Sprintf("✅ Fed: %d\n", len(fed)))
+	for _, line := range []struct {
+		label string
+		names []string
+	}{
+		{"❌ Refused", refused},
+		{"🌀 Pre-molt", premolt},
+		{"⏭️ Skipped", skipped},
+	} {
+		if len(line.names) > 0 {
+			sb.WriteString(fmt.Sprintf("%s: %d (%s)\n", line.label, len(line.names), strings.Join(line.names, ", ")))
+		}
+	}
+	if left := len(r.stops) - r.current; left > 0 {
+		sb.WriteString(fmt.Sprintf("⏹️ Not reached: %d\n", left))
+	}
+
+	if len(used) == 0 && bought == 0 {
+		return sb.String()
+	}
+	sb.WriteString("\n🦗 Feeders used\n")
+	for _, colony := range colonies {
+		if count, ok := used[int(colony.ID)]; ok {
+			sb.WriteString(fmt.Sprintf("• %s: %d (%d left)\n", colony.ColonyName, count, colony.CurrentCount))
+			delete(used, int(colony.ID))
+		}
+	}
+	for _, count := range used {
+		bought += count
+	}
+	if bought > 0 {
+		sb.WriteString(fmt.Sprintf("• Not from a colony: %d\n", bought))
+	}
+	return sb.String()
+}
+
+func (t *TarantulaBot) handleFeedingRoundStart(c tele.Context) error {
+	access := collectionAccess(c)
+	if !access.CanEdit() && access.Role != models.CollectionRoleSitter {
+		return c.Send(roleRefusals[access.Role])
+	}
+
+	due, err := t.db.GetTarantulasDueFeeding(t.reqCtx(c), ownerID(c))
+	if err != nil {
+		return fmt.Errorf("failed to get tarantulas due feeding: %w", err)
+	}
+	if len(due) == 0 {
+		return SendInfo(c, "No tarantulas are due a feeding right now 🎉")
+	}
+
+	defer t.sessions.Lock(sessionKey(c))()
+	session := t.sessions.GetSession(sessionKey(c))
+	session.reset()
+	session.CurrentState = StateFeedingRound
+	session.Round = newFeedingRound(due)
+	t.sessions.UpdateSession(sessionKey(c), session)
+
+	if err := c.Send(fmt.Sprintf("🪱 Feeding round: %d tarantula(s) due, in enclosure order.", len(due))); err != nil {
+		return err
+	}
+	return t.sendRoundStop(c, session)
+}
+
+// sendRoundStop asks about the current stop, or ends the round once every
+// tarantula has been answered
+func (t *TarantulaBot) sendRoundStop(c tele.Context, session *UserSession) error {
+	round := session.Round
+	stop, ok := round.stop()
+	if !ok {
+		return t.finishFeedingRound(c, session)
+	}
+
+	// Offer the same amount as last time
+	last, err := t.db.GetLastFeeding(t.reqCtx(c), stop.tarantulaID, ownerID(c))
+	if err != nil {
+		return fmt.Errorf("failed to get last feeding: %w", err)
+	}
+	if round.count == 0 {
+		round.count = 1
+		if last != nil && last.PreyCount > 0 {
+			round.count = min(last.PreyCount, roundMaxPrey)
+		}
+		t.sessions.UpdateSession(sessionKey(c), session)
+	}
+
+	text, markup := roundStopMessage(round, stop, last)
+	return c.Send(text, markup)
+}
+
+func roundStopMessage(round *feedingRound, stop roundStop, last *models.FeedingEvent) (string, *tele.ReplyMarkup) {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("🕷️ %d/%d · %s\n", round.current+1, len(round.stops), stop.name))
+	if stop.enclosure != "" {
+		sb.WriteString(fmt.Sprintf("📦 %s\n", stop.enclosure))
+	}
+	sb.WriteString(stop.speciesName)
+	if last != nil {
+		sb.WriteString(fmt.Sprintf(" · last fed %d days ago", stop.days))
+		if last.PreySize != "" {
+			sb.WriteString(fmt.Sprintf(" (%d %s)", last.PreyCount, last.PreySize))
+		}
+	} else {
+		sb.WriteString(" · never fed")
+	}
+	sb.WriteString("\n\nHow many prey, and did it eat?")
+
+	var counts []tele.InlineButton
+	for n := 1; n <= roundMaxPrey; n++ {
+		label := strconv.Itoa(n)
+		if n == round.count {
+			label = "✔️ " + label
+		}
+		counts = append(counts, roundCountAction.Button(label, stop.tarantulaID, n))
+	}
+	var outcomes []tele.InlineButton
+	for _, outcome := range roundOutcomes {
+		outcomes = append(outcomes, roundOutcomeAction.Button(outcome.label, stop.tarantulaID, outcome.status))
+	}
+
+	return sb.String(), &tele.ReplyMarkup{InlineKeyboard: [][]tele.InlineButton{
+		counts,
+		outcomes,
+		{roundSkipAction.Button("⏭️ Skip", stop.tarantulaID), roundEndAction.Button("⏹️ End round")},
+	}}
+}
+
+// roundSession returns the round a button belongs to, if tarantulaID is still
+// the one being asked about. Callers hold the session lock, so a second tap on
+// the same question finds the round already moved on.
+func (t *TarantulaBot) roundSession(c tele.Context, tarantulaID int32) (*UserSession, roundStop, error) {
+	session := t.sessions.GetSession(sessionKey(c))
+	if session.CurrentState != StateFeedingRound || session.Round == nil {
+		return nil, roundStop{}, errStaleCallback
+	}
+	stop, ok := session.Round.stop()
+	if !ok || stop.tarantulaID != tarantulaID {
+		return nil, roundStop{}, errStaleCallback
+	}
+	return session, stop, nil
+}
+
+func (t *TarantulaBot) handleRoundCount(c tele.Context, tarantulaID int32, count int) error {
+	defer t.sessions.Lock(sessionKey(c))()
+	session, stop, err := t.roundSession(c, tarantulaID)
+	if err != nil {
+		return err
+	}
+	if count < 1 || count > roundMaxPrey {
+		return errStaleCallback
+	}
+	session.Round.count = count
+	t.sessions.UpdateSession(sessionKey(c), session)
+
+	last, err := t.db.GetLastFeeding(t.reqCtx(c), stop.tarantulaID, ownerID(c))
+	if err != nil {
+		return fmt.Errorf("failed to get last feeding: %w", err)
+	}
+	text, markup := roundStopMessage(session.Round, stop, last)
+	return c.Edit(text, markup)
+}
+
+func (t *TarantulaBot) handleRoundOutcome(c tele.Context, tarantulaID int32, status models.FeedingStatusEnum) error {
+	defer t.sessions.Lock(sessionKey(c))()
+	session, stop, err := t.roundSession(c, tarantulaID)
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(roundOutcomes, func(o roundOutcome) bool { return o.status == status }) {
+		return errStaleCallback
+	}
+
+	event, err := t.feedingLikeLast(c, stop.tarantulaID, time.Now())
+	if err != nil {
+		return err
+	}
+	event.FeedingStatusID = int(status)
+	event.PreyCount = session.Round.count
+	event.Notes = "Feeding round"
+	if status != models.FeedingStatusAccepted {
+		event.PreyCount = 0
+		event.CricketColonyID = nil
+	}
+	if _, err := t.db.RecordFeeding(t.reqCtx(c), event); err != nil {
+		return fmt.Errorf("failed to record feeding: %w", err)
+	}
+
+	// Sitters only record feedings, so the molt stage is left to the keeper
+	if status == models.FeedingStatusPreMolt && collectionAccess(c).CanEdit() {
+		if err := t.db.SetMoltStage(t.reqCtx(c), stop.tarantulaID, ownerID(c), models.MoltStagePreMolt); err != nil {
+			return fmt.Errorf("failed to mark premolt: %w", err)
+		}
+	}
+
+	var line string
+	switch status {
+	case models.FeedingStatusAccepted:
+		line = fmt.Sprintf("✅ %s ate %d", stop.name, event.PreyCount)
+		if event.PreySize != "" {
+			line += " " + event.PreySize
+		}
+	case models.FeedingStatusRejected:
+		line = fmt.Sprintf("❌ %s refused", stop.name)
+	case models.FeedingStatusPreMolt:
+		line = fmt.Sprintf("🌀 %s is in pre-molt", stop.name)
+	}
+	return t.advanceRound(c, session, line, roundResult{
+		stop:      stop,
+		status:    status,
+		preyCount: event.PreyCount,
+		colonyID:  event.CricketColonyID,
+	})
+}
+
+func (t *TarantulaBot) handleRoundSkip(c tele.Context, tarantulaID int32) error {
+	defer t.sessions.Lock(sessionKey(c))()
+	session, stop, err := t.roundSession(c, tarantulaID)
+	if err != nil {
+		return err
+	}
+	return t.advanceRound(c, session, fmt.Sprintf("⏭️ %s skipped", stop.name), roundResult{stop: stop})
+}
+
+// advanceRound leaves line in place of the answered question and asks about
+// the next tarantula
+func (t *TarantulaBot) advanceRound(c tele.Context, session *UserSession, line string, result roundResult) error {
+	session.Round.answer(result)
+	t.sessions.UpdateSession(sessionKey(c), session)
+	if err := c.Edit(line); err != nil {
+		return err
+	}
+	return t.sendRoundStop(c, session)
+}
+
+func (t *TarantulaBot) handleRoundEnd(c tele.Context) error {
+	defer t.sessions.Lock(sessionKey(c))()
+	session := t.sessions.GetSession(sessionKey(c))
+	if session.CurrentState != StateFeedingRound || session.Round == nil {
+		return errStaleCallback
+	}
+	if err := c.Edit("⏹️ Round ended"); err != nil {
+		return err
+	}
+	return t.finishFeedingRound(c, session)
+}
+
+func (t *TarantulaBot) finishFeedingRound(c tele.Context, session *UserSession) error {
+	round := session.Round
+	session.reset()
+	t.sessions.UpdateSession(sessionKey(c), session)
+
+	colonies, err := t.db.GetColonyStatus(t.reqCtx(c), ownerID(c))
+	if err != nil {
+		return fmt.Errorf("failed to get colony status: %w", err)
+	}
+	return c.Send(round.summary(colonies))
+}
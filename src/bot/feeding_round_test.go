@@ -0,0 +1,109 @@
+package bot
+
+import (
+	"strings"
+	"tarantulago/models"
+	"testing"
+	"time"
+)
+
+func TestFeedingRoundOrder(t *testing.T) {
+	round := newFeedingRound([]models.TarantulaListItem{
+		{ID: 1, Name: "Loose"},
+		{ID: 2, Name: "Tenth", EnclosureName: "Rack A-10"},
+		{ID: 3, Name: "Second", EnclosureName: "rack a-2"},
+		{ID: 4, Name: "Bravo", EnclosureName: "Rack B-1"},
+		{ID: 5, Name: "Alpha", EnclosureName: "Rack B-1"},
+	})
+
+	var got []string
+	for _, stop := range round.stops {
+		got = append(got, stop.name)
+	}
+	want := "Second Tenth Alpha Bravo Loose"
+	if strings.Join(got, " ") != want {
+		t.Errorf("order = %v, want %s", got, want)
+	}
+}
+
+func TestFeedingRoundSummary(t *testing.T) {
+	round := newFeedingRound([]models.TarantulaListItem{
+		{ID: 1, Name: "Rosie", EnclosureName: "A1"},
+		{ID: 2, Name: "Boris", EnclosureName: "A2"},
+		{ID: 3, Name: "Kiki", EnclosureName: "A3"},
+		{ID: 4, Name: "Mo", EnclosureName: "A4"},
+		{ID: 5, Name: "Never", EnclosureName: "A5"},
+	})
+	colony := 7
+	answers := []roundResult{
+		{status: models.FeedingStatusAccepted, preyCount: 2, colonyID: &colony},
+		{status: models.FeedingStatusAccepted, preyCount: 3, colonyID: &colony},
+		{status: models.FeedingStatusRejected},
+		{},
+	}
+	for _, result := range answers {
+		stop, ok := round.stop()
+		if !ok {
+			t.Fatal("round ended early")
+		}
+		result.stop = stop
+		round.answer(result)
+	}
+
+	summary := round.summary([]models.ColonyStatus{{ID: 7, ColonyName: "Crickets", CurrentCount: 40}})
+	for _, line := range []string{"Fed: 2", "Refused: 1 (Kiki)", "Skipped: 1 (Mo)", "Not reached: 1", "Crickets: 5 (40 left)"} {
+		if !strings.Contains(summary, line) {
+			t.Errorf("summary is missing %q:\n%s", line, summary)
+		}
+	}
+}
+
+func TestSessionLock(t *testing.T) {
+	sm := NewSessionManager()
+	a, b := SessionKey{ChatID: 1, UserID: 1}, SessionKey{ChatID: 2, UserID: 1}
+
+	unlock := sm.Lock(a)
+	sm.Lock(b)() // other sessions aren't held up
+
+	acquired := make(chan struct{})
+	go func() {
+		defer sm.Lock(a)()
+		close(acquired)
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("second handler got the session while the first held it")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	unlock()
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("second handler never got the session")
+	}
+}
+
+func TestInFormCountsUpdatedSessions(t *testing.T) {
+	sm := NewSessionManager()
+	key := SessionKey{ChatID: 1, UserID: 1}
+	session := sm.GetSession(key)
+	session.CurrentState = StateFeedingRound
+	sm.UpdateSession(key, session)
+
+	// A handler changing the session while metrics are scraped isn't a race
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		session.CurrentState = StateIdle
+	}()
+	if got := sm.InForm(); got != 1 {
+		t.Errorf("InForm = %d, want 1", got)
+	}
+	<-done
+
+	sm.UpdateSession(key, session)
+	if got := sm.InForm(); got != 0 {
+		t.Errorf("InForm after reset = %d, want 0", got)
+	}
+}
@@ -0,0 +1,504 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// A form is a conversation declared as an ordered list of fields. The engine
+// asks each field in turn and keeps the answers in the session, offers Back,
+// Skip and Cancel at every step, and shows a summary to confirm before the
+// answers are applied and saved. Buttons and the typed words "back", "skip"
+// and "cancel" do the same thing.
+
+// fieldConfirm is the step after the last field, showing the summary
+const fieldConfirm TarantulaFormField = "confirm"
+
+const formDateLayout = "2006-01-02"
+
+type form struct {
+	intro  string
+	fields []formField
+	// save stores the applied answers and says what was done
+	save func(c tele.Context, session *UserSession) (string, error)
+}
+
+type formField struct {
+	key    TarantulaFormField
+	label  string // shown in the summary
+	prompt string
+	// def is the answer used when the field is skipped
+	def      string
+	optional bool
+	// choices are offered as buttons; typing a choice's label also picks it
+	choices    func(c tele.Context) ([]formChoice, error)
+	choiceOnly bool
+	// ask and input replace the prompt and the typed answer handling for
+	// fields the engine can't ask by itself
+	ask   func(c tele.Context) (string, [][]tele.InlineButton, error)
+	input func(c tele.Context, session *UserSession) error
+
+	parse func(text string) (any, error)
+	apply func(session *UserSession, value any)
+}
+
+type formChoice struct {
+	label string
+	value string
+}
+
+// formAnswer is a parsed answer and how the summary shows it
+type formAnswer struct {
+	value   any
+	display string
+}
+
+// newField declares a field whose answers parse to V. validate may be nil.
+func newField[V any](key TarantulaFormField, label, prompt string, parse func(string) (V, error), validate func(V) error, apply func(*UserSession, V)) formField {
+	return formField{
+		key:    key,
+		label:  label,
+		prompt: prompt,
+		parse: func(text string) (any, error) {
+			value, err := parse(text)
+			if err != nil {
+				return nil, err
+			}
+			if validate != nil {
+				if err := validate(value); err != nil {
+					return nil, err
+				}
+			}
+			return value, nil
+		},
+		apply: func(session *UserSession, value any) {
+			apply(session, value.(V))
+		},
+	}
+}
+
+func (f formField) skippable() bool {
+	return f.optional || f.def != ""
+}
+
+// Parsers for the common kinds of answer
+
+func parseText(text string) (string, error) {
+	if text == "" {
+		return "", errors.New("Please type an answer")
+	}
+	return text, nil
+}
+
+func parseWholeNumber(text string) (int, error) {
+	n, err := strconv.Atoi(text)
+	if err != nil {
+		return 0, errors.New("Please enter a whole number")
+	}
+	return n, nil
+}
+
+func parseDecimal(text string) (float64, error) {
+	n, err := strconv.ParseFloat(strings.ReplaceAll(text, ",", "."), 64)
+	if err != nil {
+		return 0, errors.New("Please enter a number, e.g. 4.5")
+	}
+	return n, nil
+}
+
+// parseFormDate reads YYYY-MM-DD, or "today"
+func parseFormDate(text string) (time.Time, error) {
+	if strings.EqualFold(text, "today") {
+		year, month, day := time.Now().Date()
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC), nil
+	}
+	date, err := time.Parse(formDateLayout, text)
+	if err != nil {
+		return time.Time{}, errors.New("Please enter the date in YYYY-MM-DD format")
+	}
+	return date, nil
+}
+
+// Validators
+
+func notNegative(n int) error {
+	if n < 0 {
+		return errors.New("The number can't be negative")
+	}
+	return nil
+}
+
+func lengthCM(n float64) error {
+	if n <= 0 || n > 40 {
+		return errors.New("Please enter a length between 0 and 40 cm")
+	}
+	return nil
+}
+
+func notInFuture(date time.Time) error {
+	if date.After(time.Now()) {
+		return errors.New("The date can't be in the future")
+	}
+	return nil
+}
+
+func formatFormValue(value any) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format(formDateLayout)
+	case bool:
+		if v {
+			return "Yes"
+		}
+		return "No"
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func (f *form) index(key TarantulaFormField) int {
+	return slices.IndexFunc(f.fields, func(field formField) bool { return field.key == key })
+}
+
+func (f *form) field(key TarantulaFormField) (formField, bool) {
+	i := f.index(key)
+	if i < 0 {
+		return formField{}, false
+	}
+	return f.fields[i], true
+}
+
+// next is the step after key: the following field, or the summary
+func (f *form) next(key TarantulaFormField) TarantulaFormField {
+	i := f.index(key)
+	if i < 0 || i+1 >= len(f.fields) {
+		return fieldConfirm
+	}
+	return f.fields[i+1].key
+}
+
+// previous is the step before key, or "" on the first field
+func (f *form) previous(key TarantulaFormField) TarantulaFormField {
+	if key == fieldConfirm {
+		return f.fields[len(f.fields)-1].key
+	}
+	i := f.index(key)
+	if i <= 0 {
+		return ""
+	}
+	return f.fields[i-1].key
+}
+
+// summary lists every answer, with a dash for skipped fields
+func (f *form) summary(answers map[TarantulaFormField]formAnswer) string {
+	var b strings.Builder
+	b.WriteString("📋 Please check your answers:\n\n")
+	for _, field := range f.fields {
+		display := "—"
+		if answer, ok := answers[field.key]; ok && answer.display != "" {
+			display = answer.display
+		}
+		b.WriteString(fmt.Sprintf("%s: %s\n", field.label, display))
+	}
+	return b.String()
+}
+
+// startForm opens the form behind state with a fresh session; prepare can
+// fill in what the form doesn't ask, such as the tarantula being recorded
+func (t *TarantulaBot) startForm(c tele.Context, state FormState, prepare func(*UserSession)) error {
+	f, ok := t.forms[state]
+	if !ok {
+		return fmt.Errorf("no form declared for %s", state)
+	}
+
+	defer t.sessions.Lock(sessionKey(c))()
+	session := t.sessions.GetSession(sessionKey(c))
+	session.reset()
+	session.CurrentState = state
+	session.CurrentField = f.fields[0].key
+	if prepare != nil {
+		prepare(session)
+	}
+	t.sessions.UpdateSession(sessionKey(c), session)
+
+	if f.intro != "" {
+		if err := c.Send(f.intro); err != nil {
+			return err
+		}
+	}
+	return t.askFormField(c, session)
+}
+
+// askFormField sends the question for the session's current step
+func (t *TarantulaBot) askFormField(c tele.Context, session *UserSession) error {
+	f := t.forms[session.CurrentState]
+	if session.CurrentField == fieldConfirm {
+		markup := &tele.ReplyMarkup{InlineKeyboard: [][]tele.InlineButton{
+			{formSaveAction.Button("✅ Save")},
+			{formBackAction.Button("⬅️ Back", fieldConfirm), formCancelAction.Button("✖️ Cancel")},
+		}}
+		return c.Send(f.summary(session.FormAnswers), markup)
+	}
+
+	field, ok := f.field(session.CurrentField)
+	if !ok {
+		return fmt.Errorf("form %s has no field %s", session.CurrentState, session.CurrentField)
+	}
+
+	prompt := field.prompt
+	var rows [][]tele.InlineButton
+	switch {
+	case field.ask != nil:
+		var err error
+		if prompt, rows, err = field.ask(c); err != nil {
+			return err
+		}
+	case field.choices != nil:
+		choices, err := field.choices(c)
+		if err != nil {
+			return fmt.Errorf("failed to load choices for %s: %w", field.key, err)
+		}
+		rows = choiceRows(field.key, choices)
+	}
+
+	if answer, ok := session.FormAnswers[field.key]; ok && answer.display != "" {
+		prompt += fmt.Sprintf("\n\n(Currently: %s)", answer.display)
+	}
+
+	var controls []tele.InlineButton
+	if f.previous(field.key) != "" {
+		controls = append(controls, formBackAction.Button("⬅️ Back", field.key))
+	}
+	if field.skippable() {
+		controls = append(controls, formSkipAction.Button("⏭ Skip", field.key))
+	}
+	controls = append(controls, formCancelAction.Button("✖️ Cancel"))
+
+	return c.Send(prompt, &tele.ReplyMarkup{InlineKeyboard: append(rows, controls)})
+}
+
+func choiceRows(key TarantulaFormField, choices []formChoice) [][]tele.InlineButton {
+	var rows [][]tele.InlineButton
+	var row []tele.InlineButton
+	for _, choice := range choices {
+		row = append(row, formChoiceAction.Button(choice.label, key, choice.value))
+		if len(row) == 2 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// handleFormInput takes a typed answer, or a typed back, skip or cancel
+func (t *TarantulaBot) handleFormInput(c tele.Context, session *UserSession) error {
+	f := t.forms[session.CurrentState]
+	text := strings.TrimSpace(c.Text())
+
+	switch strings.ToLower(text) {
+	case "cancel":
+		return t.cancelForm(c, session)
+	case "back":
+		return t.formBack(c, session)
+	case "skip":
+		if session.CurrentField != fieldConfirm {
+			return t.formSkip(c, session)
+		}
+	}
+
+	if session.CurrentField == fieldConfirm {
+		if strings.EqualFold(text, "save") || strings.EqualFold(text, "yes") {
+			return t.saveForm(c, session)
+		}
+		return c.Send("Tap ✅ Save to keep these answers, or Back to change one.")
+	}
+
+	field, ok := f.field(session.CurrentField)
+	if !ok {
+		return fmt.Errorf("form %s has no field %s", session.CurrentState, session.CurrentField)
+	}
+	if field.input != nil {
+		return field.input(c, session)
+	}
+
+	if field.choices != nil {
+		choices, err := field.choices(c)
+		if err != nil {
+			return fmt.Errorf("failed to load choices for %s: %w", field.key, err)
+		}
+		for _, choice := range choices {
+			if strings.EqualFold(text, choice.label) || strings.EqualFold(text, choice.value) {
+				return t.answerFormChoice(c, session, field, choice)
+			}
+		}
+		if field.choiceOnly {
+			return c.Send("Please choose one of the options above")
+		}
+	}
+
+	value, err := field.parse(text)
+	if err != nil {
+		return c.Send(err.Error())
+	}
+	return t.answerForm(c, session, formAnswer{value: value, display: formatFormValue(value)})
+}
+
+func (t *TarantulaBot) answerFormChoice(c tele.Context, session *UserSession, field formField, choice formChoice) error {
+	value, err := field.parse(choice.value)
+	if err != nil {
+		return c.Send(err.Error())
+	}
+	return t.answerForm(c, session, formAnswer{value: value, display: choice.label})
+}
+
+// answerForm records the answer to the current field and asks the next one
+func (t *TarantulaBot) answerForm(c tele.Context, session *UserSession, answer formAnswer) error {
+	if session.FormAnswers == nil {
+		session.FormAnswers = make(map[TarantulaFormField]formAnswer)
+	}
+	session.FormAnswers[session.CurrentField] = answer
+	session.CurrentField = t.forms[session.CurrentState].next(session.CurrentField)
+	t.sessions.UpdateSession(sessionKey(c), session)
+
+	return t.askFormField(c, session)
+}
+
+func (t *TarantulaBot) formBack(c tele.Context, session *UserSession) error {
+	previous := t.forms[session.CurrentState].previous(session.CurrentField)
+	if previous == "" {
+		return c.Send("This is the first question. Type 'cancel' to stop.")
+	}
+	session.CurrentField = previous
+	t.sessions.UpdateSession(sessionKey(c), session)
+
+	return t.askFormField(c, session)
+}
+
+// formSkip answers with the field's default, or leaves it empty
+func (t *TarantulaBot) formSkip(c tele.Context, session *UserSession) error {
+	f := t.forms[session.CurrentState]
+	field, ok := f.field(session.CurrentField)
+	if !ok || !field.skippable() {
+		return c.Send("This one can't be skipped.")
+	}
+
+	if field.def != "" {
+		value, err := field.parse(field.def)
+		if err != nil {
+			return fmt.Errorf("bad default for %s: %w", field.key, err)
+		}
+		return t.answerForm(c, session, formAnswer{value: value, display: formatFormValue(value)})
+	}
+
+	delete(session.FormAnswers, field.key)
+	session.CurrentField = f.next(field.key)
+	t.sessions.UpdateSession(sessionKey(c), session)
+
+	return t.askFormField(c, session)
+}
+
+func (t *TarantulaBot) cancelForm(c tele.Context, session *UserSession) error {
+	session.reset()
+	t.sessions.UpdateSession(sessionKey(c), session)
+	return SendInfo(c, "Cancelled. Nothing was saved.")
+}
+
+// saveForm applies the answers to the session and saves them. The session
+// is kept if saving fails, so Save can be tried again.
+func (t *TarantulaBot) saveForm(c tele.Context, session *UserSession) error {
+	f := t.forms[session.CurrentState]
+	for _, field := range f.fields {
+		if answer, ok := session.FormAnswers[field.key]; ok {
+			field.apply(session, answer.value)
+		}
+	}
+
+	msg, err := f.save(c, session)
+	if err != nil {
+		return err
+	}
+
+	session.reset()
+	t.sessions.UpdateSession(sessionKey(c), session)
+	return sendSuccess(c, msg)
+}
+
+// formSession is the session behind a form button, if it's still on step key.
+// Callers hold the session lock, so a second tap on Save finds the form
+// already saved and reset.
+func (t *TarantulaBot) formSession(c tele.Context, key TarantulaFormField) (*UserSession, error) {
+	session := t.sessions.GetSession(sessionKey(c))
+	if _, ok := t.forms[session.CurrentState]; !ok || session.CurrentField != key {
+		return nil, errStaleCallback
+	}
+	return session, nil
+}
+
+func (t *TarantulaBot) handleFormChoice(c tele.Context, key TarantulaFormField, value string) error {
+	defer t.sessions.Lock(sessionKey(c))()
+	session, err := t.formSession(c, key)
+	if err != nil {
+		return err
+	}
+	field, _ := t.forms[session.CurrentState].field(key)
+	if field.choices == nil {
+		return errStaleCallback
+	}
+
+	choices, err := field.choices(c)
+	if err != nil {
+		return fmt.Errorf("failed to load choices for %s: %w", key, err)
+	}
+	i := slices.IndexFunc(choices, func(choice formChoice) bool { return choice.value == value })
+	if i < 0 {
+		return errStaleCallback
+	}
+	return t.answerFormChoice(c, session, field, choices[i])
+}
+
+func (t *TarantulaBot) handleFormBack(c tele.Context, key TarantulaFormField) error {
+	defer t.sessions.Lock(sessionKey(c))()
+	session, err := t.formSession(c, key)
+	if err != nil {
+		return err
+	}
+	return t.formBack(c, session)
+}
+
+func (t *TarantulaBot) handleFormSkip(c tele.Context, key TarantulaFormField) error {
+	defer t.sessions.Lock(sessionKey(c))()
+	session, err := t.formSession(c, key)
+	if err != nil {
+		return err
+	}
+	return t.formSkip(c, session)
+}
+
+func (t *TarantulaBot) handleFormSave(c tele.Context) error {
+	defer t.sessions.Lock(sessionKey(c))()
+	session, err := t.formSession(c, fieldConfirm)
+	if err != nil {
+		return err
+	}
+	return t.saveForm(c, session)
+}
+
+func (t *TarantulaBot) handleFormCancel(c tele.Context) error {
+	defer t.sessions.Lock(sessionKey(c))()
+	session := t.sessions.GetSession(sessionKey(c))
+	if _, ok := t.forms[session.CurrentState]; !ok {
+		return errStaleCallback
+	}
+	return t.cancelForm(c, session)
+}
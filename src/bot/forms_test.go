@@ -0,0 +1,111 @@
+package bot
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+func TestFormNavigation(t *testing.T) {
+	f := (&TarantulaBot{}).moltForm()
+
+	if got := f.next(FieldPreMoltLengthCM); got != FieldPostMoltLengthCM {
+		t.Errorf("next(pre) = %q", got)
+	}
+	if got := f.next(FieldSuccess); got != fieldConfirm {
+		t.Errorf("next(last) = %q, want the summary", got)
+	}
+	if got := f.previous(FieldPreMoltLengthCM); got != "" {
+		t.Errorf("previous(first) = %q, want none", got)
+	}
+	if got := f.previous(fieldConfirm); got != FieldSuccess {
+		t.Errorf("previous(summary) = %q", got)
+	}
+
+	summary := f.summary(map[TarantulaFormField]formAnswer{
+		FieldPreMoltLengthCM:  {value: 4.5, display: "4.5"},
+		FieldPostMoltLengthCM: {value: 5.25, display: "5.25"},
+		FieldSuccess:          {value: true, display: "Yes"},
+	})
+	for _, line := range []string{"Length before (cm): 4.5", "Length after (cm): 5.25", "Notes: —", "Successful: Yes"} {
+		if !strings.Contains(summary, line) {
+			t.Errorf("summary is missing %q:\n%s", line, summary)
+		}
+	}
+}
+
+func TestFormFieldParsing(t *testing.T) {
+	f := (&TarantulaBot{}).tarantulaForm()
+	tests := []struct {
+		field TarantulaFormField
+		text  string
+		ok    bool
+	}{
+		{FieldCurrentSize, "4,5", true},
+		{FieldCurrentSize, "0", false},
+		{FieldCurrentSize, "big", false},
+		{FieldAge, "-3", false},
+		{FieldAcquisitionDate, "2024-02-30", false},
+		{FieldAcquisitionDate, time.Now().AddDate(0, 0, 2).Format(formDateLayout), false},
+		{FieldAcquisitionDate, "today", true},
+	}
+	for _, tt := range tests {
+		field, _ := f.field(tt.field)
+		if _, err := field.parse(tt.text); (err == nil) != tt.ok {
+			t.Errorf("%s %q: error %v, want ok %v", tt.field, tt.text, err, tt.ok)
+		}
+	}
+
+	health, _ := f.field(FieldHealthStatus)
+	value, err := health.parse(health.def)
+	if err != nil || !health.skippable() {
+		t.Fatalf("health default %q: %v", health.def, err)
+	}
+	var session UserSession
+	health.apply(&session, value)
+	if session.TarantulaData.CurrentHealthStatusID != 1 {
+		t.Errorf("health default applied as %d", session.TarantulaData.CurrentHealthStatusID)
+	}
+}
+
+type fakeChatContext struct {
+	tele.Context
+}
+
+func (fakeChatContext) Chat() *tele.Chat               { return &tele.Chat{ID: 5} }
+func (fakeChatContext) Sender() *tele.User             { return &tele.User{ID: 5} }
+func (fakeChatContext) Send(any, ...interface{}) error { return nil }
+
+func TestFormSavedOnce(t *testing.T) {
+	var saves atomic.Int32
+	bot := &TarantulaBot{sessions: NewSessionManager(), forms: map[FormState]*form{
+		StateAddingMolt: {
+			fields: []formField{{key: FieldMoltNotes}},
+			save: func(tele.Context, *UserSession) (string, error) {
+				saves.Add(1)
+				time.Sleep(10 * time.Millisecond)
+				return "saved", nil
+			},
+		},
+	}}
+	c := fakeChatContext{}
+	bot.sessions.UpdateSession(sessionKey(c), &UserSession{CurrentState: StateAddingMolt, CurrentField: fieldConfirm})
+
+	// A double tap on Save
+	var wg sync.WaitGroup
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = bot.handleFormSave(c)
+		}()
+	}
+	wg.Wait()
+	if n := saves.Load(); n != 1 {
+		t.Errorf("form saved %d times, want once", n)
+	}
+}
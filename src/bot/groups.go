@@ -0,0 +1,150 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+	"tarantulago/models"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// Group chats. A group bound to a collection works in it for everyone in the
+// group; each member keeps their own forms, and keyboards and prompts are
+// addressed to whoever asked for them.
+
+const unboundGroupMessage = "🕷 This group isn't linked to a collection yet. The collection owner can link it with /bind (or /bind viewer for view-only)."
+
+func isGroupChat(chat *tele.Chat) bool {
+	return chat != nil && (chat.Type == tele.ChatGroup || chat.Type == tele.ChatSuperGroup)
+}
+
+// command is the command a message starts with, without any @botname suffix
+func command(c tele.Context) string {
+	if c.Message() == nil || !strings.HasPrefix(c.Message().Text, "/") {
+		return ""
+	}
+	cmd, _, _ := strings.Cut(strings.Fields(c.Message().Text)[0], "@")
+	return cmd
+}
+
+// groupContext addresses replies in a group to the member being served
+type groupContext struct {
+	tele.Context
+	inForm func() bool
+}
+
+func (c groupContext) Send(what interface{}, opts ...interface{}) error {
+	var replyTo *tele.Message
+	if c.Callback() == nil {
+		replyTo = c.Message()
+	}
+	return c.Context.Send(what, selectiveOptions(opts, replyTo, c.inForm())...)
+}
+
+// selectiveOptions shows reply keyboards only to the member replied to, and
+// turns a form's prompts into forced replies so the answer reaches the bot
+// even with group privacy mode on
+func selectiveOptions(opts []interface{}, replyTo *tele.Message, inForm bool) []interface{} {
+	hasMarkup := false
+	selective := make([]interface{}, 0, len(opts)+2)
+	for _, opt := range opts {
+		if markup, ok := opt.(*tele.ReplyMarkup); ok && markup != nil {
+			hasMarkup = true
+			if markup.InlineKeyboard == nil {
+				m := *markup
+				m.Selective = true
+				opt = &m
+			}
+		}
+		selective = append(selective, opt)
+	}
+
+	if !hasMarkup && inForm {
+		selective = append(selective, &tele.ReplyMarkup{ForceReply: true, Selective: true})
+	}
+	if replyTo != nil {
+		selective = append(selective, &tele.ReplyParams{MessageID: replyTo.ID, AllowWithoutReply: true})
+	}
+	return selective
+}
+
+// answerUnboundGroup points a group that isn't linked yet at /bind
+func answerUnboundGroup(c tele.Context) error {
+	switch {
+	case c.Callback() != nil:
+		return c.Respond(&tele.CallbackResponse{Text: unboundGroupMessage, ShowAlert: true})
+	case command(c) != "":
+		return c.Send(unboundGroupMessage)
+	}
+	return nil
+}
+
+func (t *TarantulaBot) handleGroupAdded(c tele.Context) error {
+	return c.Send("🕷 Hi! I can look after a shared tarantula collection here.\n\n" +
+		"The collection owner links this group with /bind, so everyone can log feedings and molts together " +
+		"(/bind viewer lets the group look but not record). Members invited to the collection keep their own role.\n\n" +
+		"Reminders come to the group once it's linked; turn them off with /groupreminders off.")
+}
+
+func (t *TarantulaBot) handleBindGroup(c tele.Context) error {
+	if !isGroupChat(c.Chat()) {
+		return SendInfo(c, "Add me to a group and send /bind there to share your collection with it.")
+	}
+	if ownerID(c) != c.Sender().ID {
+		return SendError(c, "Only the collection owner can link this group")
+	}
+
+	role := models.CollectionRoleEditor
+	if c.Message().Payload == models.CollectionRoleViewer {
+		role = models.CollectionRoleViewer
+	}
+
+	err := t.db.BindGroupChat(t.reqCtx(c), models.GroupChat{
+		ChatID:  c.Chat().ID,
+		OwnerID: c.Sender().ID,
+		Title:   c.Chat().Title,
+		Role:    role,
+		BoundBy: c.Sender().ID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to link group: %w", err)
+	}
+
+	return sendSuccess(c, fmt.Sprintf("This group now works in %s's collection; members are %ss unless invited otherwise. "+
+		"Send /start for the menu. Reminders will come here.", c.Sender().FirstName, role))
+}
+
+func (t *TarantulaBot) handleUnbindGroup(c tele.Context) error {
+	if !isGroupChat(c.Chat()) {
+		return SendInfo(c, "Send /unbind in the group you want to unlink.")
+	}
+
+	if err := t.db.UnbindGroupChat(t.reqCtx(c), c.Chat().ID, c.Sender().ID); err != nil {
+		return fmt.Errorf("failed to unlink group: %w", err)
+	}
+	return sendSuccess(c, "This group is no longer linked to your collection.")
+}
+
+func (t *TarantulaBot) handleGroupReminders(c tele.Context) error {
+	if !isGroupChat(c.Chat()) {
+		return SendInfo(c, "Send /groupreminders on or off in a linked group.")
+	}
+
+	var enabled bool
+	switch strings.ToLower(c.Message().Payload) {
+	case "on":
+		enabled = true
+	case "off":
+		enabled = false
+	default:
+		return c.Send("Use /groupreminders on or /groupreminders off")
+	}
+
+	if err := t.db.SetGroupReminders(t.reqCtx(c), c.Chat().ID, c.Sender().ID, enabled); err != nil {
+		return fmt.Errorf("failed to change reminders: %w", err)
+	}
+	if enabled {
+		return sendSuccess(c, "Reminders and alerts for this collection will come to the group.")
+	}
+	return sendSuccess(c, "Reminders go back to whoever is on duty.")
+}
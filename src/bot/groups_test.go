@@ -0,0 +1,38 @@
+package bot
+
+import (
+	"testing"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+func TestSelectiveOptions(t *testing.T) {
+	menuMarkup := &tele.ReplyMarkup{ReplyKeyboard: [][]tele.ReplyButton{{{Text: "🕷 Tarantulas"}}}}
+	request := &tele.Message{ID: 42}
+
+	opts := selectiveOptions([]interface{}{menuMarkup, tele.ModeMarkdown}, request, false)
+	markup, ok := opts[0].(*tele.ReplyMarkup)
+	if !ok || !markup.Selective {
+		t.Fatalf("reply keyboard not made selective: %#v", opts[0])
+	}
+	if menuMarkup.Selective {
+		t.Error("shared menu markup was modified")
+	}
+	if reply, ok := opts[len(opts)-1].(*tele.ReplyParams); !ok || reply.MessageID != 42 {
+		t.Errorf("last option = %#v, want a reply to the request", opts[len(opts)-1])
+	}
+
+	opts = selectiveOptions(nil, nil, true)
+	if len(opts) != 1 {
+		t.Fatalf("form prompt options = %#v, want only a forced reply", opts)
+	}
+	if markup, ok := opts[0].(*tele.ReplyMarkup); !ok || !markup.ForceReply || !markup.Selective {
+		t.Errorf("form prompt not a selective forced reply: %#v", opts[0])
+	}
+
+	inline := &tele.ReplyMarkup{InlineKeyboard: [][]tele.InlineButton{{{Text: "Feed"}}}}
+	opts = selectiveOptions([]interface{}{inline}, nil, true)
+	if len(opts) != 1 || opts[0] != inline {
+		t.Errorf("inline keyboard options = %#v, want it untouched", opts)
+	}
+}
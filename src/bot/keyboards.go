@@ -1,7 +1,6 @@
 package bot
 
 import (
-	"context"
 	"fmt"
 	"io"
 	"strconv"
@@ -14,13 +13,13 @@ import (
 )
 
 type Menu struct {
-	main             *tele.ReplyMarkup
-	tarantula        *tele.ReplyMarkup
-	settings         *tele.ReplyMarkup
-	colony           *tele.ReplyMarkup
-	tarantulaColony  *tele.ReplyMarkup
-	analytics        *tele.ReplyMarkup
-	back             tele.Btn
+	main            *tele.ReplyMarkup
+	tarantula       *tele.ReplyMarkup
+	settings        *tele.ReplyMarkup
+	colony          *tele.ReplyMarkup
+	tarantulaColony *tele.ReplyMarkup
+	analytics       *tele.ReplyMarkup
+	back            tele.Btn
 }
 
 var (
@@ -35,13 +34,15 @@ var (
 		analytics:       &tele.ReplyMarkup{ResizeKeyboard: true},
 		back:            btnBackToMain,
 	}
-	btnAddTarantula     = menu.tarantula.Text("➕ Add New Tarantula")
-	btnListTarantulas   = menu.tarantula.Text("📋 List Tarantulas")
-	btnViewMolts        = menu.tarantula.Text("📊 View Molt History")
-	btnQuickActions     = menu.tarantula.Text("⚡ Quick Actions")
-	btnManageColonies   = menu.tarantula.Text("👥 Manage Colonies")
+	btnAddTarantula   = menu.tarantula.Text("➕ Add New Tarantula")
+	btnListTarantulas = menu.tarantula.Text("📋 List Tarantulas")
+	btnViewMolts      = menu.tarantula.Text("📊 View Molt History")
+	btnQuickActions   = menu.tarantula.Text("⚡ Quick Actions")
+	btnManageColonies = menu.tarantula.Text("👥 Manage Colonies")
+	btnMySpecies      = menu.tarantula.Text("🧬 My Species")
+	btnCareSheets     = menu.tarantula.Text("📖 Care Sheets")
 
-	btnColonyStatus   = menu.colony.Text("📊 Cricket Status")
+	btnColonyStatus   = menu.colony.Text("📊 Feeder Status")
 	btnUpdateCount    = menu.colony.Text("🔢 Update Cricket Count")
 	btnFeedingHistory = menu.colony.Text("📈 Feeding History")
 
@@ -54,6 +55,7 @@ var (
 	btnGrowthCharts    = menu.analytics.Text("📈 Growth Charts")
 	btnAnnualReports   = menu.analytics.Text("📋 Annual Reports")
 	btnMoltPredictions = menu.analytics.Text("🔮 Molt Predictions")
+	btnSpending        = menu.analytics.Text("💰 Spending")
 
 	btnTarantulas = menu.main.Text("🕷 Tarantulas")
 	btnFeeding    = menu.main.Text("🪱 Feeding")
@@ -63,6 +65,7 @@ var (
 
 	btnNotifications      = menu.settings.Text("🔔 Notification Settings")
 	btnPauseNotifications = menu.settings.Text("⏸️ Pause Notifications")
+	btnSharing            = menu.settings.Text("👥 Sharing")
 )
 
 func (m *Menu) init() {
@@ -75,7 +78,8 @@ func (m *Menu) init() {
 	m.tarantula.Reply(
 		m.tarantula.Row(btnAddTarantula, btnListTarantulas),
 		m.tarantula.Row(btnViewMolts, btnQuickActions),
-		m.tarantula.Row(btnManageColonies),
+		m.tarantula.Row(btnManageColonies, btnMySpecies),
+		m.tarantula.Row(btnCareSheets),
 		m.tarantula.Row(m.back),
 	)
 
@@ -94,12 +98,14 @@ func (m *Menu) init() {
 	m.analytics.Reply(
 		m.analytics.Row(btnFeedingPatterns, btnGrowthCharts),
 		m.analytics.Row(btnAnnualReports, btnMoltPredictions),
+		m.analytics.Row(btnSpending),
 		m.analytics.Row(m.back),
 	)
 
 	menu.settings.Reply(
 		menu.settings.Row(btnNotifications),
 		menu.settings.Row(btnPauseNotifications),
+		menu.settings.Row(btnSharing),
 		menu.settings.Row(menu.back),
 	)
 }
@@ -107,26 +113,37 @@ func (m *Menu) init() {
 func (t *TarantulaBot) setupHandlers() {
 	menu.init()
 	b := t.bot
+	b.Use(t.trackHandlers, t.correlateUpdates, reportErrors, measureUpdates, t.collectionMiddleware)
 	b.Handle("/start", func(c tele.Context) error {
-		err := t.db.EnsureUserExists(context.Background(), &models.TelegramUser{
+		// Keep reaching the keeper privately when they start the bot in a group
+		chatID := c.Chat().ID
+		if isGroupChat(c.Chat()) {
+			chatID = c.Sender().ID
+		}
+		err := t.db.EnsureUserExists(t.reqCtx(c), &models.TelegramUser{
 			TelegramID: c.Sender().ID,
 			FirstName:  c.Sender().FirstName,
-			ChatID:     c.Chat().ID,
+			ChatID:     chatID,
 			LastName:   c.Sender().LastName,
 			Username:   c.Sender().Username,
 		})
 		if err != nil {
 			return fmt.Errorf("failed to ensure user exists: %w", err)
 		}
+		if code, ok := strings.CutPrefix(c.Message().Payload, joinPayloadPrefix); ok {
+			if err := t.handleJoinCollection(c, code); err != nil {
+				return err
+			}
+		}
 		return c.Send("🕷 Welcome to TarantulaGo! Choose an option:", menu.main)
 	})
 
 	b.Handle("/check", func(c tele.Context) error {
-		settings, err := t.db.GetUserSettings(t.ctx, c.Sender().ID)
+		settings, err := t.db.GetUserSettings(t.reqCtx(c), c.Sender().ID)
 		if err != nil {
 			return fmt.Errorf("failed to get user settings: %w", err)
 		}
-		t.notifications.triggerChecks(models.TelegramUser{
+		t.notifications.triggerChecks(t.reqCtx(c), models.TelegramUser{
 			TelegramID: c.Sender().ID,
 			FirstName:  c.Sender().FirstName,
 			ChatID:     c.Chat().ID,
@@ -153,16 +170,21 @@ func (t *TarantulaBot) setupHandlers() {
 	})
 
 	b.Handle(&btnBackToMain, func(c tele.Context) error {
-		session := t.sessions.GetSession(c.Sender().ID)
+		session := t.sessions.GetSession(sessionKey(c))
 		if session != nil {
 			session.reset()
-			t.sessions.UpdateSession(c.Sender().ID, session)
+			t.sessions.UpdateSession(sessionKey(c), session)
 		}
 		return c.Send("Main Menu:", menu.main)
 	})
 
 	b.Handle(&btnNotifications, t.handleNotificationSettings)
 	b.Handle(&btnPauseNotifications, t.handlePauseNotificationSettings)
+	b.Handle(&btnSharing, t.handleSharing)
+	b.Handle("/bind", t.handleBindGroup)
+	b.Handle("/unbind", t.handleUnbindGroup)
+	b.Handle("/groupreminders", t.handleGroupReminders)
+	b.Handle(tele.OnAddedToGroup, t.handleGroupAdded)
 
 	b.Handle(&btnSettings, func(c tele.Context) error {
 		return c.Send("⚙️ Settings:", menu.settings)
@@ -173,9 +195,9 @@ func (t *TarantulaBot) setupHandlers() {
 	})
 
 	b.Handle(&btnFeedingPatterns, func(c tele.Context) error {
-		patterns, err := t.db.GetAllFeedingPatterns(context.Background(), c.Sender().ID)
+		patterns, err := t.db.GetAllFeedingPatterns(t.reqCtx(c), ownerID(c))
 		if err != nil {
-			return SendError(c, fmt.Sprintf("Error: %v", err))
+			return fmt.Errorf("failed to get feeding patterns: %w", err)
 		}
 
 		if len(patterns) == 0 {
@@ -191,9 +213,9 @@ func (t *TarantulaBot) setupHandlers() {
 	})
 
 	b.Handle(&btnGrowthCharts, func(c tele.Context) error {
-		growthData, err := t.db.GetAllGrowthData(context.Background(), c.Sender().ID)
+		growthData, err := t.db.GetAllGrowthData(t.reqCtx(c), ownerID(c))
 		if err != nil {
-			return SendError(c, fmt.Sprintf("Error: %v", err))
+			return fmt.Errorf("failed to get growth data: %w", err)
 		}
 
 		if len(growthData) == 0 {
@@ -210,7 +232,7 @@ func (t *TarantulaBot) setupHandlers() {
 
 	b.Handle(&btnAnnualReports, func(c tele.Context) error {
 		currentYear := time.Now().Year()
-		reports, err := t.db.GetAllAnnualReports(context.Background(), currentYear, c.Sender().ID)
+		reports, err := t.db.GetAllAnnualReports(t.reqCtx(c), currentYear, ownerID(c))
 		if err != nil {
 			return fmt.Errorf("failed to get annual reports: %w", err)
 		}
@@ -221,12 +243,13 @@ func (t *TarantulaBot) setupHandlers() {
 
 		msg := fmt.Sprintf("📋 *Annual Report %d*\n\n", currentYear)
 
-		var totalCrickets int32
+		var totalPrey int32
+		var totalMass float64
 		var totalCost float64
 
 		for _, report := range reports {
 			msg += fmt.Sprintf("*%s*\n", report.TarantulaName)
-			msg += fmt.Sprintf("🍽️ Fed %d times (%d crickets)\n", report.TotalFeedings, report.TotalCrickets)
+			msg += fmt.Sprintf("🍽️ Fed %d times (%d prey, %.1fg)\n", report.TotalFeedings, report.TotalPreyItems, report.TotalPreyMassGrams)
 			msg += fmt.Sprintf("✅ %.1f%% acceptance rate\n", report.AcceptanceRate)
 
 			if report.MoltCount > 0 {
@@ -244,56 +267,53 @@ func (t *TarantulaBot) setupHandlers() {
 				}
 			}
 
-			msg += fmt.Sprintf("💰 Est. cost: $%.2f\n\n", report.EstimatedCost)
+			msg += fmt.Sprintf("💰 Prey: $%.2f", report.PreyCost)
+			if report.ExpenseCost > 0 {
+				msg += fmt.Sprintf(", other: $%.2f", report.ExpenseCost)
+			}
+			msg += "\n\n"
 
-			totalCrickets += report.TotalCrickets
-			totalCost += report.EstimatedCost
+			totalPrey += report.TotalPreyItems
+			totalMass += report.TotalPreyMassGrams
+			totalCost += report.PreyCost + report.ExpenseCost
 		}
 
 		msg += "📊 *Total Summary*\n"
-		msg += fmt.Sprintf("🦗 %d crickets consumed\n", totalCrickets)
-		msg += fmt.Sprintf("💰 $%.2f estimated cost\n", totalCost)
+		msg += fmt.Sprintf("🦗 %d prey items (%.1fg) consumed\n", totalPrey, totalMass)
+		msg += fmt.Sprintf("💰 $%.2f spent on your tarantulas\n", totalCost)
+		msg += "_Prey is priced from your feeder purchases. See 💰 Spending for the full ledger._\n"
 
 		return c.Send(msg, tele.ModeMarkdown)
 	})
 
+	b.Handle(&btnSpending, func(c tele.Context) error {
+		return t.handleSpendingOverview(c)
+	})
+
 	b.Handle(&btnMoltPredictions, func(c tele.Context) error {
 		return t.handleMoltPredictionsOverview(c)
 	})
 
 	b.Handle(&btnColonyStatus, func(c tele.Context) error {
-		colonyStatuses, err := t.db.GetColonyStatus(context.Background(), c.Sender().ID)
+		colonyStatuses, err := t.db.GetColonyStatus(t.reqCtx(c), ownerID(c))
 		if err != nil {
 			return fmt.Errorf("failed to get colony status: %w", err)
 		}
-		var msg string
+		markup := &tele.ReplyMarkup{
+			InlineKeyboard: [][]tele.InlineButton{{
+				addFeederColonyAction.Button("➕ Add Feeder Colony"),
+				breedingAction.Button("🥚 Breeding", defaultProjectionWeeks),
+				feederForecastAction.Button("📦 Forecast"),
+			}},
+		}
 		if len(colonyStatuses) == 0 {
-			msg = "🦗 No cricket colony found.\n\n💡 Use 'Update Cricket Count' to set your current cricket amount!"
-		} else {
-			colony := colonyStatuses[0]
-			msg = fmt.Sprintf("🦗 *Cricket Status*\n\n"+
-				"Current Count: *%d crickets*\n"+
-				"Used in last 7 days: *%d crickets*\n",
-				colony.CurrentCount, colony.CricketsUsed7Days)
-
-			if colony.WeeksRemaining != nil {
-				if *colony.WeeksRemaining < 2 {
-					msg += fmt.Sprintf("⚠️ Low stock: ~%.1f weeks remaining\n", *colony.WeeksRemaining)
-				} else {
-					msg += fmt.Sprintf("✅ Stock: ~%.1f weeks remaining\n", *colony.WeeksRemaining)
-				}
-			}
+			return c.Send("🦗 No feeder colonies found.\n\n💡 Add a colony of crickets, roaches or worms to track your stock!", markup)
 		}
-		return c.Send(msg, tele.ModeMarkdown)
+		return c.Send(FormatColonyStatuses(colonyStatuses), markup, tele.ModeMarkdown)
 	})
 
 	b.Handle(&btnAddTarantula, func(c tele.Context) error {
-		session := t.sessions.GetSession(c.Sender().ID)
-		session.CurrentState = StateAddingTarantula
-		session.CurrentField = FieldName
-		t.sessions.UpdateSession(c.Sender().ID, session)
-
-		return c.Send("Let's add a new tarantula! What's their name?")
+		return t.startForm(c, StateAddingTarantula, nil)
 	})
 
 	b.Handle(&btnListTarantulas, func(c tele.Context) error {
@@ -322,208 +342,57 @@ func (t *TarantulaBot) setupHandlers() {
 	})
 
 	b.Handle(&btnUpdateCount, func(c tele.Context) error {
-		session := t.sessions.GetSession(c.Sender().ID)
-		session.CurrentState = StateAddingCrickets
-		session.CurrentField = FieldColonyCount
-		t.sessions.UpdateSession(c.Sender().ID, session)
-
-		return c.Send("🦗 Enter your current cricket count:")
+		return t.startForm(c, StateAddingCrickets, nil)
 	})
 
-	b.Handle(&btnFeeding, func(c tele.Context) error {
-
-		recentFeedings, err := t.db.GetRecentFeedingRecords(t.ctx, c.Sender().ID, 10)
-		if err != nil {
-			return SendError(c, fmt.Sprintf("Failed to get feeding records: %v", err))
-		}
-
-		tarantulas, err := t.db.GetAllTarantulas(t.ctx, c.Sender().ID)
-		if err != nil {
-			return SendError(c, fmt.Sprintf("Failed to get tarantulas: %v", err))
-		}
-
-		var msg strings.Builder
-		msg.WriteString("🍽️ *Feeding Dashboard*\n\n")
-
-		if len(tarantulas) > 0 {
-			msg.WriteString("📊 *Feeding Status Overview:*\n")
-			for _, spider := range tarantulas {
-				daysSince := int(spider.DaysSinceFeeding)
-				statusEmoji, _ := GetFeedingStatusWithMolt(daysSince, int(spider.MinDays), int(spider.MaxDays), spider.CurrentStatus)
-
-				lastFed := "Never"
-				if daysSince < 999 {
-					lastFed = fmt.Sprintf("%d days ago", daysSince)
-				}
-
-				msg.WriteString(fmt.Sprintf("%s *%s* - %s\n", statusEmoji, spider.Name, lastFed))
-			}
-			msg.WriteString("\n")
-		}
+	b.Handle(&btnFeeding, t.handleFeedingDashboard)
 
-		if len(recentFeedings) == 0 {
-			msg.WriteString("📝 *Recent Activity:*\nNo feeding records found.\n")
-		} else {
-			msg.WriteString("📝 *Recent Feeding History:*\n")
-			for i, record := range recentFeedings {
-				if i >= 8 {
-					break
-				}
-
-				status := "✅"
-				if record.FeedingStatus.StatusName == "Rejected" {
-					status = "❌"
-				}
-
-				// Get the name - could be a tarantula or a colony
-				name := "Unknown"
-				if record.Tarantula != nil {
-					name = record.Tarantula.Name
-				} else if record.TarantulaColony != nil {
-					name = record.TarantulaColony.ColonyName
-				}
-
-				msg.WriteString(fmt.Sprintf("%s *%s* • %s • %s\n",
-					status,
-					name,
-					FormatDate(&record.FeedingDate),
-					FormatDaysAgo(&record.FeedingDate)))
-			}
-		}
-
-		markup := &tele.ReplyMarkup{}
-		var buttons [][]tele.InlineButton
-
-		if len(tarantulas) > 0 {
-
-			needsFeeding := 0
-			overdue := 0
-			for _, spider := range tarantulas {
-				daysSince := int(spider.DaysSinceFeeding)
-				if daysSince >= int(spider.MaxDays) {
-					overdue++
-				} else if daysSince >= int(spider.MinDays) {
-					needsFeeding++
-				}
-			}
-
-			if overdue > 0 || needsFeeding > 0 {
-				msg.WriteString("\n⚠️ *Attention Needed:*\n")
-				if overdue > 0 {
-					msg.WriteString(fmt.Sprintf("🔴 %d tarantula(s) overdue for feeding\n", overdue))
-				}
-				if needsFeeding > 0 {
-					msg.WriteString(fmt.Sprintf("🟡 %d tarantula(s) ready for feeding\n", needsFeeding))
-				}
-			}
-
-			btnQuickFeed := tele.InlineButton{
-				Text: "🚀 Quick Feed",
-				Data: "quick_actions",
-			}
-
-			btnFeedingHistory := tele.InlineButton{
-				Text: "📊 Full History",
-				Data: "feeding_history",
-			}
-
-			buttons = append(buttons, []tele.InlineButton{btnQuickFeed, btnFeedingHistory})
-		}
-
-		btnBack := tele.InlineButton{
-			Text: "⬅️ Back to Main",
-			Data: "back_to_main",
-		}
-		buttons = append(buttons, []tele.InlineButton{btnBack})
-
-		markup.InlineKeyboard = buttons
-		return c.Send(msg.String(), markup, tele.ModeMarkdown)
-	})
-
-	b.Handle(&btnFeedingHistory, func(c tele.Context) error {
-		feedings, err := t.db.GetRecentFeedingRecords(t.ctx, c.Sender().ID, 20)
-		if err != nil {
-			return SendError(c, fmt.Sprintf("Failed to get feeding records: %v", err))
-		}
-
-		var msg strings.Builder
-		msg.WriteString("📊 *Complete Feeding History*\n\n")
-
-		if len(feedings) == 0 {
-			msg.WriteString("No feeding records found.\n")
-		} else {
-
-			spiderFeedings := make(map[string][]models.FeedingEvent)
-			for _, feeding := range feedings {
-				// Get the name - could be a tarantula or a colony
-				name := "Unknown"
-				if feeding.Tarantula != nil {
-					name = feeding.Tarantula.Name
-				} else if feeding.TarantulaColony != nil {
-					name = feeding.TarantulaColony.ColonyName
-				}
-				spiderFeedings[name] = append(spiderFeedings[name], feeding)
-			}
-
-			for spiderName, records := range spiderFeedings {
-				msg.WriteString(fmt.Sprintf("🕷 *%s:*\n", spiderName))
-
-				for i, record := range records {
-					if i >= 5 {
-						msg.WriteString("   _...and more_\n")
-						break
-					}
-
-					status := "✅"
-					if record.FeedingStatus.StatusName == "Rejected" {
-						status = "❌"
-					}
-
-					msg.WriteString(fmt.Sprintf("  %s %s • %d 🦗 • %s\n",
-						status,
-						FormatDate(&record.FeedingDate),
-						record.NumberOfCrickets,
-						FormatDaysAgo(&record.FeedingDate)))
-				}
-				msg.WriteString("\n")
-			}
-		}
-
-		markup := &tele.ReplyMarkup{
-			InlineKeyboard: [][]tele.InlineButton{
-				{{Text: "⬅️ Back to Feeding", Data: "feeding_dashboard"}},
-			},
-		}
-
-		return c.Send(msg.String(), markup, tele.ModeMarkdown)
-	})
+	b.Handle(&btnFeedingHistory, t.handleFeedingHistory)
 
+	// Text and photos answer whatever the session is waiting for, so two
+	// arriving together are handled one after the other
 	b.Handle(tele.OnText, func(c tele.Context) error {
-		session := t.sessions.GetSession(c.Sender().ID)
+		defer t.sessions.Lock(sessionKey(c))()
+		session := t.sessions.GetSession(sessionKey(c))
+		if _, ok := t.forms[session.CurrentState]; ok {
+			return t.handleFormInput(c, session)
+		}
 
 		switch session.CurrentState {
-		case StateAddingTarantula:
-			return t.handleTarantulaFormInput(c, session)
 		case StateFeeding:
 			return t.handleFeedingFormInput(c, session)
-		case StateAddingMolt:
-			return t.handleMoltFormInput(c, session)
-		case StateAddingColony:
-			return t.handleColonyFormInput(c, session)
-		case StateAddingCrickets:
-			return t.handleCricketsFormInput(c, session)
 		case StateNotificationSettings:
 			return t.handleSettingsInput(c, session)
-		case StateCreatingColony:
-			return t.handleTarantulaColonyFormInput(c, session)
+		case StateAddingSpecies:
+			return t.handleSpeciesFormInput(c, session)
+		case StateEditingSpecies:
+			return t.handleEditSpeciesInput(c, session)
+		case StateRecordingHatch:
+			return t.handleHatchCountInput(c, session)
+		case StateRecordingPurchase:
+			return t.handlePurchaseFormInput(c, session)
+		case StateRecordingExpense:
+			return t.handleExpenseFormInput(c, session)
+		case StateRecordingCensus:
+			return t.handleCensusCountInput(c, session)
+		case StateSplittingColony:
+			return t.handleSplitFormInput(c, session)
+		case StateCareNote:
+			return t.handleCareNoteInput(c, session)
+		case StateRecordingWeight:
+			return t.handleWeightInput(c, session)
 
 		default:
+			if kind, args, ok := quickLogText(c.Text()); ok && !isGroupChat(c.Chat()) {
+				return t.handleQuickLog(c, kind, args)
+			}
 			return nil
 		}
 	})
 
 	b.Handle(tele.OnPhoto, func(c tele.Context) error {
-		session := t.sessions.GetSession(c.Sender().ID)
+		defer t.sessions.Lock(sessionKey(c))()
+		session := t.sessions.GetSession(sessionKey(c))
 		if session.CurrentState == StateAddingPhoto {
 			return t.handlePhotoInput(c, session)
 		}
@@ -532,6 +401,20 @@ func (t *TarantulaBot) setupHandlers() {
 
 	b.Handle(&btnViewMolts, t.handleViewMolts)
 
+	b.Handle(&btnMySpecies, t.handleMySpecies)
+	b.Handle("/species", t.handleMySpecies)
+	b.Handle("/addspecies", t.handleAddSpecies)
+	b.Handle("/pendingspecies", t.handlePendingSpecies)
+	b.Handle("/caresheet", t.handleCareSheetCommand)
+	for _, kind := range quickLogKinds {
+		b.Handle("/"+string(kind), t.handleQuickLogCommand(kind))
+	}
+	b.Handle("/round", t.handleFeedingRoundStart)
+	b.Handle(&btnCareSheets, func(c tele.Context) error {
+		return t.sendCareSheetBrowser(c, "", 0)
+	})
+	b.Handle(tele.OnQuery, t.handleSpeciesInlineQuery)
+
 	t.setupColonyMaintenanceHandlers()
 	t.setupInlineKeyboards()
 }
@@ -545,7 +428,7 @@ func sendSuccess(c tele.Context, msg string) error {
 }
 
 func (t *TarantulaBot) showTarantulaList(c tele.Context) error {
-	tarantulas, err := t.db.GetAllTarantulas(t.ctx, c.Sender().ID)
+	tarantulas, err := t.db.GetAllTarantulas(t.reqCtx(c), ownerID(c))
 	if err != nil {
 		return fmt.Errorf("failed to get tarantulas: %w", err)
 	}
@@ -556,10 +439,7 @@ func (t *TarantulaBot) showTarantulaList(c tele.Context) error {
 
 	var rows [][]tele.InlineButton
 	for _, tarantula := range tarantulas {
-		btn := tele.InlineButton{
-			Text: fmt.Sprintf("🕷 %s", tarantula.Name),
-			Data: fmt.Sprintf("select:%d", tarantula.ID),
-		}
+		btn := selectAction.Button(fmt.Sprintf("🕷 %s", tarantula.Name), int(tarantula.ID))
 		rows = append(rows, []tele.InlineButton{btn})
 	}
 
@@ -569,7 +449,7 @@ func (t *TarantulaBot) showTarantulaList(c tele.Context) error {
 }
 
 func (t *TarantulaBot) handleTarantulaSelect(c tele.Context, tarantulaID int) error {
-	tarantula, err := t.db.GetTarantulaByID(t.ctx, c.Sender().ID, int32(tarantulaID))
+	tarantula, err := t.db.GetTarantulaByID(t.reqCtx(c), ownerID(c), int32(tarantulaID))
 	if err != nil {
 		return fmt.Errorf("failed to get tarantula: %w", err)
 	}
@@ -597,20 +477,10 @@ func (t *TarantulaBot) handleTarantulaSelect(c tele.Context, tarantulaID int) er
 func makeTarantulaMarkup(tarantulaID int) *tele.ReplyMarkup {
 	markup := &tele.ReplyMarkup{}
 
-	feedBtn := tele.InlineButton{
-		Data: fmt.Sprintf("%s:%d", feedCallback, tarantulaID),
-		Text: "🍽 Feed",
-	}
-
-	moltBtn := tele.InlineButton{
-		Text: "🔄 Record Molt", Data: fmt.Sprintf("%s:%d", moltCallback, tarantulaID)}
-
-	feedSchedulerBtn := tele.InlineButton{
-		Text: "📅 Schedule Feedings", Data: fmt.Sprintf("%s:%d", feedSchedulerCallback, tarantulaID),
-	}
-
-	backBtn := tele.InlineButton{Text: "⬅️ Back to List",
-		Data: backToListCallback + ":0"}
+	feedBtn := feedAction.Button("🍽 Feed", tarantulaID)
+	moltBtn := moltAction.Button("🔄 Record Molt", tarantulaID)
+	feedSchedulerBtn := feedSchedulerAction.Button("📅 Schedule Feedings", tarantulaID)
+	backBtn := backToListAction.Button("⬅️ Back to List")
 
 	markup.InlineKeyboard = [][]tele.InlineButton{
 		{feedBtn, moltBtn, feedSchedulerBtn},
@@ -620,28 +490,25 @@ func makeTarantulaMarkup(tarantulaID int) *tele.ReplyMarkup {
 }
 
 func (t *TarantulaBot) handleTarantulaFeed(c tele.Context, tarantulaID int) error {
-	session := t.sessions.GetSession(c.Sender().ID)
+	session := t.sessions.GetSession(sessionKey(c))
+	session.reset()
 	session.CurrentState = StateFeeding
-	session.CurrentField = FieldColonyID
+	session.CurrentField = FieldPreyType
 	tid := tarantulaID
 	session.FeedEvent.TarantulaID = &tid
-	t.sessions.UpdateSession(c.Sender().ID, session)
+	t.sessions.UpdateSession(sessionKey(c), session)
 
-	return c.Send("What's colony ID?")
+	return t.promptPreyType(c)
 }
 
 func (t *TarantulaBot) handleTarantulaMolt(c tele.Context, tarantulaID int) error {
-	session := t.sessions.GetSession(c.Sender().ID)
-	session.CurrentState = StateAddingMolt
-	session.CurrentField = FieldPreMoltLengthCM
-	session.MoltData.TarantulaID = tarantulaID
-	t.sessions.UpdateSession(c.Sender().ID, session)
-
-	return c.Send("How long was your tarantula before it molted (in cm)?")
+	return t.startForm(c, StateAddingMolt, func(session *UserSession) {
+		session.MoltData.TarantulaID = tarantulaID
+	})
 }
 
 func (t *TarantulaBot) handleTarantulaInfo(c tele.Context, id int) error {
-	tarantula, err := t.db.GetTarantulaByID(t.ctx, c.Sender().ID, int32(id))
+	tarantula, err := t.db.GetTarantulaByID(t.reqCtx(c), ownerID(c), int32(id))
 	if err != nil {
 		return fmt.Errorf("failed to get tarantula: %w", err)
 	}
@@ -669,15 +536,9 @@ func sendTarantulaInfo(c tele.Context, tarantula *models.Tarantula) error {
 	// Add photo action buttons only
 	markup := &tele.ReplyMarkup{}
 
-	photoBtn := tele.InlineButton{
-		Text: "📸 Add Photo",
-		Data: fmt.Sprintf("add_photo:%d", tarantula.ID),
-	}
+	photoBtn := addPhotoAction.Button("📸 Add Photo", int32(tarantula.ID))
 
-	viewPhotosBtn := tele.InlineButton{
-		Text: "🖼️ View Photos",
-		Data: fmt.Sprintf("view_photos:%d", tarantula.ID),
-	}
+	viewPhotosBtn := viewPhotosAction.Button("🖼️ View Photos", int32(tarantula.ID))
 
 	markup.InlineKeyboard = [][]tele.InlineButton{
 		{photoBtn, viewPhotosBtn},
@@ -698,13 +559,13 @@ func getHealthStatus(statusID int) string {
 }
 
 func (t *TarantulaBot) handleNotificationSettings(c tele.Context) error {
-	settings, err := t.db.GetUserSettings(t.ctx, c.Sender().ID)
+	settings, err := t.db.GetUserSettings(t.reqCtx(c), c.Sender().ID)
 	if err != nil {
 		return fmt.Errorf("failed to get user settings: %w", err)
 	}
-	session := t.sessions.GetSession(c.Sender().ID)
+	session := t.sessions.GetSession(sessionKey(c))
 	session.CurrentState = StateNotificationSettings
-	t.sessions.UpdateSession(c.Sender().ID, session)
+	t.sessions.UpdateSession(sessionKey(c), session)
 	markup := &tele.ReplyMarkup{}
 
 	toggleText := "🔕 Disable Notifications"
@@ -712,20 +573,11 @@ func (t *TarantulaBot) handleNotificationSettings(c tele.Context) error {
 		toggleText = "🔔 Enable Notifications"
 	}
 
-	toggleBtn := tele.InlineButton{
-		Text: toggleText,
-		Data: "toggle_notifications",
-	}
+	toggleBtn := toggleNotificationsAction.Button(toggleText)
 
-	timeBtn := tele.InlineButton{
-		Text: fmt.Sprintf("⏰ Notification Time: %s UTC", settings.NotificationTimeUTC),
-		Data: "set_notification_time",
-	}
+	timeBtn := setNotificationTimeAction.Button(fmt.Sprintf("⏰ Notification Time: %s UTC", settings.NotificationTimeUTC))
 
-	reminderBtn := tele.InlineButton{
-		Text: fmt.Sprintf("📅 Feeding Reminder: %d days", settings.FeedingReminderDays),
-		Data: "set_feeding_reminder",
-	}
+	reminderBtn := setFeedingReminderAction.Button(fmt.Sprintf("📅 Feeding Reminder: %d days", settings.FeedingReminderDays))
 
 	// Molt prediction settings
 	moltPredictionToggleText := "🔕 Disable Molt Predictions"
@@ -733,20 +585,11 @@ func (t *TarantulaBot) handleNotificationSettings(c tele.Context) error {
 		moltPredictionToggleText = "🔔 Enable Molt Predictions"
 	}
 
-	moltPredictionToggleBtn := tele.InlineButton{
-		Text: moltPredictionToggleText,
-		Data: "toggle_molt_predictions",
-	}
+	moltPredictionToggleBtn := toggleMoltPredictionsAction.Button(moltPredictionToggleText)
 
-	moltPredictionDaysBtn := tele.InlineButton{
-		Text: fmt.Sprintf("🦗 Molt Alert Days: %d days before", settings.MoltPredictionDays),
-		Data: "set_molt_prediction_days",
-	}
+	moltPredictionDaysBtn := setMoltPredictionDaysAction.Button(fmt.Sprintf("🦗 Molt Alert Days: %d days before", settings.MoltPredictionDays))
 
-	postMoltMuteBtn := tele.InlineButton{
-		Text: fmt.Sprintf("🤫 Post-Molt Mute: %d days", settings.PostMoltMuteDays),
-		Data: "set_post_molt_mute_days",
-	}
+	postMoltMuteBtn := setPostMoltMuteDaysAction.Button(fmt.Sprintf("🤫 Post-Molt Mute: %d days", settings.PostMoltMuteDays))
 
 	markup.InlineKeyboard = [][]tele.InlineButton{
 		{toggleBtn},
@@ -761,7 +604,7 @@ func (t *TarantulaBot) handleNotificationSettings(c tele.Context) error {
 }
 
 func (t *TarantulaBot) handlePauseNotificationSettings(c tele.Context) error {
-	settings, err := t.db.GetUserSettings(t.ctx, c.Sender().ID)
+	settings, err := t.db.GetUserSettings(t.reqCtx(c), c.Sender().ID)
 	if err != nil {
 		return fmt.Errorf("failed to get user settings: %w", err)
 	}
@@ -771,10 +614,7 @@ func (t *TarantulaBot) handlePauseNotificationSettings(c tele.Context) error {
 	var pauseBtn, unpauseBtn, pause1Day, pause3Day, pause1Week tele.InlineButton
 
 	if settings.NotificationsPaused {
-		unpauseBtn = tele.InlineButton{
-			Text: "▶️ Resume Notifications",
-			Data: "unpause_notifications",
-		}
+		unpauseBtn = unpauseNotificationsAction.Button("▶️ Resume Notifications")
 
 		var statusText string
 		if settings.PauseEndDate != nil {
@@ -789,22 +629,10 @@ func (t *TarantulaBot) handlePauseNotificationSettings(c tele.Context) error {
 
 		return c.Send(fmt.Sprintf("🔕 Notifications Status: %s", statusText), markup)
 	} else {
-		pause1Day = tele.InlineButton{
-			Text: "⏸️ Pause 1 Day",
-			Data: "pause_1_day",
-		}
-		pause3Day = tele.InlineButton{
-			Text: "⏸️ Pause 3 Days",
-			Data: "pause_3_days",
-		}
-		pause1Week = tele.InlineButton{
-			Text: "⏸️ Pause 1 Week",
-			Data: "pause_1_week",
-		}
-		pauseBtn = tele.InlineButton{
-			Text: "⏸️ Pause Indefinitely",
-			Data: "pause_indefinitely",
-		}
+		pause1Day = pauseNotificationsAction.Button("⏸️ Pause 1 Day", 24)
+		pause3Day = pauseNotificationsAction.Button("⏸️ Pause 3 Days", 72)
+		pause1Week = pauseNotificationsAction.Button("⏸️ Pause 1 Week", 168)
+		pauseBtn = pauseNotificationsAction.Button("⏸️ Pause Indefinitely", 0)
 
 		markup.InlineKeyboard = [][]tele.InlineButton{
 			{pause1Day, pause3Day},
@@ -817,29 +645,29 @@ func (t *TarantulaBot) handlePauseNotificationSettings(c tele.Context) error {
 }
 
 func (t *TarantulaBot) handleSetNotificationTime(c tele.Context) error {
-	session := t.sessions.GetSession(c.Sender().ID)
+	session := t.sessions.GetSession(sessionKey(c))
 	session.CurrentField = "notification_time"
-	t.sessions.UpdateSession(c.Sender().ID, session)
+	t.sessions.UpdateSession(sessionKey(c), session)
 
 	return c.Send("Please enter the time you want to receive notifications (HH:MM in UTC)")
 }
 
 func (t *TarantulaBot) handleSetFeedingReminder(c tele.Context) error {
-	session := t.sessions.GetSession(c.Sender().ID)
+	session := t.sessions.GetSession(sessionKey(c))
 	session.CurrentField = "feeding_reminder"
-	t.sessions.UpdateSession(c.Sender().ID, session)
+	t.sessions.UpdateSession(sessionKey(c), session)
 
 	return c.Send("How many days before feeding would you like to be reminded?")
 }
 
 func (t *TarantulaBot) handleToggleNotifications(c tele.Context) error {
-	settings, err := t.db.GetUserSettings(t.ctx, c.Sender().ID)
+	settings, err := t.db.GetUserSettings(t.reqCtx(c), c.Sender().ID)
 	if err != nil {
 		return fmt.Errorf("failed to get user settings: %w", err)
 	}
 
 	settings.NotificationEnabled = !settings.NotificationEnabled
-	err = t.db.UpdateUserSettings(t.ctx, settings)
+	err = t.db.UpdateUserSettings(t.reqCtx(c), settings)
 	if err != nil {
 		return fmt.Errorf("failed to update user settings: %w", err)
 	}
@@ -852,13 +680,13 @@ func (t *TarantulaBot) handleToggleNotifications(c tele.Context) error {
 }
 
 func (t *TarantulaBot) handleToggleMoltPredictions(c tele.Context) error {
-	settings, err := t.db.GetUserSettings(t.ctx, c.Sender().ID)
+	settings, err := t.db.GetUserSettings(t.reqCtx(c), c.Sender().ID)
 	if err != nil {
 		return fmt.Errorf("failed to get user settings: %w", err)
 	}
 
 	settings.MoltPredictionEnabled = !settings.MoltPredictionEnabled
-	err = t.db.UpdateUserSettings(t.ctx, settings)
+	err = t.db.UpdateUserSettings(t.reqCtx(c), settings)
 	if err != nil {
 		return fmt.Errorf("failed to update user settings: %w", err)
 	}
@@ -871,23 +699,23 @@ func (t *TarantulaBot) handleToggleMoltPredictions(c tele.Context) error {
 }
 
 func (t *TarantulaBot) handleSetMoltPredictionDays(c tele.Context) error {
-	session := t.sessions.GetSession(c.Sender().ID)
+	session := t.sessions.GetSession(sessionKey(c))
 	session.CurrentField = "molt_prediction_days"
-	t.sessions.UpdateSession(c.Sender().ID, session)
+	t.sessions.UpdateSession(sessionKey(c), session)
 
 	return c.Send("How many days before a predicted molt should you be notified?")
 }
 
 func (t *TarantulaBot) handleSetPostMoltMuteDays(c tele.Context) error {
-	session := t.sessions.GetSession(c.Sender().ID)
+	session := t.sessions.GetSession(sessionKey(c))
 	session.CurrentField = "post_molt_mute_days"
-	t.sessions.UpdateSession(c.Sender().ID, session)
+	t.sessions.UpdateSession(sessionKey(c), session)
 
 	return c.Send("How many days after a molt should feeding notifications be muted?")
 }
 
 func (t *TarantulaBot) handleSettingsInput(c tele.Context, session *UserSession) error {
-	settings, err := t.db.GetUserSettings(t.ctx, c.Sender().ID)
+	settings, err := t.db.GetUserSettings(t.reqCtx(c), c.Sender().ID)
 	if err != nil {
 		return fmt.Errorf("failed to get user settings: %w", err)
 	}
@@ -921,23 +749,23 @@ func (t *TarantulaBot) handleSettingsInput(c tele.Context, session *UserSession)
 		settings.PostMoltMuteDays = days
 	}
 
-	err = t.db.UpdateUserSettings(t.ctx, settings)
+	err = t.db.UpdateUserSettings(t.reqCtx(c), settings)
 	if err != nil {
 		return fmt.Errorf("failed to update user settings: %w", err)
 	}
 
 	session.reset()
-	t.sessions.UpdateSession(c.Sender().ID, session)
+	t.sessions.UpdateSession(sessionKey(c), session)
 
 	return c.Send("✅ Settings updated successfully!")
 }
 
 func (t *TarantulaBot) handleFeedScheduler(c tele.Context, tarantulaId int) error {
-	tarantula, err := t.db.GetTarantulaByID(t.ctx, c.Sender().ID, int32(tarantulaId))
+	tarantula, err := t.db.GetTarantulaByID(t.reqCtx(c), ownerID(c), int32(tarantulaId))
 	if err != nil {
 		return fmt.Errorf("failed to get tarantula: %w", err)
 	}
-	schedule, err := t.db.GetFeedingSchedule(t.ctx, int64(tarantula.ID), float32(tarantula.CurrentSize))
+	schedule, err := t.db.GetFeedingSchedule(t.reqCtx(c), int64(tarantula.ID), float32(tarantula.CurrentSize))
 	if err != nil {
 		return fmt.Errorf("failed to get feeding schedule: %w", err)
 	}
@@ -970,21 +798,18 @@ func (t *TarantulaBot) setupColonyMaintenanceHandlers() {
 }
 
 func (t *TarantulaBot) handleColonyMaintenanceMenu(c tele.Context) error {
-	colonies, err := t.db.GetColonyStatus(t.ctx, c.Sender().ID)
+	colonies, err := t.db.GetColonyStatus(t.reqCtx(c), ownerID(c))
 	if err != nil {
 		return fmt.Errorf("failed to get colonies: %w", err)
 	}
 
 	if len(colonies) == 0 {
-		return c.Send("No cricket colonies found. Add a colony first.")
+		return c.Send("No feeder colonies found. Add a colony first.")
 	}
 
 	var rows [][]tele.InlineButton
 	for _, colony := range colonies {
-		btn := tele.InlineButton{
-			Text: fmt.Sprintf("🦗 %s (%d crickets)", colony.ColonyName, colony.CurrentCount),
-			Data: fmt.Sprintf("colony_maintain_select_%d", colony.ID),
-		}
+		btn := maintainSelectAction.Button(fmt.Sprintf("🦗 %s (%d × %s)", colony.ColonyName, colony.CurrentCount, colony.FeederName), int(colony.ID))
 		rows = append(rows, []tele.InlineButton{btn})
 	}
 
@@ -994,12 +819,12 @@ func (t *TarantulaBot) handleColonyMaintenanceMenu(c tele.Context) error {
 }
 
 func (t *TarantulaBot) handleSelectColonyForMaintenance(c tele.Context, colonyID int) error {
-	alerts, err := t.db.GetColonyMaintenanceAlerts(t.ctx, c.Sender().ID)
+	alerts, err := t.db.GetColonyMaintenanceAlerts(t.reqCtx(c), ownerID(c))
 	if err != nil {
 		return fmt.Errorf("failed to get maintenance alerts: %w", err)
 	}
 
-	colonies, err := t.db.GetColonyStatus(t.ctx, c.Sender().ID)
+	colonies, err := t.db.GetColonyStatus(t.reqCtx(c), ownerID(c))
 	if err != nil {
 		return fmt.Errorf("failed to get colony: %w", err)
 	}
@@ -1016,12 +841,12 @@ func (t *TarantulaBot) handleSelectColonyForMaintenance(c tele.Context, colonyID
 		return c.Send("Colony not found.")
 	}
 
-	maintenanceTypes, err := t.db.GetMaintenanceTypes(t.ctx)
+	maintenanceTypes, err := t.db.GetMaintenanceTypes(t.reqCtx(c))
 	if err != nil {
 		return fmt.Errorf("failed to get maintenance types: %w", err)
 	}
 
-	msg := fmt.Sprintf("🦗 *%s* (%d crickets)\n\n", colony.ColonyName, colony.CurrentCount)
+	msg := fmt.Sprintf("🦗 *%s* (%d × %s)\n\n", colony.ColonyName, colony.CurrentCount, colony.FeederName)
 
 	colonyAlerts := make(map[string]models.ColonyMaintenanceAlert)
 	for _, alert := range alerts {
@@ -1047,17 +872,11 @@ func (t *TarantulaBot) handleSelectColonyForMaintenance(c tele.Context, colonyID
 			alertIndicator = "⚠️ "
 		}
 
-		btn := tele.InlineButton{
-			Text: fmt.Sprintf("%s%s", alertIndicator, mType.TypeName),
-			Data: fmt.Sprintf("colony_maintain_record_%d_%d", colonyID, mType.ID),
-		}
+		btn := maintainRecordAction.Button(fmt.Sprintf("%s%s", alertIndicator, mType.TypeName), int32(colonyID), int32(mType.ID))
 		rows = append(rows, []tele.InlineButton{btn})
 	}
 
-	historyBtn := tele.InlineButton{
-		Text: "📜 View Maintenance History",
-		Data: fmt.Sprintf("colony_maintain_history_%d", colonyID),
-	}
+	historyBtn := maintainHistoryAction.Button("📜 View Maintenance History", colonyID)
 	rows = append(rows, []tele.InlineButton{historyBtn})
 
 	keyboard := &tele.ReplyMarkup{
@@ -1071,27 +890,27 @@ func (t *TarantulaBot) handleSelectColonyForMaintenance(c tele.Context, colonyID
 	return c.Send(msg, keyboard)
 }
 
-func (t *TarantulaBot) handleRecordColonyMaintenance(c tele.Context, colonyID, typeID int) error {
+func (t *TarantulaBot) handleRecordColonyMaintenance(c tele.Context, colonyID, typeID int32) error {
 	record := models.ColonyMaintenanceRecord{
-		ColonyID:          colonyID,
-		MaintenanceTypeID: typeID,
+		ColonyID:          int(colonyID),
+		MaintenanceTypeID: int(typeID),
 		MaintenanceDate:   time.Now(),
-		UserID:            c.Sender().ID,
+		UserID:            ownerID(c),
 	}
 
-	_, err := t.db.RecordColonyMaintenance(t.ctx, record)
+	_, err := t.db.RecordColonyMaintenance(t.reqCtx(c), record)
 	if err != nil {
 		return fmt.Errorf("failed to record maintenance: %w", err)
 	}
 
-	maintenanceTypes, err := t.db.GetMaintenanceTypes(t.ctx)
+	maintenanceTypes, err := t.db.GetMaintenanceTypes(t.reqCtx(c))
 	if err != nil {
 		return fmt.Errorf("failed to get maintenance types: %w", err)
 	}
 
 	var typeName string
 	for _, mt := range maintenanceTypes {
-		if mt.ID == typeID {
+		if mt.ID == int(typeID) {
 			typeName = mt.TypeName
 			break
 		}
@@ -1106,7 +925,7 @@ func (t *TarantulaBot) handleRecordColonyMaintenance(c tele.Context, colonyID, t
 }
 
 func (t *TarantulaBot) handleColonyMaintenanceHistory(c tele.Context, colonyID int) error {
-	history, err := t.db.GetColonyMaintenanceHistory(t.ctx, int64(colonyID), c.Sender().ID, 10)
+	history, err := t.db.GetColonyMaintenanceHistory(t.reqCtx(c), int64(colonyID), ownerID(c), 10)
 	if err != nil {
 		return fmt.Errorf("failed to get maintenance history: %w", err)
 	}
@@ -1123,10 +942,7 @@ func (t *TarantulaBot) handleColonyMaintenanceHistory(c tele.Context, colonyID i
 	}
 	var rows [][]tele.InlineButton
 
-	backBtn := tele.InlineButton{
-		Text: "⬅️ Back to Colony List",
-		Data: "colony_maintain_back",
-	}
+	backBtn := maintainBackAction.Button("⬅️ Back to Colony List")
 	rows = append(rows, []tele.InlineButton{backBtn})
 
 	if c.Callback() != nil {
@@ -1172,30 +988,30 @@ func (t *TarantulaBot) handlePhotoInput(c tele.Context, session *UserSession) er
 		PhotoData:   photoBytes,
 		PhotoType:   "general",
 		Caption:     c.Message().Caption,
-		UserID:      c.Sender().ID,
+		UserID:      ownerID(c),
 	}
 
-	_, err = t.db.AddPhoto(t.ctx, photoRecord)
+	_, err = t.db.AddPhoto(t.reqCtx(c), photoRecord)
 	if err != nil {
 		return fmt.Errorf("failed to save photo: %w", err)
 	}
 
 	// Update profile photo if it's the first one
-	tarantula, err := t.db.GetTarantulaByID(t.ctx, c.Sender().ID, int32(session.TarantulaData.ID))
+	tarantula, err := t.db.GetTarantulaByID(t.reqCtx(c), ownerID(c), int32(session.TarantulaData.ID))
 	if err == nil && tarantula.ProfilePhotoURL == "" {
-		_ = t.db.UpdateTarantulaProfilePhoto(t.ctx, int32(session.TarantulaData.ID), photoURL, c.Sender().ID)
+		_ = t.db.UpdateTarantulaProfilePhoto(t.reqCtx(c), int32(session.TarantulaData.ID), photoURL, ownerID(c))
 	}
 
 	session.reset()
-	t.sessions.UpdateSession(c.Sender().ID, session)
+	t.sessions.UpdateSession(sessionKey(c), session)
 
 	return c.Send("📸 Photo added successfully!")
 }
 
 func (t *TarantulaBot) handleViewMolts(c tele.Context) error {
-	moltRecords, err := t.db.GetRecentMoltRecords(t.ctx, c.Sender().ID, 20)
+	moltRecords, err := t.db.GetRecentMoltRecords(t.reqCtx(c), ownerID(c), 20)
 	if err != nil {
-		return SendError(c, fmt.Sprintf("Failed to get molt records: %v", err))
+		return fmt.Errorf("failed to get molt records: %w", err)
 	}
 
 	if len(moltRecords) == 0 {
@@ -1223,6 +1039,9 @@ func (t *TarantulaBot) handleViewMolts(c tele.Context) error {
 		if record.Notes != "" {
 			msg += fmt.Sprintf("📝 %s\n", record.Notes)
 		}
+		if recorder := FormatRecorder(record.Recorder, record.UserID); recorder != "" {
+			msg += "👤" + recorder + "\n"
+		}
 
 		msg += "\n"
 	}
@@ -1231,12 +1050,12 @@ func (t *TarantulaBot) handleViewMolts(c tele.Context) error {
 }
 
 func (t *TarantulaBot) handleQuickActions(c tele.Context) error {
-	tarantulas, err := t.db.GetAllTarantulas(context.Background(), c.Sender().ID)
+	tarantulas, err := t.db.GetAllTarantulas(t.reqCtx(c), ownerID(c))
 	if err != nil {
 		return fmt.Errorf("failed to get tarantulas: %w", err)
 	}
 
-	colonies, err := t.db.GetUserColonies(context.Background(), c.Sender().ID)
+	colonies, err := t.db.GetColonyFeedingPlans(t.reqCtx(c), ownerID(c))
 	if err != nil {
 		return fmt.Errorf("failed to get colonies: %w", err)
 	}
@@ -1246,7 +1065,7 @@ func (t *TarantulaBot) handleQuickActions(c tele.Context) error {
 	}
 
 	markup := &tele.ReplyMarkup{}
-	var buttons [][]tele.InlineButton
+	buttons := [][]tele.InlineButton{{roundStartAction.Button("🪱 Start a feeding round")}}
 
 	// Add individual tarantulas
 	for _, spider := range tarantulas {
@@ -1258,38 +1077,33 @@ func (t *TarantulaBot) handleQuickActions(c tele.Context) error {
 			statusEmoji = "🟡"
 		}
 
-		button := tele.InlineButton{
-			Text: fmt.Sprintf("%s %s (%dd)", statusEmoji, spider.Name, daysSince),
-			Data: fmt.Sprintf("quick_feed:%d", spider.ID),
-		}
+		button := quickFeedAction.Button(fmt.Sprintf("%s %s (%dd)", statusEmoji, spider.Name, daysSince), spider.ID)
 		buttons = append(buttons, []tele.InlineButton{button})
 	}
 
-	// Add colonies
+	// Add colonies, sized to the members that are eating
 	for _, colony := range colonies {
-		activeMembers := 0
-		for _, member := range colony.Members {
-			if member.IsActive {
-				activeMembers++
-			}
+		if colony.FeedingMembers == 0 {
+			continue
 		}
-
-		button := tele.InlineButton{
-			Text: fmt.Sprintf("👥 %s (%d members)", colony.ColonyName, activeMembers),
-			Data: fmt.Sprintf("quick_feed_colony:%d", colony.ID),
+		statusEmoji := "🟢"
+		if colony.IsDue() {
+			statusEmoji = "🟡"
 		}
+
+		button := quickFeedColonyAction.Button(fmt.Sprintf("%s 👥 %s (%d due, %s)", statusEmoji, colony.ColonyName, len(colony.DueMembers), FormatColonyPrey(colony)), int32(colony.ColonyID))
 		buttons = append(buttons, []tele.InlineButton{button})
 	}
 
 	markup.InlineKeyboard = buttons
-	return c.Send("🚀 Quick Feed - Tap to feed with 1 cricket:", markup)
+	return c.Send("🚀 Quick Feed - Tap to feed 1 of the usual prey, or a colony its suggested amount:", markup)
 }
 
 // Temporary debug function to troubleshoot feeding status
 func (t *TarantulaBot) handleDebugStatus(c tele.Context) error {
-	userID := GetUserID(c)
+	userID := ownerID(c)
 
-	tarantulas, err := t.db.GetAllTarantulas(t.ctx, userID)
+	tarantulas, err := t.db.GetAllTarantulas(t.reqCtx(c), userID)
 	if err != nil {
 		return SendError(c, "Failed to load tarantula data")
 	}
@@ -1313,10 +1127,10 @@ func (t *TarantulaBot) handleDebugStatus(c tele.Context) error {
 
 // Temporary debug function to troubleshoot molt predictions
 func (t *TarantulaBot) handleDebugMolts(c tele.Context) error {
-	userID := GetUserID(c)
+	userID := ownerID(c)
 
 	// Get recent molt records
-	molts, err := t.db.GetRecentMoltRecords(t.ctx, userID, 20)
+	molts, err := t.db.GetRecentMoltRecords(t.reqCtx(c), userID, 20)
 	if err != nil {
 		return SendError(c, "Failed to get molt records")
 	}
@@ -1352,18 +1166,13 @@ func (t *TarantulaBot) sendOrEdit(c tele.Context, text string, options ...interf
 // ========== Tarantula Colony Management Handlers ==========
 
 func (t *TarantulaBot) handleCreateColony(c tele.Context) error {
-	session := t.sessions.GetSession(c.Sender().ID)
-	session.CurrentState = StateCreatingColony
-	session.CurrentField = FieldColonyName
-	t.sessions.UpdateSession(c.Sender().ID, session)
-
-	return c.Send("👥 Let's create a tarantula colony!\n\nWhat would you like to name this colony?\n(e.g., 'Balfouri Group', 'Main Colony')")
+	return t.startForm(c, StateCreatingColony, nil)
 }
 
 func (t *TarantulaBot) handleListColonies(c tele.Context) error {
-	colonies, err := t.db.GetUserColonies(t.ctx, c.Sender().ID)
+	colonies, err := t.db.GetUserColonies(t.reqCtx(c), ownerID(c))
 	if err != nil {
-		return SendError(c, fmt.Sprintf("Failed to get colonies: %v", err))
+		return fmt.Errorf("failed to get colonies: %w", err)
 	}
 
 	if len(colonies) == 0 {
@@ -1392,10 +1201,7 @@ func (t *TarantulaBot) handleListColonies(c tele.Context) error {
 		}
 		msg += "\n"
 
-		btn := tele.InlineButton{
-			Text: fmt.Sprintf("📋 %s (%d)", colony.ColonyName, activeMembers),
-			Data: fmt.Sprintf("colony_details:%d", colony.ID),
-		}
+		btn := colonyDetailsAction.Button(fmt.Sprintf("📋 %s (%d)", colony.ColonyName, activeMembers), int32(colony.ID))
 		buttons = append(buttons, []tele.InlineButton{btn})
 	}
 
@@ -1405,19 +1211,19 @@ func (t *TarantulaBot) handleListColonies(c tele.Context) error {
 
 func (t *TarantulaBot) handleAddToColony(c tele.Context) error {
 	// First check if user has any colonies
-	colonies, err := t.db.GetUserColonies(t.ctx, c.Sender().ID)
+	colonies, err := t.db.GetUserColonies(t.reqCtx(c), ownerID(c))
 	if err != nil {
-		return SendError(c, fmt.Sprintf("Failed to get colonies: %v", err))
+		return fmt.Errorf("failed to get colonies: %w", err)
 	}
 
 	if len(colonies) == 0 {
 		return SendInfo(c, "❌ You need to create a colony first!\n\nUse 'Create Colony' to start.")
 	}
 
-	session := t.sessions.GetSession(c.Sender().ID)
+	session := t.sessions.GetSession(sessionKey(c))
 	session.CurrentState = StateAddingToColony
 	session.CurrentField = FieldColonySelection
-	t.sessions.UpdateSession(c.Sender().ID, session)
+	t.sessions.UpdateSession(sessionKey(c), session)
 
 	// Show colony selection
 	msg := "👤 Add a tarantula to a colony\n\nSelect the colony:"
@@ -1426,10 +1232,7 @@ func (t *TarantulaBot) handleAddToColony(c tele.Context) error {
 	var buttons [][]tele.InlineButton
 
 	for _, colony := range colonies {
-		btn := tele.InlineButton{
-			Text: fmt.Sprintf("%s - %s", colony.ColonyName, colony.Species.CommonName),
-			Data: fmt.Sprintf("select_colony_for_add:%d", colony.ID),
-		}
+		btn := selectColonyForAddAction.Button(fmt.Sprintf("%s - %s", colony.ColonyName, colony.Species.CommonName), int32(colony.ID))
 		buttons = append(buttons, []tele.InlineButton{btn})
 	}
 
@@ -1437,25 +1240,10 @@ func (t *TarantulaBot) handleAddToColony(c tele.Context) error {
 	return c.Send(msg, markup)
 }
 
-func (t *TarantulaBot) handleColonySpeciesSelected(c tele.Context, speciesID int) error {
-	session := t.sessions.GetSession(c.Sender().ID)
-
-	// Verify we're in colony creation mode
-	if session.CurrentState != StateCreatingColony {
-		return SendError(c, "Invalid session state. Please start over by selecting 'Create Colony'.")
-	}
-
-	session.TarantulaColony.SpeciesID = speciesID
-	session.CurrentField = FieldFormationDate
-	t.sessions.UpdateSession(c.Sender().ID, session)
-
-	return c.Send("When was this colony formed? (YYYY-MM-DD)\n(Or enter today's date if forming now)")
-}
-
 func (t *TarantulaBot) handleColonyDetails(c tele.Context, colonyID int32) error {
-	colony, err := t.db.GetColony(t.ctx, colonyID, c.Sender().ID)
+	colony, err := t.db.GetColony(t.reqCtx(c), colonyID, ownerID(c))
 	if err != nil {
-		return SendError(c, fmt.Sprintf("Failed to get colony details: %v", err))
+		return fmt.Errorf("failed to get colony details: %w", err)
 	}
 
 	activeMembers := 0
@@ -1463,9 +1251,10 @@ func (t *TarantulaBot) handleColonyDetails(c tele.Context, colonyID int32) error
 	for _, member := range colony.Members {
 		if member.IsActive {
 			activeMembers++
-			membersList.WriteString(fmt.Sprintf("  • %s (joined %s)\n",
+			membersList.WriteString(fmt.Sprintf("  • %s (joined %s, seen %s)\n",
 				member.Tarantula.Name,
-				member.JoinedDate.Format("Jan 2, 2006")))
+				member.JoinedDate.Format("Jan 2, 2006"),
+				formatLastSeen(member)))
 		}
 	}
 
@@ -1480,42 +1269,58 @@ func (t *TarantulaBot) handleColonyDetails(c tele.Context, colonyID int32) error
 		msg += "  No members yet\n"
 	}
 
+	if censuses, err := t.db.GetColonyCensuses(t.reqCtx(c), colonyID, ownerID(c), 1); err == nil && len(censuses) > 0 {
+		msg += fmt.Sprintf("\nLast census: %d of %d visible (%s)\n",
+			censuses[0].VisibleCount, censuses[0].ExpectedCount, censuses[0].CensusDate.Format("Jan 2, 2006"))
+	}
+
+	if events, err := t.db.GetColonyMemberEvents(t.reqCtx(c), colonyID, ownerID(c), 3); err == nil && len(events) > 0 {
+		msg += "\n*Recent events:*\n"
+		for _, event := range events {
+			msg += fmt.Sprintf("  %s %s %s (%s)\n", memberEventEmoji[event.EventType], event.Tarantula.Name,
+				strings.ToLower(event.EventType), event.EventDate.Format("Jan 2"))
+		}
+	}
+
 	if colony.Notes != "" {
 		msg += fmt.Sprintf("\nNotes: %s\n", colony.Notes)
 	}
 
 	markup := &tele.ReplyMarkup{}
-	btnAddMember := tele.InlineButton{
-		Text: "➕ Add Member",
-		Data: fmt.Sprintf("select_colony_for_add:%d", colony.ID),
-	}
-	btnFeedColony := tele.InlineButton{
-		Text: "🍽️ Feed Colony",
-		Data: fmt.Sprintf("feed_colony:%d", colony.ID),
-	}
+	btnAddMember := colonyBulkAddAction.Button("➕ Add Members", int32(colony.ID))
+	btnRemoveMembers := colonyBulkRemoveAction.Button("➖ Remove Members", int32(colony.ID))
+	btnFeedColony := feedColonyAction.Button("🍽️ Feed Colony", int32(colony.ID))
 	markup.InlineKeyboard = [][]tele.InlineButton{
 		{btnFeedColony},
-		{btnAddMember},
+		{
+			colonyCensusAction.Button("🔢 Census", int32(colony.ID)),
+			colonyEventAction.Button("📝 Member Event", int32(colony.ID)),
+		},
+		{btnAddMember, btnRemoveMembers},
+		{
+			colonySplitAction.Button("✂️ Split", int32(colony.ID)),
+			colonyMergeAction.Button("🔗 Merge", int32(colony.ID)),
+		},
 	}
 
 	return c.Send(msg, markup, tele.ModeMarkdown)
 }
 
 func (t *TarantulaBot) handleColonySelectedForAdd(c tele.Context, colonyID int32) error {
-	session := t.sessions.GetSession(c.Sender().ID)
+	session := t.sessions.GetSession(sessionKey(c))
 	session.SelectedColonyID = int(colonyID)
-	t.sessions.UpdateSession(c.Sender().ID, session)
+	t.sessions.UpdateSession(sessionKey(c), session)
 
 	// Get colony to show species
-	colony, err := t.db.GetColony(t.ctx, colonyID, c.Sender().ID)
+	colony, err := t.db.GetColony(t.reqCtx(c), colonyID, ownerID(c))
 	if err != nil {
-		return SendError(c, fmt.Sprintf("Failed to get colony: %v", err))
+		return fmt.Errorf("failed to get colony: %w", err)
 	}
 
 	// Get user's tarantulas of the same species
-	allTarantulas, err := t.db.GetAllTarantulas(t.ctx, c.Sender().ID)
+	allTarantulas, err := t.db.GetAllTarantulas(t.reqCtx(c), ownerID(c))
 	if err != nil {
-		return SendError(c, fmt.Sprintf("Failed to get tarantulas: %v", err))
+		return fmt.Errorf("failed to get tarantulas: %w", err)
 	}
 
 	var availableTarantulas []models.TarantulaListItem
@@ -1536,10 +1341,7 @@ func (t *TarantulaBot) handleColonySelectedForAdd(c tele.Context, colonyID int32
 	var buttons [][]tele.InlineButton
 
 	for _, tarantula := range availableTarantulas {
-		btn := tele.InlineButton{
-			Text: tarantula.Name,
-			Data: fmt.Sprintf("add_tarantula_to_colony:%d", tarantula.ID),
-		}
+		btn := addTarantulaToColonyAction.Button(tarantula.Name, tarantula.ID)
 		buttons = append(buttons, []tele.InlineButton{btn})
 	}
 
@@ -1548,7 +1350,7 @@ func (t *TarantulaBot) handleColonySelectedForAdd(c tele.Context, colonyID int32
 }
 
 func (t *TarantulaBot) handleTarantulaSelectedForColony(c tele.Context, tarantulaID int32) error {
-	session := t.sessions.GetSession(c.Sender().ID)
+	session := t.sessions.GetSession(sessionKey(c))
 	colonyID := session.SelectedColonyID
 
 	if colonyID == 0 {
@@ -1561,39 +1363,42 @@ func (t *TarantulaBot) handleTarantulaSelectedForColony(c tele.Context, tarantul
 		TarantulaID: int(tarantulaID),
 		JoinedDate:  time.Now(),
 		IsActive:    true,
-		UserID:      c.Sender().ID,
+		UserID:      ownerID(c),
 	}
 
-	err := t.db.AddMemberToColony(t.ctx, member)
+	err := t.db.AddMemberToColony(t.reqCtx(c), member)
 	if err != nil {
-		return SendError(c, fmt.Sprintf("Failed to add member: %v", err))
+		return fmt.Errorf("failed to add member: %w", err)
 	}
 
 	session.reset()
-	t.sessions.UpdateSession(c.Sender().ID, session)
+	t.sessions.UpdateSession(sessionKey(c), session)
 
 	return sendSuccess(c, "Tarantula added to colony successfully!")
 }
 
 func (t *TarantulaBot) handleTarantulaSpeciesSelected(c tele.Context, speciesID int) error {
-	session := t.sessions.GetSession(c.Sender().ID)
+	session := t.sessions.GetSession(sessionKey(c))
 
 	if session.CurrentState != StateAddingTarantula || session.CurrentField != FieldSpecies {
 		return SendError(c, "Invalid session state. Please start over.")
 	}
 
-	session.TarantulaData.SpeciesID = speciesID
-	session.CurrentField = FieldAcquisitionDate
-	t.sessions.UpdateSession(c.Sender().ID, session)
-
-	return c.Send("When did you acquire this tarantula? (YYYY-MM-DD)")
+	species, err := t.db.GetSpeciesByID(t.reqCtx(c), int32(speciesID))
+	if err != nil {
+		return fmt.Errorf("failed to get species: %w", err)
+	}
+	if !speciesVisibleTo(species, ownerID(c)) {
+		return errStaleCallback
+	}
+	return t.answerForm(c, session, formAnswer{value: speciesID, display: speciesLabel(*species)})
 }
 
 func (t *TarantulaBot) handleFeedColony(c tele.Context, colonyID int32) error {
 	// Get colony details
-	colony, err := t.db.GetColony(t.ctx, colonyID, c.Sender().ID)
+	colony, err := t.db.GetColony(t.reqCtx(c), colonyID, ownerID(c))
 	if err != nil {
-		return SendError(c, fmt.Sprintf("Failed to get colony: %v", err))
+		return fmt.Errorf("failed to get colony: %w", err)
 	}
 
 	// Count active members
@@ -1608,13 +1413,181 @@ func (t *TarantulaBot) handleFeedColony(c tele.Context, colonyID int32) error {
 		return SendInfo(c, "This colony has no members yet. Add some tarantulas first!")
 	}
 
-	// Prompt for number of crickets
-	session := t.sessions.GetSession(c.Sender().ID)
+	session := t.sessions.GetSession(sessionKey(c))
+	session.reset()
 	session.CurrentState = StateFeeding
 	session.SelectedColonyID = int(colonyID)
-	session.CurrentField = FieldFeedingCount
-	t.sessions.UpdateSession(c.Sender().ID, session)
+	session.CurrentField = FieldPreyType
+	t.sessions.UpdateSession(sessionKey(c), session)
+
+	msg := fmt.Sprintf("🍽️ Feeding colony: %s (%d members)", colony.ColonyName, activeMembers)
+	if plan, err := t.db.GetColonyFeedingPlan(t.reqCtx(c), colonyID, ownerID(c)); err == nil {
+		msg += "\n💡 Suggested: " + FormatColonyPrey(*plan)
+		if fasting := plan.ActiveMembers - plan.FeedingMembers; fasting > 0 {
+			msg += fmt.Sprintf(" (%d in molt, skipped)", fasting)
+		}
+	}
+	if err := c.Send(msg); err != nil {
+		return err
+	}
+	return t.promptPreyType(c)
+}
+
+func (t *TarantulaBot) handleFeedingDashboard(c tele.Context) error {
+	recentFeedings, err := t.db.GetRecentFeedingRecords(t.reqCtx(c), ownerID(c), 10)
+	if err != nil {
+		return fmt.Errorf("failed to get feeding records: %w", err)
+	}
+
+	tarantulas, err := t.db.GetAllTarantulas(t.reqCtx(c), ownerID(c))
+	if err != nil {
+		return fmt.Errorf("failed to get tarantulas: %w", err)
+	}
+
+	var msg strings.Builder
+	msg.WriteString("🍽️ *Feeding Dashboard*\n\n")
+
+	if len(tarantulas) > 0 {
+		msg.WriteString("📊 *Feeding Status Overview:*\n")
+		for _, spider := range tarantulas {
+			daysSince := int(spider.DaysSinceFeeding)
+			statusEmoji, _ := GetFeedingStatusWithMolt(daysSince, int(spider.MinDays), int(spider.MaxDays), spider.CurrentStatus)
+
+			lastFed := "Never"
+			if daysSince < 999 {
+				lastFed = fmt.Sprintf("%d days ago", daysSince)
+			}
+
+			msg.WriteString(fmt.Sprintf("%s *%s* - %s\n", statusEmoji, spider.Name, lastFed))
+		}
+		msg.WriteString("\n")
+	}
+
+	if len(recentFeedings) == 0 {
+		msg.WriteString("📝 *Recent Activity:*\nNo feeding records found.\n")
+	} else {
+		msg.WriteString("📝 *Recent Feeding History:*\n")
+		for i, record := range recentFeedings {
+			if i >= 8 {
+				break
+			}
+
+			status := "✅"
+			if record.FeedingStatus.StatusName == "Rejected" {
+				status = "❌"
+			}
+
+			// Get the name - could be a tarantula or a colony
+			name := "Unknown"
+			if record.Tarantula != nil {
+				name = record.Tarantula.Name
+			} else if record.TarantulaColony != nil {
+				name = record.TarantulaColony.ColonyName
+			}
+
+			msg.WriteString(fmt.Sprintf("%s *%s* • %s • %s\n",
+				status,
+				name,
+				FormatDate(&record.FeedingDate),
+				FormatDaysAgo(&record.FeedingDate)))
+		}
+	}
+
+	markup := &tele.ReplyMarkup{}
+	var buttons [][]tele.InlineButton
+
+	if len(tarantulas) > 0 {
+
+		needsFeeding := 0
+		overdue := 0
+		for _, spider := range tarantulas {
+			daysSince := int(spider.DaysSinceFeeding)
+			if daysSince >= int(spider.MaxDays) {
+				overdue++
+			} else if daysSince >= int(spider.MinDays) {
+				needsFeeding++
+			}
+		}
+
+		if overdue > 0 || needsFeeding > 0 {
+			msg.WriteString("\n⚠️ *Attention Needed:*\n")
+			if overdue > 0 {
+				msg.WriteString(fmt.Sprintf("🔴 %d tarantula(s) overdue for feeding\n", overdue))
+			}
+			if needsFeeding > 0 {
+				msg.WriteString(fmt.Sprintf("🟡 %d tarantula(s) ready for feeding\n", needsFeeding))
+			}
+		}
+
+		btnQuickFeed := quickActionsAction.Button("🚀 Quick Feed")
+
+		btnFeedingHistory := feedingHistoryAction.Button("📊 Full History")
+
+		buttons = append(buttons, []tele.InlineButton{btnQuickFeed, btnFeedingHistory})
+	}
+
+	btnBack := backToMainAction.Button("⬅️ Back to Main")
+	buttons = append(buttons, []tele.InlineButton{btnBack})
+
+	markup.InlineKeyboard = buttons
+	return c.Send(msg.String(), markup, tele.ModeMarkdown)
+}
+
+func (t *TarantulaBot) handleFeedingHistory(c tele.Context) error {
+	feedings, err := t.db.GetRecentFeedingRecords(t.reqCtx(c), ownerID(c), 20)
+	if err != nil {
+		return fmt.Errorf("failed to get feeding records: %w", err)
+	}
+
+	var msg strings.Builder
+	msg.WriteString("📊 *Complete Feeding History*\n\n")
+
+	if len(feedings) == 0 {
+		msg.WriteString("No feeding records found.\n")
+	} else {
+
+		spiderFeedings := make(map[string][]models.FeedingEvent)
+		for _, feeding := range feedings {
+			// Get the name - could be a tarantula or a colony
+			name := "Unknown"
+			if feeding.Tarantula != nil {
+				name = feeding.Tarantula.Name
+			} else if feeding.TarantulaColony != nil {
+				name = feeding.TarantulaColony.ColonyName
+			}
+			spiderFeedings[name] = append(spiderFeedings[name], feeding)
+		}
+
+		for spiderName, records := range spiderFeedings {
+			msg.WriteString(fmt.Sprintf("🕷 *%s:*\n", spiderName))
+
+			for i, record := range records {
+				if i >= 5 {
+					msg.WriteString("   _...and more_\n")
+					break
+				}
+
+				status := "✅"
+				if record.FeedingStatus.StatusName == "Rejected" {
+					status = "❌"
+				}
+
+				msg.WriteString(fmt.Sprintf("  %s %s • %s • %s%s\n",
+					status,
+					FormatDate(&record.FeedingDate),
+					FormatPrey(record),
+					FormatDaysAgo(&record.FeedingDate),
+					FormatRecorder(record.Recorder, record.UserID)))
+			}
+			msg.WriteString("\n")
+		}
+	}
+
+	markup := &tele.ReplyMarkup{
+		InlineKeyboard: [][]tele.InlineButton{
+			{feedingDashboardAction.Button("⬅️ Back to Feeding")},
+		},
+	}
 
-	return c.Send(fmt.Sprintf("🍽️ Feeding colony: %s (%d members)\n\nHow many crickets are you offering?",
-		colony.ColonyName, activeMembers))
+	return c.Send(msg.String(), markup, tele.ModeMarkdown)
 }
@@ -0,0 +1,116 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// Run serves updates and sends notifications until ctx is cancelled, then
+// shuts down, giving in-flight work up to shutdownTimeout to finish
+func (t *TarantulaBot) Run(ctx context.Context, shutdownTimeout time.Duration) error {
+	t.notifications.Start()
+	go t.bot.Start()
+
+	<-ctx.Done()
+	slog.Info("Shutting down", "timeout", shutdownTimeout)
+
+	stopCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownTimeout)
+	defer cancel()
+	return t.Shutdown(stopCtx)
+}
+
+// Shutdown stops taking updates, then waits for the notification round and
+// the handlers in progress until ctx expires. The bot can't be restarted.
+func (t *TarantulaBot) Shutdown(ctx context.Context) error {
+	defer t.cancelFunc()
+
+	stopped := make(chan struct{})
+	go func() {
+		t.bot.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		return fmt.Errorf("failed to stop receiving updates: %w", ctx.Err())
+	}
+
+	var errs []error
+	if err := t.notifications.Stop(ctx); err != nil {
+		errs = append(errs, err)
+	}
+
+	select {
+	case <-t.handlers.drain():
+	case <-ctx.Done():
+		errs = append(errs, fmt.Errorf("%d handlers still running: %w", t.handlers.count(), ctx.Err()))
+	}
+
+	return errors.Join(errs...)
+}
+
+// trackHandlers lets Shutdown wait for handlers, which telebot runs in their
+// own goroutines
+func (t *TarantulaBot) trackHandlers(next tele.HandlerFunc) tele.HandlerFunc {
+	return func(c tele.Context) error {
+		if !t.handlers.enter() {
+			slog.Warn("Dropped update received while shutting down", "update_id", c.Update().ID)
+			return nil
+		}
+		defer t.handlers.leave()
+		return next(c)
+	}
+}
+
+// handlerTracker counts running handlers and refuses new ones once draining
+type handlerTracker struct {
+	mu       sync.Mutex
+	running  int
+	draining bool
+	idle     chan struct{}
+}
+
+func (h *handlerTracker) enter() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.draining {
+		return false
+	}
+	h.running++
+	return true
+}
+
+func (h *handlerTracker) leave() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.running--
+	if h.draining && h.running == 0 {
+		close(h.idle)
+	}
+}
+
+// drain refuses new handlers and returns a channel closed once none are running
+func (h *handlerTracker) drain() <-chan struct{} {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.draining {
+		h.draining = true
+		h.idle = make(chan struct{})
+		if h.running == 0 {
+			close(h.idle)
+		}
+	}
+	return h.idle
+}
+
+func (h *handlerTracker) count() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.running
+}
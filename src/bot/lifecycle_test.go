@@ -0,0 +1,32 @@
+package bot
+
+import "testing"
+
+func TestHandlerTracker(t *testing.T) {
+	var h handlerTracker
+
+	if !h.enter() || !h.enter() {
+		t.Fatal("handlers refused before draining")
+	}
+	idle := h.drain()
+	if h.enter() {
+		t.Error("handler accepted while draining")
+	}
+
+	h.leave()
+	select {
+	case <-idle:
+		t.Fatal("idle before the last handler finished")
+	default:
+	}
+
+	h.leave()
+	select {
+	case <-idle:
+	default:
+		t.Fatal("not idle after all handlers finished")
+	}
+	if h.drain() != idle {
+		t.Error("draining twice returned a different channel")
+	}
+}
@@ -0,0 +1,56 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"tarantulago/metrics"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// measureUpdates counts handled updates and times their handlers
+func measureUpdates(next tele.HandlerFunc) tele.HandlerFunc {
+	return func(c tele.Context) error {
+		start := time.Now()
+		err := next(c)
+		metrics.ObserveUpdate(updateKind(c), time.Since(start), err)
+		return err
+	}
+}
+
+// updateKind is a low-cardinality label for the update being handled
+func updateKind(c tele.Context) string {
+	switch {
+	case c.Callback() != nil:
+		return "callback"
+	case c.Message() == nil:
+		return "other"
+	case c.Message().Photo != nil:
+		return "photo"
+	case command(c) != "":
+		return "command"
+	case c.Message().Text != "":
+		return "text"
+	}
+	return "other"
+}
+
+// Ping checks the Telegram Bot API can be reached with our token
+func (t *TarantulaBot) Ping(ctx context.Context) error {
+	done := make(chan error, 1)
+	go func() {
+		_, err := t.bot.Raw("getMe", nil)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("failed to reach Telegram: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to reach Telegram: %w", ctx.Err())
+	}
+}
@@ -4,6 +4,10 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
+	"sync"
+	"tarantulago/logging"
+	"tarantulago/metrics"
 	"tarantulago/models"
 	"time"
 
@@ -11,19 +15,33 @@ import (
 )
 
 type NotificationSystem struct {
-	bot    *tele.Bot
-	db     NotificationOperations
-	ctx    context.Context
-	cancel context.CancelFunc
+	bot      *tele.Bot
+	db       NotificationOperations
+	ctx      context.Context
+	cancel   context.CancelFunc
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+
+	// tick is how often due notifications are checked
+	tick            time.Duration
+	moltPredictions bool
 }
 
-func NewNotificationSystem(bot *tele.Bot, db NotificationOperations) *NotificationSystem {
-	ctx, cancel := context.WithCancel(context.Background())
+// NewNotificationSystem takes the process context for its values only: the
+// scheduler ends through Stop so a round of notifications isn't cut short
+func NewNotificationSystem(ctx context.Context, bot *tele.Bot, db NotificationOperations, tick time.Duration, moltPredictions bool) *NotificationSystem {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
 	return &NotificationSystem{
 		bot:    bot,
 		db:     db,
 		ctx:    ctx,
 		cancel: cancel,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+
+		tick:            tick,
+		moltPredictions: moltPredictions,
 	}
 }
 
@@ -31,8 +49,18 @@ func (n *NotificationSystem) Start() {
 	go n.runNotificationScheduler()
 }
 
-func (n *NotificationSystem) Stop() {
-	n.cancel()
+// Stop ends the scheduler once the round in progress has been sent, giving up
+// on it when ctx expires. Start must have been called.
+func (n *NotificationSystem) Stop(ctx context.Context) error {
+	n.stopOnce.Do(func() { close(n.stop) })
+	defer n.cancel()
+
+	select {
+	case <-n.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("notifications still sending: %w", ctx.Err())
+	}
 }
 
 func (n *NotificationSystem) shouldSendNotification(settings *models.UserSettings) bool {
@@ -57,56 +85,110 @@ func (n *NotificationSystem) shouldSendNotification(settings *models.UserSetting
 	}
 
 	now := time.Now().UTC()
-	currentTime := time.Date(0, 1, 1, now.Hour(), now.Minute(), 0, 0, time.UTC)
+	currentTime := time.Date(0, 1, 1, now.Hour(), now.Minute(), now.Second(), 0, time.UTC)
 
+	// Each notification time falls in exactly one tick's window, including
+	// windows that span midnight
 	diff := currentTime.Sub(notificationTime)
-	return diff >= 0 && diff < time.Minute
+	if diff < 0 {
+		diff += 24 * time.Hour
+	}
+	return diff < n.tick
 }
 
 func (n *NotificationSystem) runNotificationScheduler() {
-	ticker := time.NewTicker(1 * time.Minute)
+	defer close(n.done)
+	ticker := time.NewTicker(n.tick)
 	defer ticker.Stop()
 
 	for {
 		select {
-		case <-n.ctx.Done():
+		case <-n.stop:
 			return
-		case <-ticker.C:
-			n.processScheduledNotifications()
+		case tick := <-ticker.C:
+			ctx := logging.WithCorrelationID(n.ctx, fmt.Sprintf("notify-%d", tick.Unix()))
+			n.processScheduledNotifications(ctx)
+			n.sendSitterDigests(ctx)
 		}
 	}
 }
 
-func (n *NotificationSystem) processScheduledNotifications() {
-	users, err := n.db.GetActiveUsers(n.ctx)
+func (n *NotificationSystem) processScheduledNotifications(ctx context.Context) {
+	users, err := n.db.GetActiveUsers(ctx)
 	if err != nil {
-		slog.Error("Failed to get active users", "error", err)
+		slog.ErrorContext(ctx, "Failed to get active users", "error", err)
 		return
 	}
 
 	for _, user := range users {
-		settings, err := n.db.GetUserSettings(n.ctx, user.TelegramID)
+		settings, err := n.db.GetUserSettings(ctx, user.TelegramID)
 		if err != nil {
-			slog.Error("Failed to get user settings", "user_id", user.TelegramID, "error", err)
+			slog.ErrorContext(ctx, "Failed to get user settings", "user_id", user.TelegramID, "error", err)
 			continue
 		}
 
 		if n.shouldSendNotification(settings) {
-			n.triggerChecks(user, settings)
+			n.triggerChecks(ctx, user, settings)
 		}
 	}
 }
 
-func (n *NotificationSystem) triggerChecks(user models.TelegramUser, settings *models.UserSettings) {
-	n.checkFeedings(user.TelegramID, user.ChatID, settings)
-	n.checkMoltPredictions(user.TelegramID, user.ChatID, settings)
-	//n.checkColonyMaintenance(user.TelegramID, user.ChatID, settings)
+func (n *NotificationSystem) triggerChecks(ctx context.Context, user models.TelegramUser, settings *models.UserSettings) {
+	chatIDs := n.dutyChats(ctx, user)
+	n.checkFeedings(ctx, user.TelegramID, chatIDs, settings)
+	if n.moltPredictions {
+		n.checkMoltPredictions(ctx, user.TelegramID, chatIDs, settings)
+	}
+	n.checkColonyCensus(ctx, user.TelegramID, chatIDs)
+	//n.checkColonyMaintenance(ctx, user.TelegramID, chatIDs, settings)
+}
+
+// dutyChats are the chats that receive the user's collection notifications:
+// its groups with reminders on, else whoever is on duty, falling back to the
+// user's own chat
+func (n *NotificationSystem) dutyChats(ctx context.Context, user models.TelegramUser) []int64 {
+	groups, err := n.db.GetReminderGroups(ctx, user.TelegramID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error getting reminder groups", "user_id", user.TelegramID, "error", err)
+	}
+	if len(groups) > 0 {
+		chatIDs := make([]int64, 0, len(groups))
+		for _, group := range groups {
+			chatIDs = append(chatIDs, group.ChatID)
+		}
+		return chatIDs
+	}
+
+	recipients, err := n.db.GetDutyRecipients(ctx, user.TelegramID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error getting duty recipients", "user_id", user.TelegramID, "error", err)
+		return []int64{user.ChatID}
+	}
+
+	chatIDs := make([]int64, 0, len(recipients))
+	for _, recipient := range recipients {
+		if recipient.TelegramID == user.TelegramID {
+			recipient.ChatID = user.ChatID
+		}
+		chatIDs = append(chatIDs, recipient.ChatID)
+	}
+	return chatIDs
+}
+
+func (n *NotificationSystem) send(ctx context.Context, userID int64, chatIDs []int64, message, kind string) {
+	for _, chatID := range chatIDs {
+		_, err := n.bot.Send(&tele.Chat{ID: chatID}, message, tele.ModeMarkdown)
+		metrics.ObserveNotification(kind, err)
+		if err != nil {
+			slog.ErrorContext(ctx, "Error sending "+kind+" notification", "user_id", userID, "chat_id", chatID, "error", err)
+		}
+	}
 }
 
-func (n *NotificationSystem) checkFeedings(userID int64, chatID int64, settings *models.UserSettings) {
-	feedings, err := n.db.GetTarantulasDueFeeding(n.ctx, userID)
+func (n *NotificationSystem) checkFeedings(ctx context.Context, userID int64, chatIDs []int64, settings *models.UserSettings) {
+	feedings, err := n.db.GetTarantulasDueFeeding(ctx, userID)
 	if err != nil {
-		slog.Error("Error checking feedings", "user_id", userID, "error", err)
+		slog.ErrorContext(ctx, "Error checking feedings", "user_id", userID, "error", err)
 		return
 	}
 
@@ -145,39 +227,35 @@ func (n *NotificationSystem) checkFeedings(userID int64, chatID int64, settings
 			}
 		}
 
-		if _, err = n.bot.Send(&tele.Chat{ID: chatID}, message, tele.ModeMarkdown); err != nil {
-			slog.Error("Error sending feeding notification", "user_id", userID, "error", err)
-		}
+		n.send(ctx, userID, chatIDs, message, "feeding")
 	}
 }
 
-func (n *NotificationSystem) checkColonies(userID int64, chatID int64, settings *models.UserSettings) {
-	colonies, err := n.db.GetColonyStatus(n.ctx, userID)
+func (n *NotificationSystem) checkColonies(ctx context.Context, userID int64, chatIDs []int64, settings *models.UserSettings) {
+	colonies, err := n.db.GetColonyStatus(ctx, userID)
 	if err != nil {
-		slog.Error("Error checking colonies", "user_id", userID, "error", err)
+		slog.ErrorContext(ctx, "Error checking colonies", "user_id", userID, "error", err)
 		return
 	}
 
-	if len(colonies) > 0 {
-		colony := colonies[0]
+	for _, colony := range colonies {
 		if colony.CurrentCount <= int32(settings.LowColonyThreshold) {
-			message := fmt.Sprintf("🦗 *Low Cricket Alert*\n\nYour cricket colony has %d crickets remaining\n\n💡 Consider breeding more crickets soon!", colony.CurrentCount)
+			message := fmt.Sprintf("🦗 *Low Feeder Alert*\n\nYour %s colony *%s* has %d remaining\n\n💡 Consider breeding or buying more soon!",
+				strings.ToLower(colony.FeederName), colony.ColonyName, colony.CurrentCount)
 
-			if _, err = n.bot.Send(&tele.Chat{ID: chatID}, message, tele.ModeMarkdown); err != nil {
-				slog.Error("Error sending colony notification", "user_id", userID, "error", err)
-			}
+			n.send(ctx, userID, chatIDs, message, "colony")
 		}
 	}
 }
 
-func (n *NotificationSystem) checkMoltPredictions(userID int64, chatID int64, settings *models.UserSettings) {
+func (n *NotificationSystem) checkMoltPredictions(ctx context.Context, userID int64, chatIDs []int64, settings *models.UserSettings) {
 	if !settings.MoltPredictionEnabled {
 		return
 	}
 
-	predictions, err := n.db.GetUpcomingMoltPredictions(n.ctx, userID, settings.MoltPredictionDays)
+	predictions, err := n.db.GetUpcomingMoltPredictions(ctx, userID, settings.MoltPredictionDays)
 	if err != nil {
-		slog.Error("Error checking molt predictions", "user_id", userID, "error", err)
+		slog.ErrorContext(ctx, "Error checking molt predictions", "user_id", userID, "error", err)
 		return
 	}
 
@@ -200,7 +278,9 @@ func (n *NotificationSystem) checkMoltPredictions(userID int64, chatID int64, se
 
 		message += fmt.Sprintf("🕷 *%s*\n", pred.TarantulaName)
 		message += fmt.Sprintf("  • Predicted molt: %s\n", daysUntil)
-		message += fmt.Sprintf("  • Confidence: %s\n", pred.ConfidenceLevel)
+		if pred.EarliestMoltDate != nil && pred.LatestMoltDate != nil {
+			message += fmt.Sprintf("  • Window: %s (%s)\n", FormatMoltWindow(pred), FormatConfidence(pred))
+		}
 
 		if len(pred.PreMoltSigns) > 0 {
 			message += fmt.Sprintf("  • Signs: %s\n", pred.PreMoltSigns[0])
@@ -214,19 +294,17 @@ func (n *NotificationSystem) checkMoltPredictions(userID int64, chatID int64, se
 
 	message += "_Tip: Stop feeding and ensure water is available when molt is imminent._"
 
-	if _, err = n.bot.Send(&tele.Chat{ID: chatID}, message, tele.ModeMarkdown); err != nil {
-		slog.Error("Error sending molt prediction notification", "user_id", userID, "error", err)
-	}
+	n.send(ctx, userID, chatIDs, message, "molt prediction")
 }
 
-func (n *NotificationSystem) checkColonyMaintenance(userID int64, chatID int64, settings *models.UserSettings) {
+func (n *NotificationSystem) checkColonyMaintenance(ctx context.Context, userID int64, chatIDs []int64, settings *models.UserSettings) {
 	if !settings.MaintenanceReminderEnabled {
 		return
 	}
 
-	alerts, err := n.db.GetColonyMaintenanceAlerts(n.ctx, userID)
+	alerts, err := n.db.GetColonyMaintenanceAlerts(ctx, userID)
 	if err != nil {
-		slog.Error("Error checking colony maintenance alerts", "user_id", userID, "error", err)
+		slog.ErrorContext(ctx, "Error checking colony maintenance alerts", "user_id", userID, "error", err)
 		return
 	}
 
@@ -249,7 +327,36 @@ func (n *NotificationSystem) checkColonyMaintenance(userID int64, chatID int64,
 		message += "\n"
 	}
 
-	if _, err = n.bot.Send(&tele.Chat{ID: chatID}, message, tele.ModeMarkdown); err != nil {
-		slog.Error("Error sending maintenance notification", "user_id", userID, "error", err)
+	n.send(ctx, userID, chatIDs, message, "maintenance")
+}
+
+func (n *NotificationSystem) checkColonyCensus(ctx context.Context, userID int64, chatIDs []int64) {
+	alerts, err := n.db.GetColonyCensusAlerts(ctx, userID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error checking colony census", "user_id", userID, "error", err)
+		return
+	}
+
+	if len(alerts) == 0 {
+		return
+	}
+
+	message := "👥 *Colony Check*\n\n"
+	for _, alert := range alerts {
+		message += fmt.Sprintf("*%s*\n", alert.ColonyName)
+		if alert.CountDropped {
+			message += fmt.Sprintf("⚠️ Census dropped from %d to %d visible (%d members)\n",
+				alert.PreviousCount, alert.VisibleCount, alert.ExpectedCount)
+		}
+		if len(alert.UnseenMembers) > 0 {
+			message += fmt.Sprintf("❓ Not seen for over a month: %s\n", strings.Join(alert.UnseenMembers, ", "))
+		}
+		if len(alert.MissingMembers) > 0 {
+			message += fmt.Sprintf("🔎 Reported missing: %s\n", strings.Join(alert.MissingMembers, ", "))
+		}
+		message += "\n"
 	}
+	message += "💡 Take a census or log sightings from the colony details."
+
+	n.send(ctx, userID, chatIDs, message, "colony census")
 }
@@ -3,6 +3,7 @@ package bot
 import (
 	"context"
 	"tarantulago/models"
+	"time"
 )
 
 type TarantulaOperations interface {
@@ -12,6 +13,8 @@ type TarantulaOperations interface {
 
 	TarantulaService
 
+	SpeciesService
+
 	FeedingService
 
 	ColonyService
@@ -20,6 +23,10 @@ type TarantulaOperations interface {
 
 	AnalyticsService
 
+	ExpenseService
+
+	CollectionService
+
 	NotificationOperations
 }
 
@@ -41,16 +48,35 @@ type TarantulaService interface {
 	UpdateTarantulaProfilePhoto(ctx context.Context, tarantulaID int32, photoURL string, userID int64) error
 
 	RecordHealthCheck(ctx context.Context, healthCheck models.HealthCheckRecord) error
-	RecordMolt(ctx context.Context, molt models.MoltRecord) error
+	RecordMolt(ctx context.Context, molt models.MoltRecord) (int64, error)
+	DeleteMolt(ctx context.Context, moltID int64, userID int64) error
+	DeleteWeight(ctx context.Context, weightID int64, userID int64) error
+	SetMoltStage(ctx context.Context, tarantulaID int32, userID int64, stage models.MoltStageEnum) error
 	GetHealthAlerts(ctx context.Context, userID int64) ([]models.HealthAlert, error)
 	GetRecentMoltRecords(ctx context.Context, userID int64, limit int32) ([]models.MoltRecord, error)
 }
 
+type SpeciesService interface {
+	GetAvailableSpecies(ctx context.Context, userID int64) ([]models.TarantulaSpecies, error)
+	GetUserSpecies(ctx context.Context, userID int64) ([]models.TarantulaSpecies, error)
+	GetRecentSpeciesIDs(ctx context.Context, userID int64, limit int) ([]int32, error)
+	GetSpeciesByID(ctx context.Context, speciesID int32) (*models.TarantulaSpecies, error)
+	GetFeedingSchedules(ctx context.Context, speciesID int32) ([]models.FeedingSchedule, error)
+	AddUserSpecies(ctx context.Context, species models.TarantulaSpecies) (int64, error)
+	UpdateSpecies(ctx context.Context, species models.TarantulaSpecies) error
+	ProposeSpecies(ctx context.Context, speciesID int32, userID int64) error
+	GetPendingSpecies(ctx context.Context) ([]models.TarantulaSpecies, error)
+	ReviewSpecies(ctx context.Context, speciesID int32, approve bool) (*models.TarantulaSpecies, error)
+}
+
 type FeedingService interface {
 	RecordFeeding(ctx context.Context, event models.FeedingEvent) (int64, error)
-	QuickFeed(ctx context.Context, tarantulaID int32, userID int64) error
+	DeleteFeeding(ctx context.Context, feedingID int64, userID int64) error
+	QuickFeed(ctx context.Context, tarantulaID int32, userID, recordedBy int64) error
+	GetFeederSpecies(ctx context.Context) ([]models.FeederSpecies, error)
 	GetFeedingHistory(ctx context.Context, userID int64, limit int32) ([]models.FeedingEvent, error)
 	GetRecentFeedingRecords(ctx context.Context, userID int64, limit int32) ([]models.FeedingEvent, error)
+	GetLastFeeding(ctx context.Context, tarantulaID int32, userID int64) (*models.FeedingEvent, error)
 	GetFeedingSchedule(ctx context.Context, speciesID int64, bodyLengthCM float32) (*models.FeedingSchedule, error)
 }
 
@@ -61,18 +87,39 @@ type ColonyService interface {
 	RecordColonyMaintenance(ctx context.Context, record models.ColonyMaintenanceRecord) (int64, error)
 	GetColonyMaintenanceHistory(ctx context.Context, colonyID int64, userID int64, limit int32) ([]models.ColonyMaintenanceRecord, error)
 	GetMaintenanceTypes(ctx context.Context) ([]models.ColonyMaintenanceType, error)
+
+	StartClutch(ctx context.Context, colonyID int32, userID int64) (int64, error)
+	RemoveClutchSubstrate(ctx context.Context, clutchID int32, userID int64) error
+	RecordClutchHatch(ctx context.Context, clutchID int32, userID int64, count int) error
+	MergeClutch(ctx context.Context, clutchID int32, userID int64) error
+	GetFeederProjection(ctx context.Context, userID int64, weeks int) (*models.FeederProjection, error)
+	GetFeederForecast(ctx context.Context, userID int64) (*models.FeederForecast, error)
 }
 
 type TarantulaColonyService interface {
 	CreateColony(ctx context.Context, colony models.TarantulaColony) (int64, error)
 	GetColony(ctx context.Context, colonyID int32, userID int64) (*models.TarantulaColony, error)
 	GetUserColonies(ctx context.Context, userID int64) ([]models.TarantulaColony, error)
-	GetColoniesDueFeeding(ctx context.Context, userID int64) ([]models.TarantulaColony, error)
-	QuickFeedColony(ctx context.Context, colonyID int32, userID int64) error
+	GetColoniesDueFeeding(ctx context.Context, userID int64) ([]models.ColonyFeedingPlan, error)
+	GetColonyFeedingPlan(ctx context.Context, colonyID int32, userID int64) (*models.ColonyFeedingPlan, error)
+	GetColonyFeedingPlans(ctx context.Context, userID int64) ([]models.ColonyFeedingPlan, error)
+	QuickFeedColony(ctx context.Context, colonyID int32, userID, recordedBy int64) (*models.FeedingEvent, error)
 	AddMemberToColony(ctx context.Context, member models.TarantulaColonyMember) error
 	RemoveMemberFromColony(ctx context.Context, colonyID, tarantulaID int32, userID int64) error
 	GetColonyMembers(ctx context.Context, colonyID int32, userID int64, activeOnly bool) ([]models.TarantulaColonyMember, error)
 	UpdateColony(ctx context.Context, colony models.TarantulaColony) error
+
+	SplitColony(ctx context.Context, colonyID int32, tarantulaIDs []int32, newColony models.TarantulaColony) (int64, error)
+	MergeColonies(ctx context.Context, targetID, sourceID int32, userID int64) error
+	AddMembersToColony(ctx context.Context, colonyID int32, tarantulaIDs []int32, userID int64) error
+	RemoveMembersFromColony(ctx context.Context, colonyID int32, tarantulaIDs []int32, userID int64) error
+	GetEnclosures(ctx context.Context, userID int64) ([]models.Enclosure, error)
+	CreateEnclosure(ctx context.Context, enclosure models.Enclosure) (int64, error)
+
+	RecordColonyCensus(ctx context.Context, census models.ColonyCensus) (int64, error)
+	GetColonyCensuses(ctx context.Context, colonyID int32, userID int64, limit int32) ([]models.ColonyCensus, error)
+	RecordColonyMemberEvent(ctx context.Context, event models.ColonyMemberEvent) (int64, error)
+	GetColonyMemberEvents(ctx context.Context, colonyID int32, userID int64, limit int32) ([]models.ColonyMemberEvent, error)
 }
 
 type AnalyticsService interface {
@@ -86,6 +133,33 @@ type AnalyticsService interface {
 	GetAllMoltPredictions(ctx context.Context, userID int64) ([]models.MoltPrediction, error)
 }
 
+type ExpenseService interface {
+	RecordFeederPurchase(ctx context.Context, purchase models.FeederPurchase) (int64, error)
+	RecordExpense(ctx context.Context, expense models.Expense) (int64, error)
+	GetFeederPurchases(ctx context.Context, userID int64) ([]models.FeederPurchase, error)
+	GetExpenses(ctx context.Context, userID int64, year int) ([]models.Expense, error)
+	GetSpendReport(ctx context.Context, userID int64, year int) (*models.SpendReport, error)
+}
+
+type CollectionService interface {
+	GetCollectionAccess(ctx context.Context, userID int64) (*models.CollectionAccess, error)
+	CreateCollectionInvite(ctx context.Context, ownerID int64, role string) (*models.CollectionInvite, error)
+	AcceptCollectionInvite(ctx context.Context, code string, userID int64) (*models.CollectionAccess, error)
+	GetCollectionMembers(ctx context.Context, ownerID int64) ([]models.CollectionMember, error)
+	GetUserMemberships(ctx context.Context, userID int64) ([]models.CollectionMember, error)
+	SwitchCollection(ctx context.Context, userID, ownerID int64) error
+	SetCollectionRole(ctx context.Context, ownerID, memberID int64, role string) error
+	RemoveCollectionMember(ctx context.Context, ownerID, memberID int64) error
+	TakeDuty(ctx context.Context, ownerID, userID int64) error
+	BindGroupChat(ctx context.Context, group models.GroupChat) error
+	UnbindGroupChat(ctx context.Context, chatID int64, ownerID int64) error
+	GetGroupAccess(ctx context.Context, chatID int64, userID int64) (*models.CollectionAccess, error)
+	SetGroupReminders(ctx context.Context, chatID int64, ownerID int64, enabled bool) error
+	CreateSitterInvite(ctx context.Context, ownerID int64, until time.Time) (*models.CollectionInvite, error)
+	RecordCareLog(ctx context.Context, log models.CareLog) error
+	GetCareSummary(ctx context.Context, ownerID int64) ([]models.CareSummaryItem, error)
+}
+
 type NotificationOperations interface {
 	GetColonyStatus(ctx context.Context, userID int64) ([]models.ColonyStatus, error)
 	GetTarantulasDueFeeding(ctx context.Context, userID int64) ([]models.TarantulaListItem, error)
@@ -93,4 +167,10 @@ type NotificationOperations interface {
 	GetActiveUsers(ctx context.Context) ([]models.TelegramUser, error)
 	GetColonyMaintenanceAlerts(ctx context.Context, userID int64) ([]models.ColonyMaintenanceAlert, error)
 	GetUpcomingMoltPredictions(ctx context.Context, userID int64, withinDays int) ([]models.MoltPrediction, error)
+	GetColonyCensusAlerts(ctx context.Context, userID int64) ([]models.ColonyCensusAlert, error)
+	GetDutyRecipients(ctx context.Context, ownerID int64) ([]models.TelegramUser, error)
+	GetReminderGroups(ctx context.Context, ownerID int64) ([]models.GroupChat, error)
+	GetExpiredSitters(ctx context.Context, now time.Time) ([]models.CollectionMember, error)
+	GetSitterDigest(ctx context.Context, sitter models.CollectionMember) (*models.SitterDigest, error)
+	MarkSitterDigestSent(ctx context.Context, memberID int, sentAt time.Time) error
 }
@@ -0,0 +1,174 @@
+package bot
+
+import (
+	"fmt"
+	"slices"
+	"tarantulago/models"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// The feeding form asks for feeder species, size, count, whether the prey
+// was pre-killed and which feeder colony it came from (or bought).
+
+func (t *TarantulaBot) buildFeederSpeciesMarkup(action action1[int]) (*tele.ReplyMarkup, error) {
+	feeders, err := t.db.GetFeederSpecies(t.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	markup := &tele.ReplyMarkup{}
+	var rows [][]tele.InlineButton
+	var row []tele.InlineButton
+	for _, f := range feeders {
+		row = append(row, action.Button(f.Name, f.ID))
+		if len(row) == 2 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+	markup.InlineKeyboard = rows
+	return markup, nil
+}
+
+func (t *TarantulaBot) promptPreyType(c tele.Context) error {
+	markup, err := t.buildFeederSpeciesMarkup(preyTypeAction)
+	if err != nil {
+		return fmt.Errorf("failed to load feeder species: %w", err)
+	}
+	return c.Send("🦗 What prey are you offering?", markup)
+}
+
+func (t *TarantulaBot) handlePreyTypeSelected(c tele.Context, feederSpeciesID int) error {
+	session := t.sessions.GetSession(sessionKey(c))
+	if session.CurrentState != StateFeeding || session.CurrentField != FieldPreyType {
+		return SendError(c, "Invalid session state. Please start over.")
+	}
+
+	session.FeedEvent.FeederSpeciesID = feederSpeciesID
+	session.CurrentField = FieldPreySize
+	t.sessions.UpdateSession(sessionKey(c), session)
+
+	markup := &tele.ReplyMarkup{}
+	var row []tele.InlineButton
+	for _, size := range models.PreySizes {
+		row = append(row, preySizeAction.Button(size, size))
+	}
+	markup.InlineKeyboard = [][]tele.InlineButton{row}
+
+	return c.Send("📏 What size?", markup)
+}
+
+func (t *TarantulaBot) handlePreySizeSelected(c tele.Context, size string) error {
+	session := t.sessions.GetSession(sessionKey(c))
+	if session.CurrentState != StateFeeding || session.CurrentField != FieldPreySize {
+		return SendError(c, "Invalid session state. Please start over.")
+	}
+	if !slices.Contains(models.PreySizes, size) {
+		return SendError(c, "Unknown prey size")
+	}
+
+	session.FeedEvent.PreySize = size
+	session.CurrentField = FieldFeedingCount
+	t.sessions.UpdateSession(sessionKey(c), session)
+
+	if session.SelectedColonyID > 0 {
+		plan, err := t.db.GetColonyFeedingPlan(t.reqCtx(c), int32(session.SelectedColonyID), ownerID(c))
+		if err == nil && plan.PreyCount > 0 {
+			return c.Send(fmt.Sprintf("How many did you offer? (%d suggested for %d feeding members)", plan.PreyCount, plan.FeedingMembers))
+		}
+	}
+	return c.Send("How many did you offer?")
+}
+
+func (t *TarantulaBot) promptPreKilled(c tele.Context) error {
+	markup := &tele.ReplyMarkup{
+		InlineKeyboard: [][]tele.InlineButton{{
+			preyKilledAction.Button("🦗 Live", false),
+			preyKilledAction.Button("🔪 Pre-killed", true),
+		}},
+	}
+	return c.Send("Was the prey live or pre-killed?", markup)
+}
+
+func (t *TarantulaBot) handlePreKilledSelected(c tele.Context, preKilled bool) error {
+	session := t.sessions.GetSession(sessionKey(c))
+	if session.CurrentState != StateFeeding || session.CurrentField != FieldPreKilled {
+		return SendError(c, "Invalid session state. Please start over.")
+	}
+	session.FeedEvent.PreKilled = preKilled
+
+	colonies, err := t.db.GetColonyStatus(t.reqCtx(c), ownerID(c))
+	if err != nil {
+		return fmt.Errorf("failed to load feeder colonies: %w", err)
+	}
+
+	var rows [][]tele.InlineButton
+	for _, colony := range colonies {
+		if int(colony.FeederSpeciesID) != session.FeedEvent.FeederSpeciesID {
+			continue
+		}
+		rows = append(rows, []tele.InlineButton{preySourceAction.Button(fmt.Sprintf("🏠 %s (%d left)", colony.ColonyName, colony.CurrentCount), int(colony.ID))})
+	}
+
+	// Without a matching feeder colony the prey must have been bought
+	if len(rows) == 0 {
+		return t.saveFeeding(c, session)
+	}
+
+	rows = append(rows, []tele.InlineButton{preySourceAction.Button("🛒 Bought", 0)})
+	session.CurrentField = FieldPreySource
+	t.sessions.UpdateSession(sessionKey(c), session)
+
+	return c.Send("Where did the prey come from?", &tele.ReplyMarkup{InlineKeyboard: rows})
+}
+
+func (t *TarantulaBot) handlePreySourceSelected(c tele.Context, colonyID int) error {
+	session := t.sessions.GetSession(sessionKey(c))
+	if session.CurrentState != StateFeeding || session.CurrentField != FieldPreySource {
+		return SendError(c, "Invalid session state. Please start over.")
+	}
+
+	session.FeedEvent.CricketColonyID = nil
+	if colonyID > 0 {
+		session.FeedEvent.CricketColonyID = &colonyID
+	}
+	return t.saveFeeding(c, session)
+}
+
+func (t *TarantulaBot) saveFeeding(c tele.Context, session *UserSession) error {
+	session.FeedEvent.FeedingDate = time.Now()
+	session.FeedEvent.UserID = ownerID(c)
+	recordedBy := c.Sender().ID
+	session.FeedEvent.RecordedBy = &recordedBy
+	session.FeedEvent.FeedingStatusID = int(models.FeedingStatusAccepted)
+
+	// Check if this is colony feeding or individual feeding
+	if session.SelectedColonyID > 0 {
+		colonyID := session.SelectedColonyID
+		session.FeedEvent.TarantulaColonyID = &colonyID
+		session.FeedEvent.TarantulaID = nil // Not individual feeding
+	}
+
+	if _, err := t.db.RecordFeeding(t.reqCtx(c), session.FeedEvent); err != nil {
+		return fmt.Errorf("failed to save feeding event: %w", err)
+	}
+
+	// Check if it was colony feeding before reset
+	isColonyFeeding := session.FeedEvent.TarantulaColonyID != nil
+	session.reset()
+	t.sessions.UpdateSession(sessionKey(c), session)
+
+	if isColonyFeeding {
+		return sendSuccess(c, "Colony feeding recorded!")
+	}
+	return sendSuccess(c, "Feeding event recorded!")
+}
+
+func (t *TarantulaBot) handleAddFeederColony(c tele.Context) error {
+	return t.startForm(c, StateAddingColony, nil)
+}
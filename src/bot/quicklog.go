@@ -0,0 +1,347 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"tarantulago/models"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// Quick logging records something in one line, such as "/fed Rosie 2 medium
+// yesterday", and answers with an Undo button. In private chats the slash
+// can be left out.
+
+type quickLogKind string
+
+const (
+	quickFed     quickLogKind = "fed"
+	quickRefused quickLogKind = "refused"
+	quickMolt    quickLogKind = "molt"
+	quickWeigh   quickLogKind = "weigh"
+	quickPremolt quickLogKind = "premolt"
+)
+
+var quickLogKinds = []quickLogKind{quickFed, quickRefused, quickMolt, quickWeigh, quickPremolt}
+
+var quickLogUsage = map[quickLogKind]string{
+	quickFed:     "/fed Rosie 2 medium",
+	quickRefused: "/refused Rosie",
+	quickMolt:    "/molt Rosie 6.5cm",
+	quickWeigh:   "/weigh Rosie 12.3g",
+	quickPremolt: "/premolt Rosie",
+}
+
+type quickLog struct {
+	kind     quickLogKind
+	name     string
+	date     time.Time
+	count    int
+	size     string
+	lengthCM float64
+	grams    float64
+}
+
+var (
+	measurePattern = regexp.MustCompile(`^(\d+(?:[.,]\d+)?)(cm|g)?$`)
+	daysAgoPattern = regexp.MustCompile(`^(\d+)d$`)
+)
+
+// parseQuickLog reads what follows a quick logging command: the tarantula's
+// name, the details the kind takes, and optionally when it happened as
+// "today", "yesterday", "3 days ago", "3d ago" or YYYY-MM-DD
+func parseQuickLog(kind quickLogKind, args string, now time.Time) (quickLog, error) {
+	log := quickLog{kind: kind, date: now}
+	var name []string
+
+	words := strings.Fields(args)
+	for i := 0; i < len(words); i++ {
+		word := strings.ToLower(words[i])
+		next := func(n int) string {
+			if i+n < len(words) {
+				return strings.ToLower(words[i+n])
+			}
+			return ""
+		}
+
+		if m := daysAgoPattern.FindStringSubmatch(word); m != nil && next(1) == "ago" {
+			days, _ := strconv.Atoi(m[1])
+			log.date = now.AddDate(0, 0, -days)
+			i++
+			continue
+		}
+		if days, err := strconv.Atoi(word); err == nil && slices.Contains([]string{"day", "days"}, next(1)) && next(2) == "ago" {
+			log.date = now.AddDate(0, 0, -days)
+			i += 2
+			continue
+		}
+
+		switch word {
+		case "today":
+			log.date = now
+			continue
+		case "yesterday":
+			log.date = now.AddDate(0, 0, -1)
+			continue
+		}
+		if date, err := time.ParseInLocation(formDateLayout, word, now.Location()); err == nil {
+			if date.After(now) {
+				return log, errors.New("That date is in the future")
+			}
+			log.date = date
+			continue
+		}
+
+		if m := measurePattern.FindStringSubmatch(word); m != nil {
+			value, _ := strconv.ParseFloat(strings.ReplaceAll(m[1], ",", "."), 64)
+			unit, skip := m[2], 0
+			if unit == "" && (next(1) == "cm" || next(1) == "g") {
+				unit, skip = next(1), 1
+			}
+			if log.takeMeasure(value, unit) {
+				i += skip
+				continue
+			}
+		}
+
+		if size, err := parseChoice(word, models.PreySizes...); kind == quickFed && err == nil {
+			log.size = size
+			continue
+		}
+		name = append(name, words[i])
+	}
+
+	log.name = strings.Join(name, " ")
+	switch {
+	case log.name == "":
+		return log, errors.New("Which tarantula?")
+	case kind == quickWeigh && log.grams <= 0:
+		return log, errors.New("How much does it weigh? Add the weight in grams, e.g. 12.3g")
+	case kind == quickMolt && log.lengthCM > 40:
+		return log, errors.New("Please give the length in cm")
+	}
+	if kind == quickFed && log.count == 0 {
+		log.count = 1
+	}
+	return log, nil
+}
+
+// takeMeasure uses a number for what the kind records, reporting false if it
+// doesn't take one so it's read as part of the name
+func (log *quickLog) takeMeasure(value float64, unit string) bool {
+	switch {
+	case log.kind == quickFed && unit == "" && log.count == 0 && value == float64(int(value)) && value > 0:
+		log.count = int(value)
+	case log.kind == quickMolt && unit != "g" && log.lengthCM == 0 && value > 0:
+		log.lengthCM = value
+	case log.kind == quickWeigh && unit != "cm" && log.grams == 0 && value > 0:
+		log.grams = value
+	default:
+		return false
+	}
+	return true
+}
+
+// matchTarantulas finds the tarantulas a typed name could mean, tolerating
+// small typos. Only the best matches are returned; more than one means the
+// name is ambiguous.
+func matchTarantulas(tarantulas []models.TarantulaListItem, name string) []models.TarantulaListItem {
+	q := normalizeSpeciesName(name)
+	best := 0
+	var matches []models.TarantulaListItem
+	for _, tarantula := range tarantulas {
+		score := matchScore(normalizeSpeciesName(tarantula.Name), q)
+		if score == 0 || score < best {
+			continue
+		}
+		if score > best {
+			best, matches = score, nil
+		}
+		matches = append(matches, tarantula)
+	}
+	return matches
+}
+
+// quickLogText reads a quick log typed without the slash, e.g. "fed Rosie"
+func quickLogText(text string) (quickLogKind, string, bool) {
+	word, args, _ := strings.Cut(strings.TrimSpace(text), " ")
+	kind := quickLogKind(strings.ToLower(word))
+	if !slices.Contains(quickLogKinds, kind) || strings.TrimSpace(args) == "" {
+		return "", "", false
+	}
+	return kind, args, true
+}
+
+func (t *TarantulaBot) handleQuickLogCommand(kind quickLogKind) tele.HandlerFunc {
+	return func(c tele.Context) error {
+		return t.handleQuickLog(c, kind, c.Message().Payload)
+	}
+}
+
+func (t *TarantulaBot) handleQuickLog(c tele.Context, kind quickLogKind, args string) error {
+	// Sitters may record feedings but nothing else
+	access := collectionAccess(c)
+	feeding := kind == quickFed || kind == quickRefused
+	if !access.CanEdit() && !(feeding && access.Role == models.CollectionRoleSitter) {
+		return c.Send(roleRefusals[access.Role])
+	}
+
+	log, err := parseQuickLog(kind, args, time.Now())
+	if err != nil {
+		return c.Send(fmt.Sprintf("%s\n\nFor example: %s", err, quickLogUsage[kind]))
+	}
+
+	tarantulas, err := t.db.GetAllTarantulas(t.reqCtx(c), ownerID(c))
+	if err != nil {
+		return fmt.Errorf("failed to get tarantulas: %w", err)
+	}
+	matches := matchTarantulas(tarantulas, log.name)
+	switch {
+	case len(matches) == 0:
+		return SendError(c, fmt.Sprintf("I couldn't find a tarantula called %q", log.name))
+	case len(matches) > 1:
+		var names []string
+		for _, match := range matches {
+			names = append(names, match.Name)
+		}
+		return SendInfo(c, fmt.Sprintf("Which one did you mean: %s?", strings.Join(names, ", ")))
+	}
+
+	msg, undo, err := t.recordQuickLog(c, log, matches[0])
+	if err != nil {
+		return err
+	}
+	if !sameDay(log.date, time.Now()) {
+		msg += " on " + log.date.Format("Mon Jan 2")
+	}
+	return t.sendWithUndo(c, msg, undo)
+}
+
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
+
+// feedingLikeLast starts an accepted feeding of tarantulaID with the prey it
+// had last time, from the same feeder colony
+func (t *TarantulaBot) feedingLikeLast(c tele.Context, tarantulaID int32, date time.Time) (models.FeedingEvent, error) {
+	owner := ownerID(c)
+	recordedBy := c.Sender().ID
+	tid := int(tarantulaID)
+	event := models.FeedingEvent{
+		TarantulaID:     &tid,
+		FeedingDate:     date,
+		FeedingStatusID: int(models.FeedingStatusAccepted),
+		UserID:          owner,
+		RecordedBy:      &recordedBy,
+	}
+
+	last, err := t.db.GetLastFeeding(t.reqCtx(c), tarantulaID, owner)
+	if err != nil {
+		return event, fmt.Errorf("failed to get last feeding: %w", err)
+	}
+	if last != nil {
+		event.FeederSpeciesID = last.FeederSpeciesID
+		event.CricketColonyID = last.CricketColonyID
+		event.PreKilled = last.PreKilled
+		event.PreySize = last.PreySize
+	}
+	return event, nil
+}
+
+// recordQuickLog saves the log for target, returning what was done and how
+// to take it back
+func (t *TarantulaBot) recordQuickLog(c tele.Context, log quickLog, target models.TarantulaListItem) (string, func(context.Context) error, error) {
+	ctx := t.reqCtx(c)
+	owner := ownerID(c)
+	recordedBy := c.Sender().ID
+
+	switch log.kind {
+	case quickFed, quickRefused:
+		event, err := t.feedingLikeLast(c, target.ID, log.date)
+		if err != nil {
+			return "", nil, err
+		}
+		event.PreyCount = log.count
+		event.Notes = "Quick log"
+		if log.size != "" {
+			event.PreySize = log.size
+		}
+		if log.kind == quickRefused {
+			event.FeedingStatusID = int(models.FeedingStatusRejected)
+			event.PreyCount = 0
+			event.CricketColonyID = nil
+		}
+
+		id, err := t.db.RecordFeeding(ctx, event)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to record feeding: %w", err)
+		}
+		undo := func(ctx context.Context) error { return t.db.DeleteFeeding(ctx, id, owner) }
+		if log.kind == quickRefused {
+			return fmt.Sprintf("%s refused food", target.Name), undo, nil
+		}
+		prey := strconv.Itoa(event.PreyCount)
+		if event.PreySize != "" {
+			prey += " " + event.PreySize
+		}
+		return fmt.Sprintf("%s fed %s", target.Name, prey), undo, nil
+
+	case quickMolt:
+		tarantula, err := t.db.GetTarantulaByID(ctx, owner, target.ID)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to get tarantula: %w", err)
+		}
+		id, err := t.db.RecordMolt(ctx, models.MoltRecord{
+			TarantulaID:      int(target.ID),
+			MoltDate:         log.date,
+			MoltStageID:      int(models.MoltStagePostMolt),
+			PreMoltLengthCM:  tarantula.CurrentSize,
+			PostMoltLengthCM: log.lengthCM,
+			Notes:            "Quick log",
+			UserID:           owner,
+			RecordedBy:       &recordedBy,
+		})
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to record molt: %w", err)
+		}
+		msg := fmt.Sprintf("%s molted", target.Name)
+		if log.lengthCM > 0 {
+			msg += fmt.Sprintf(" (%s cm)", formatFormValue(log.lengthCM))
+		}
+		return msg, func(ctx context.Context) error { return t.db.DeleteMolt(ctx, id, owner) }, nil
+
+	case quickWeigh:
+		id, err := t.db.RecordWeight(ctx, models.WeightRecord{
+			TarantulaID: int(target.ID),
+			WeightGrams: log.grams,
+			WeighDate:   log.date,
+			UserID:      owner,
+		})
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to save weight: %w", err)
+		}
+		return fmt.Sprintf("%s weighs %sg", target.Name, formatFormValue(log.grams)),
+			func(ctx context.Context) error { return t.db.DeleteWeight(ctx, id, owner) }, nil
+
+	case quickPremolt:
+		tarantula, err := t.db.GetTarantulaByID(ctx, owner, target.ID)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to get tarantula: %w", err)
+		}
+		previous := models.MoltStageEnum(tarantula.CurrentMoltStageID)
+		if err := t.db.SetMoltStage(ctx, target.ID, owner, models.MoltStagePreMolt); err != nil {
+			return "", nil, fmt.Errorf("failed to mark premolt: %w", err)
+		}
+		return fmt.Sprintf("%s marked as in premolt", target.Name),
+			func(ctx context.Context) error { return t.db.SetMoltStage(ctx, target.ID, owner, previous) }, nil
+	}
+	return "", nil, fmt.Errorf("unknown quick log %q", log.kind)
+}
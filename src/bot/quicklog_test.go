@@ -0,0 +1,86 @@
+package bot
+
+import (
+	"context"
+	"tarantulago/models"
+	"testing"
+	"time"
+)
+
+func TestParseQuickLog(t *testing.T) {
+	now := time.Date(2025, 6, 10, 18, 0, 0, 0, time.UTC)
+	yesterday := now.AddDate(0, 0, -1)
+	tests := []struct {
+		kind quickLogKind
+		args string
+		want quickLog
+	}{
+		{quickFed, "Rosie 2 medium", quickLog{name: "Rosie", count: 2, size: models.PreySizeMedium, date: now}},
+		{quickFed, "Big Rosie yesterday", quickLog{name: "Big Rosie", count: 1, date: yesterday}},
+		{quickRefused, "Rosie 3 days ago", quickLog{name: "Rosie", date: now.AddDate(0, 0, -3)}},
+		{quickMolt, "Rosie 6,5 cm 2d ago", quickLog{name: "Rosie", lengthCM: 6.5, date: now.AddDate(0, 0, -2)}},
+		{quickMolt, "Rosie", quickLog{name: "Rosie", date: now}},
+		{quickWeigh, "Rosie 12.3g 2025-06-01", quickLog{name: "Rosie", grams: 12.3, date: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)}},
+		{quickPremolt, "Spider 7", quickLog{name: "Spider 7", date: now}},
+	}
+	for _, tt := range tests {
+		tt.want.kind = tt.kind
+		got, err := parseQuickLog(tt.kind, tt.args, now)
+		if err != nil || got != tt.want {
+			t.Errorf("parseQuickLog(%s, %q) = %+v, %v; want %+v", tt.kind, tt.args, got, err, tt.want)
+		}
+	}
+
+	for kind, args := range map[quickLogKind]string{
+		quickFed:   "2 yesterday",
+		quickWeigh: "Rosie",
+		quickMolt:  "Rosie 2099-01-01",
+	} {
+		if got, err := parseQuickLog(kind, args, now); err == nil {
+			t.Errorf("parseQuickLog(%s, %q) = %+v, want an error", kind, args, got)
+		}
+	}
+}
+
+func TestMatchTarantulas(t *testing.T) {
+	tarantulas := []models.TarantulaListItem{{ID: 1, Name: "Rosie"}, {ID: 2, Name: "Rosalind"}, {ID: 3, Name: "Boris"}}
+	tests := []struct {
+		name string
+		want []int32
+	}{
+		{"rosie", []int32{1}},
+		{"ros", []int32{1, 2}},
+		{"Borris", []int32{3}},
+		{"Charlotte", nil},
+	}
+	for _, tt := range tests {
+		var got []int32
+		for _, match := range matchTarantulas(tarantulas, tt.name) {
+			got = append(got, match.ID)
+		}
+		if len(got) != len(tt.want) || (len(got) > 0 && got[0] != tt.want[0]) {
+			t.Errorf("matchTarantulas(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestUndoLog(t *testing.T) {
+	log := newUndoLog()
+	now := time.Now()
+	undone := false
+	id := log.add(1, func(context.Context) error { undone = true; return nil }, now)
+
+	if _, ok := log.take(id, 2, now); ok {
+		t.Error("someone else took the undo")
+	}
+	if _, ok := log.take(id, 1, now.Add(undoWindow+time.Second)); ok {
+		t.Error("took an expired undo")
+	}
+	undo, ok := log.take(id, 1, now.Add(time.Minute))
+	if !ok || undo(context.Background()) != nil || !undone {
+		t.Fatal("couldn't undo")
+	}
+	if _, ok := log.take(id, 1, now); ok {
+		t.Error("undid twice")
+	}
+}
@@ -0,0 +1,288 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// Inline buttons carry "<version>|<action>|<param>...". Buttons are only built
+// from declared actions and every action is routed to a handler taking its
+// params as typed arguments, so the two can't drift apart. Anything that
+// doesn't decode, such as a button on a message from before the format
+// changed, is answered as expired.
+
+const (
+	callbackVersion  = "1"
+	callbackSep      = "|"
+	maxCallbackBytes = 64
+
+	staleCallbackMessage = "⌛ This button has expired. Please open the menu again."
+)
+
+var errStaleCallback = errors.New("stale callback")
+
+var callbackEscaper = strings.NewReplacer("%", "%25", callbackSep, "%7C")
+
+// callbackParam is what button payloads can carry
+type callbackParam interface {
+	~int | ~int32 | ~int64 | ~string | ~bool
+}
+
+// callbackActions holds every declared action name
+var callbackActions = make(map[string]bool)
+
+// declareAction registers an action whose params encode to at most
+// paramBytes each. Strings count as empty since a trailing one is shortened
+// to fit; numbers and flags can't be, so they must always fit.
+func declareAction(name string, paramBytes ...int) string {
+	if name == "" || strings.Contains(name, callbackSep) || callbackActions[name] {
+		panic(fmt.Sprintf("bad or duplicate callback action %q", name))
+	}
+	size := len(callbackVersion + callbackSep + name)
+	for _, n := range paramBytes {
+		size += len(callbackSep) + n
+	}
+	if size > maxCallbackBytes {
+		panic(fmt.Sprintf("callback action %q needs up to %d bytes, over the %d Telegram allows", name, size, maxCallbackBytes))
+	}
+	callbackActions[name] = true
+	return name
+}
+
+// Actions by number of params
+type (
+	action0                     struct{ name string }
+	action1[A callbackParam]    struct{ name string }
+	action2[A, B callbackParam] struct{ name string }
+)
+
+func newAction0(name string) action0 { return action0{declareAction(name)} }
+
+func newAction1[A callbackParam](name string) action1[A] {
+	return action1[A]{declareAction(name, maxParamBytes[A]())}
+}
+
+func newAction2[A, B callbackParam](name string) action2[A, B] {
+	return action2[A, B]{declareAction(name, maxParamBytes[A](), maxParamBytes[B]())}
+}
+
+func (a action0) Button(text string) tele.InlineButton {
+	return tele.InlineButton{Text: text, Data: encodeCallback(a.name, false)}
+}
+
+func (a action1[A]) Button(text string, p A) tele.InlineButton {
+	return tele.InlineButton{Text: text, Data: encodeCallback(a.name, isStringParam[A](), encodeParam(p))}
+}
+
+func (a action2[A, B]) Button(text string, p A, q B) tele.InlineButton {
+	return tele.InlineButton{Text: text, Data: encodeCallback(a.name, isStringParam[B](), encodeParam(p), encodeParam(q))}
+}
+
+// encodeCallback builds a button payload. Telegram drops anything over 64
+// bytes, so a trailing text param is shortened until it fits. Any other
+// overflow would change what the button means, so it panics instead.
+func encodeCallback(action string, trimLast bool, params ...string) string {
+	data := strings.Join(append([]string{callbackVersion, action}, params...), callbackSep)
+	if len(data) <= maxCallbackBytes {
+		return data
+	}
+	if !trimLast || len(params) == 0 {
+		panic(fmt.Sprintf("callback payload %q is over %d bytes", data, maxCallbackBytes))
+	}
+
+	last, _ := url.PathUnescape(params[len(params)-1])
+	prefix := strings.TrimSuffix(data, params[len(params)-1])
+	for len(last) > 0 {
+		_, size := utf8.DecodeLastRuneInString(last)
+		last = last[:len(last)-size]
+		if data = prefix + callbackEscaper.Replace(last); len(data) <= maxCallbackBytes {
+			break
+		}
+	}
+	return data
+}
+
+// decodeCallback splits a payload into its action and params
+func decodeCallback(data string) (action string, params []string, ok bool) {
+	parts := strings.Split(data, callbackSep)
+	if len(parts) < 2 || parts[0] != callbackVersion {
+		return "", nil, false
+	}
+	return parts[1], parts[2:], true
+}
+
+// callbackAction is the action a payload names, or "" if it doesn't decode
+func callbackAction(data string) string {
+	action, _, _ := decodeCallback(data)
+	return action
+}
+
+func isStringParam[P callbackParam]() bool {
+	var p P
+	return reflect.ValueOf(p).Kind() == reflect.String
+}
+
+// maxParamBytes is the longest encoding of a number or flag param, and 0 for
+// strings
+func maxParamBytes[P callbackParam]() int {
+	var p P
+	v := reflect.ValueOf(p)
+	switch v.Kind() {
+	case reflect.String:
+		return 0
+	case reflect.Bool:
+		return 1
+	default:
+		// The sign and every digit of the most negative value
+		return len(strconv.FormatInt(math.MinInt64>>(64-v.Type().Bits()), 10))
+	}
+}
+
+func encodeParam[P callbackParam](p P) string {
+	v := reflect.ValueOf(p)
+	switch v.Kind() {
+	case reflect.String:
+		return callbackEscaper.Replace(v.String())
+	case reflect.Bool:
+		if v.Bool() {
+			return "1"
+		}
+		return "0"
+	default:
+		return strconv.FormatInt(v.Int(), 10)
+	}
+}
+
+func decodeParam[P callbackParam](s string) (P, error) {
+	var p P
+	v := reflect.ValueOf(&p).Elem()
+	switch v.Kind() {
+	case reflect.String:
+		text, err := url.PathUnescape(s)
+		if err != nil {
+			return p, err
+		}
+		v.SetString(text)
+	case reflect.Bool:
+		if s != "0" && s != "1" {
+			return p, fmt.Errorf("not a flag: %q", s)
+		}
+		v.SetBool(s == "1")
+	default:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return p, err
+		}
+		v.SetInt(n)
+	}
+	return p, nil
+}
+
+type callbackRoute func(c tele.Context, params []string) error
+
+// callbackRouter sends callback queries to the handler of their action
+type callbackRouter struct {
+	routes map[string]callbackRoute
+}
+
+func newCallbackRouter() *callbackRouter {
+	return &callbackRouter{routes: make(map[string]callbackRoute)}
+}
+
+func (r *callbackRouter) add(action string, route callbackRoute) {
+	if _, ok := r.routes[action]; ok {
+		panic(fmt.Sprintf("callback action %q routed twice", action))
+	}
+	r.routes[action] = route
+}
+
+func route0(r *callbackRouter, a action0, h func(tele.Context) error) {
+	r.add(a.name, func(c tele.Context, params []string) error {
+		if len(params) != 0 {
+			return errStaleCallback
+		}
+		return h(c)
+	})
+}
+
+func route1[A callbackParam](r *callbackRouter, a action1[A], h func(tele.Context, A) error) {
+	r.add(a.name, func(c tele.Context, params []string) error {
+		if len(params) != 1 {
+			return errStaleCallback
+		}
+		p, err := decodeParam[A](params[0])
+		if err != nil {
+			return errStaleCallback
+		}
+		return h(c, p)
+	})
+}
+
+func route2[A, B callbackParam](r *callbackRouter, a action2[A, B], h func(tele.Context, A, B) error) {
+	r.add(a.name, func(c tele.Context, params []string) error {
+		if len(params) != 2 {
+			return errStaleCallback
+		}
+		p, err := decodeParam[A](params[0])
+		if err != nil {
+			return errStaleCallback
+		}
+		q, err := decodeParam[B](params[1])
+		if err != nil {
+			return errStaleCallback
+		}
+		return h(c, p, q)
+	})
+}
+
+// handle routes a callback query and makes sure it's answered, so the
+// button stops spinning even when its handler only sent a message
+func (r *callbackRouter) handle(c tele.Context) error {
+	answered := &answeringContext{Context: c}
+
+	err := errStaleCallback
+	if action, params, ok := decodeCallback(c.Callback().Data); ok {
+		if route, ok := r.routes[action]; ok {
+			err = route(answered, params)
+		}
+	}
+	if errors.Is(err, errStaleCallback) {
+		slog.InfoContext(requestContext(c), "Stale callback", "data", c.Callback().Data)
+		err = answered.RespondAlert(staleCallbackMessage)
+	}
+
+	if !answered.answered {
+		if respondErr := c.Respond(); respondErr != nil {
+			slog.WarnContext(requestContext(c), "Failed to answer callback", "error", respondErr)
+		}
+	}
+	return err
+}
+
+// answeringContext notes whether a handler answered the callback query
+type answeringContext struct {
+	tele.Context
+	answered bool
+}
+
+func (c *answeringContext) Respond(resp ...*tele.CallbackResponse) error {
+	c.answered = true
+	return c.Context.Respond(resp...)
+}
+
+func (c *answeringContext) RespondText(text string) error {
+	return c.Respond(&tele.CallbackResponse{Text: text})
+}
+
+func (c *answeringContext) RespondAlert(text string) error {
+	return c.Respond(&tele.CallbackResponse{Text: text, ShowAlert: true})
+}
@@ -0,0 +1,132 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+func TestCallbackEncoding(t *testing.T) {
+	data := shareRoleAction.Button("", 123456789, "a|b%c").Data
+	action, params, ok := decodeCallback(data)
+	if !ok || action != shareRoleAction.name || len(params) != 2 {
+		t.Fatalf("decodeCallback(%q) = %q, %q, %v", data, action, params, ok)
+	}
+	if id, err := decodeParam[int64](params[0]); err != nil || id != 123456789 {
+		t.Errorf("member ID = %d, %v", id, err)
+	}
+	if role, err := decodeParam[string](params[1]); err != nil || role != "a|b%c" {
+		t.Errorf("role = %q, %v", role, err)
+	}
+
+	field, err := decodeParam[TarantulaFormField](encodeParam(FieldHumidity))
+	if err != nil || field != FieldHumidity {
+		t.Errorf("field = %q, %v", field, err)
+	}
+	if _, err := decodeParam[bool]("yes"); err == nil {
+		t.Error("decoded a flag from \"yes\"")
+	}
+
+	long := careBrowseAction.Button("", 3, strings.Repeat("Brachypelma ", 4)+"émilia").Data
+	if len(long) > maxCallbackBytes || !utf8.ValidString(long) {
+		t.Errorf("long payload %q is %d bytes", long, len(long))
+	}
+	_, params, _ = decodeCallback(long)
+	if query, _ := decodeParam[string](params[1]); !strings.HasPrefix(query, "Brachypelma Brachypelma") {
+		t.Errorf("query cut to %q", query)
+	}
+
+	for _, stale := range []string{"feed:4", "\fselect|4", "2|feed|4", ""} {
+		if _, _, ok := decodeCallback(stale); ok {
+			t.Errorf("decoded stale payload %q", stale)
+		}
+	}
+}
+
+func TestCallbackOverflowPanics(t *testing.T) {
+	mustPanic := func(what string, f func()) {
+		t.Helper()
+		defer func() {
+			if recover() == nil {
+				t.Errorf("%s didn't panic", what)
+			}
+		}()
+		f()
+	}
+
+	// These never register, so they don't need a route
+	mustPanic("a name over the limit", func() { newAction0(strings.Repeat("x", maxCallbackBytes)) })
+	mustPanic("numbers that may not fit", func() { newAction2[int64, int64](strings.Repeat("x", 30)) })
+	mustPanic("a number that doesn't fit", func() {
+		encodeCallback(strings.Repeat("x", 50), false, "123456789012345")
+	})
+
+	if got := maxParamBytes[int32](); got != len("-2147483648") {
+		t.Errorf("int32 width = %d", got)
+	}
+	if got := maxParamBytes[int64](); got != len("-9223372036854775808") {
+		t.Errorf("int64 width = %d", got)
+	}
+}
+
+func TestEveryActionIsRouted(t *testing.T) {
+	routes := (&TarantulaBot{}).callbackRoutes().routes
+	for action := range callbackActions {
+		if _, ok := routes[action]; !ok {
+			t.Errorf("no route for callback action %q", action)
+		}
+	}
+}
+
+type fakeCallbackContext struct {
+	tele.Context
+	data      string
+	responses []*tele.CallbackResponse
+}
+
+func (c *fakeCallbackContext) Callback() *tele.Callback { return &tele.Callback{Data: c.data} }
+func (c *fakeCallbackContext) Get(string) interface{}   { return nil }
+
+func (c *fakeCallbackContext) Respond(resp ...*tele.CallbackResponse) error {
+	c.responses = append(c.responses, resp...)
+	if len(resp) == 0 {
+		c.responses = append(c.responses, nil)
+	}
+	return nil
+}
+
+func TestCallbackRouterAnswers(t *testing.T) {
+	pick := action1[int]{name: "pick"}
+	alert := action0{name: "alert"}
+	var picked int
+	r := newCallbackRouter()
+	route1(r, pick, func(c tele.Context, id int) error {
+		picked = id
+		return nil
+	})
+	route0(r, alert, func(c tele.Context) error {
+		return c.RespondAlert("done")
+	})
+
+	c := &fakeCallbackContext{data: pick.Button("", 7).Data}
+	if err := r.handle(c); err != nil || picked != 7 {
+		t.Fatalf("handle = %v, picked %d", err, picked)
+	}
+	if len(c.responses) != 1 || c.responses[0] != nil {
+		t.Errorf("silent handler answered with %v, want one empty answer", c.responses)
+	}
+
+	c = &fakeCallbackContext{data: alert.Button("").Data}
+	if err := r.handle(c); err != nil || len(c.responses) != 1 || c.responses[0].Text != "done" {
+		t.Errorf("answering handler: %v, responses %v", err, c.responses)
+	}
+
+	for _, stale := range []string{"pick:7", encodeCallback("pick", false, "x"), encodeCallback("gone", false)} {
+		c = &fakeCallbackContext{data: stale}
+		if err := r.handle(c); err != nil || len(c.responses) != 1 || c.responses[0].Text != staleCallbackMessage {
+			t.Errorf("stale %q: %v, responses %v", stale, err, c.responses)
+		}
+	}
+}
@@ -2,7 +2,6 @@ package bot
 
 import (
 	"context"
-	"fmt"
 	"tarantulago/models"
 	"time"
 )
@@ -11,10 +10,6 @@ type TarantulaServiceImpl struct {
 	db TarantulaOperations
 }
 
-type FeedingServiceImpl struct {
-	db TarantulaOperations
-}
-
 type ColonyServiceImpl struct {
 	db TarantulaOperations
 }
@@ -68,29 +63,6 @@ type TarantulaDetails struct {
 	LatestWeight *models.WeightRecord
 }
 
-func NewFeedingService(db TarantulaOperations) *FeedingServiceImpl {
-	return &FeedingServiceImpl{db: db}
-}
-
-func (s *FeedingServiceImpl) QuickFeedWithCrickets(ctx context.Context, tarantulaID int32, userID int64, cricketCount int) error {
-	tid := int(tarantulaID)
-	event := models.FeedingEvent{
-		TarantulaID:      &tid,
-		FeedingDate:      time.Now(),
-		NumberOfCrickets: cricketCount,
-		Notes:            fmt.Sprintf("Quick feed - %d crickets", cricketCount),
-		UserID:           userID,
-	}
-
-	_, err := s.db.RecordFeeding(ctx, event)
-	return err
-}
-
-func (s *FeedingServiceImpl) GetRecentFeedingsForTarantula(ctx context.Context, tarantulaID int32, userID int64) ([]models.FeedingEvent, error) {
-
-	return s.db.GetRecentFeedingRecords(ctx, userID, 10)
-}
-
 func NewColonyService(db TarantulaOperations) *ColonyServiceImpl {
 	return &ColonyServiceImpl{db: db}
 }
@@ -103,12 +75,12 @@ func (s *ColonyServiceImpl) GetStatusSummary(ctx context.Context, userID int64)
 
 	summary := &ColonySummary{
 		Colonies:       statuses,
-		TotalCrickets:  0,
+		TotalFeeders:   0,
 		NeedsAttention: false,
 	}
 
 	for _, status := range statuses {
-		summary.TotalCrickets += int(status.CurrentCount)
+		summary.TotalFeeders += int(status.CurrentCount)
 		if status.CurrentCount < 20 {
 			summary.NeedsAttention = true
 		}
@@ -119,7 +91,7 @@ func (s *ColonyServiceImpl) GetStatusSummary(ctx context.Context, userID int64)
 
 type ColonySummary struct {
 	Colonies       []models.ColonyStatus
-	TotalCrickets  int
+	TotalFeeders   int
 	NeedsAttention bool
 }
 
@@ -214,21 +186,3 @@ func DefaultQueryOptions() QueryOptions {
 		SortOrder: "desc",
 	}
 }
-
-type ServiceError struct {
-	Operation string
-	Err       error
-	UserID    int64
-}
-
-func (e ServiceError) Error() string {
-	return fmt.Sprintf("service error in %s for user %d: %v", e.Operation, e.UserID, e.Err)
-}
-
-func NewServiceError(operation string, userID int64, err error) ServiceError {
-	return ServiceError{
-		Operation: operation,
-		UserID:    userID,
-		Err:       err,
-	}
-}
@@ -1,7 +1,6 @@
 package bot
 
 import (
-	"context"
 	"fmt"
 	"strconv"
 	"sync"
@@ -28,6 +27,25 @@ const (
 	StateCreatingColony   FormState = "creating_tarantula_colony"
 	StateAddingToColony   FormState = "adding_to_colony"
 	StateSelectingSpecies FormState = "selecting_colony_species"
+
+	StateAddingSpecies  FormState = "adding_species"
+	StateEditingSpecies FormState = "editing_species"
+
+	StateRecordingHatch FormState = "recording_hatch"
+
+	StateRecordingPurchase FormState = "recording_purchase"
+	StateRecordingExpense  FormState = "recording_expense"
+
+	StateRecordingCensus FormState = "recording_census"
+
+	StateSelectingMembers FormState = "selecting_colony_members"
+	StateSplittingColony  FormState = "splitting_colony"
+
+	StateCareNote FormState = "care_note"
+
+	StateRecordingWeight FormState = "recording_weight"
+
+	StateFeedingRound FormState = "feeding_round"
 )
 
 type TarantulaFormField string
@@ -46,31 +64,83 @@ const (
 	FieldMoltNotes        TarantulaFormField = "molt_notes"
 	FieldSuccess          TarantulaFormField = "success"
 
-	FieldColonyName  TarantulaFormField = "colony_name"
-	FieldColonyCount TarantulaFormField = "colony_count"
+	FieldColonyName   TarantulaFormField = "colony_name"
+	FieldColonyFeeder TarantulaFormField = "colony_feeder"
+	FieldColonySize   TarantulaFormField = "colony_size"
+	FieldColonyCount  TarantulaFormField = "colony_count"
 
-	FieldColonyID     TarantulaFormField = "colony_id"
+	FieldPreyType     TarantulaFormField = "prey_type"
+	FieldPreySize     TarantulaFormField = "prey_size"
 	FieldFeedingCount TarantulaFormField = "feeding_count"
+	FieldPreKilled    TarantulaFormField = "pre_killed"
+	FieldPreySource   TarantulaFormField = "prey_source"
+
+	FieldHatchCount TarantulaFormField = "hatch_count"
+
+	FieldPurchaseFeeder   TarantulaFormField = "purchase_feeder"
+	FieldPurchaseSize     TarantulaFormField = "purchase_size"
+	FieldPurchaseQuantity TarantulaFormField = "purchase_quantity"
+	FieldPurchasePrice    TarantulaFormField = "purchase_price"
+	FieldPurchaseShipping TarantulaFormField = "purchase_shipping"
+	FieldPurchaseSupplier TarantulaFormField = "purchase_supplier"
+	FieldPurchaseColony   TarantulaFormField = "purchase_colony"
+
+	FieldExpenseCategory    TarantulaFormField = "expense_category"
+	FieldExpenseAmount      TarantulaFormField = "expense_amount"
+	FieldExpenseDescription TarantulaFormField = "expense_description"
+	FieldExpenseTarantula   TarantulaFormField = "expense_tarantula"
+
+	FieldCensusCount TarantulaFormField = "census_count"
+
+	FieldMembersToAdd    TarantulaFormField = "members_to_add"
+	FieldMembersToRemove TarantulaFormField = "members_to_remove"
+	FieldMembersToSplit  TarantulaFormField = "members_to_split"
+	FieldSplitEnclosure  TarantulaFormField = "split_enclosure"
 
 	FieldPhoto TarantulaFormField = "photo"
 
-	FieldColonySelection   TarantulaFormField = "colony_selection"
+	FieldColonySelection    TarantulaFormField = "colony_selection"
 	FieldTarantulaSelection TarantulaFormField = "tarantula_selection"
-	FieldFormationDate     TarantulaFormField = "formation_date"
+	FieldFormationDate      TarantulaFormField = "formation_date"
+
+	FieldScientificName TarantulaFormField = "scientific_name"
+	FieldCommonName     TarantulaFormField = "common_name"
+	FieldSynonyms       TarantulaFormField = "synonyms"
+	FieldAdultSize      TarantulaFormField = "adult_size"
+	FieldTemperament    TarantulaFormField = "temperament"
+	FieldHumidity       TarantulaFormField = "humidity"
+	FieldTemperature    TarantulaFormField = "temperature"
+	FieldCommunal       TarantulaFormField = "communal"
+	FieldHabitat        TarantulaFormField = "habitat"
+	FieldUrticating     TarantulaFormField = "urticating"
+	FieldVenom          TarantulaFormField = "venom"
+	FieldGrowthRate     TarantulaFormField = "growth_rate"
+	FieldOrigin         TarantulaFormField = "origin"
+	FieldSpeciesSharing TarantulaFormField = "species_sharing"
 )
 
 type UserSession struct {
-	CurrentState       FormState
-	CurrentField       TarantulaFormField
-	TarantulaData      models.Tarantula
-	MoltData           models.MoltRecord
-	Colony             models.CricketColony
-	TarantulaColony    models.TarantulaColony
-	ColonyMember       models.TarantulaColonyMember
-	FeedEvent          models.FeedingEvent
-	LastActivityTime   time.Time
-	SelectedColonyID   int
+	CurrentState        FormState
+	CurrentField        TarantulaFormField
+	TarantulaData       models.Tarantula
+	MoltData            models.MoltRecord
+	Colony              models.CricketColony
+	TarantulaColony     models.TarantulaColony
+	ColonyMember        models.TarantulaColonyMember
+	FeedEvent           models.FeedingEvent
+	LastActivityTime    time.Time
+	SelectedColonyID    int
 	SelectedTarantulaID int
+	SpeciesData         models.TarantulaSpecies
+	SelectedSpeciesID   int
+	SpeciesQuery        string
+	SelectedClutchID    int
+	Purchase            models.FeederPurchase
+	Expense             models.Expense
+	SelectedMemberID    int
+	SelectedTarantulas  []int32
+	FormAnswers         map[TarantulaFormField]formAnswer
+	Round               *feedingRound
 }
 
 func (s *UserSession) reset() {
@@ -84,23 +154,66 @@ func (s *UserSession) reset() {
 	s.FeedEvent = models.FeedingEvent{}
 	s.SelectedColonyID = 0
 	s.SelectedTarantulaID = 0
+	s.SpeciesData = models.TarantulaSpecies{}
+	s.SelectedSpeciesID = 0
+	s.SpeciesQuery = ""
+	s.SelectedClutchID = 0
+	s.Purchase = models.FeederPurchase{}
+	s.Expense = models.Expense{}
+	s.SelectedMemberID = 0
+	s.SelectedTarantulas = nil
+	s.FormAnswers = nil
+	s.Round = nil
+}
+
+// SessionKey identifies a form in progress: per sender, and per chat so the
+// same keeper can fill in forms in a group and in private at once
+type SessionKey struct {
+	ChatID int64
+	UserID int64
+}
+
+func sessionKey(c tele.Context) SessionKey {
+	return SessionKey{ChatID: c.Chat().ID, UserID: c.Sender().ID}
 }
 
 type SessionManager struct {
-	sessions map[int64]*UserSession
-	mu       sync.RWMutex
+	sessions map[SessionKey]*UserSession
+	locks    map[SessionKey]*sync.Mutex
+	// inForm is each session's state as of its last update. Handlers change
+	// sessions without sm.mu, so InForm can't read them live.
+	inForm map[SessionKey]bool
+	mu     sync.RWMutex
 }
 
 func NewSessionManager() *SessionManager {
 	return &SessionManager{
-		sessions: make(map[int64]*UserSession),
+		sessions: make(map[SessionKey]*UserSession),
+		locks:    make(map[SessionKey]*sync.Mutex),
+		inForm:   make(map[SessionKey]bool),
 	}
 }
 
-func (sm *SessionManager) GetSession(userID int64) *UserSession {
+// Lock serializes handlers working on one session. Telebot runs handlers
+// concurrently, so a double tap would otherwise act on the same state twice.
+// Call the returned func to release it.
+func (sm *SessionManager) Lock(key SessionKey) func() {
+	sm.mu.Lock()
+	lock, exists := sm.locks[key]
+	if !exists {
+		lock = &sync.Mutex{}
+		sm.locks[key] = lock
+	}
+	sm.mu.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
+
+func (sm *SessionManager) GetSession(key SessionKey) *UserSession {
 	sm.mu.RLock()
 	defer sm.mu.RUnlock()
-	if session, exists := sm.sessions[userID]; exists {
+	if session, exists := sm.sessions[key]; exists {
 		return session
 	}
 	return &UserSession{
@@ -109,383 +222,313 @@ func (sm *SessionManager) GetSession(userID int64) *UserSession {
 	}
 }
 
-func (sm *SessionManager) UpdateSession(userID int64, session *UserSession) {
+// InForm counts the sessions in the middle of a form, as of their last update
+func (sm *SessionManager) InForm() int {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	count := 0
+	for _, inForm := range sm.inForm {
+		if inForm {
+			count++
+		}
+	}
+	return count
+}
+
+func (sm *SessionManager) UpdateSession(key SessionKey, session *UserSession) {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 	session.LastActivityTime = time.Now()
-	sm.sessions[userID] = session
+	sm.sessions[key] = session
+	sm.inForm[key] = session.CurrentState != StateIdle && session.CurrentState != ""
 }
 
-func (t *TarantulaBot) handleTarantulaFormInput(c tele.Context, session *UserSession) error {
+func (t *TarantulaBot) handleFeedingFormInput(c tele.Context, session *UserSession) error {
 	switch session.CurrentField {
-	case FieldName:
-		session.TarantulaData.Name = c.Text()
-		session.CurrentField = FieldSpecies
-		t.sessions.UpdateSession(c.Sender().ID, session)
-
-		// Show species selection buttons
-		species, err := t.db.GetAllSpecies(context.Background())
-		if err != nil {
-			return c.Send("Failed to load species list. Please try again.")
+	case FieldFeedingCount:
+		count, err := strconv.Atoi(c.Text())
+		if err != nil || count <= 0 {
+			return c.Send("Please enter a valid number for the feeding count")
 		}
+		session.FeedEvent.PreyCount = count
+		session.CurrentField = FieldPreKilled
+		t.sessions.UpdateSession(sessionKey(c), session)
+		return t.promptPreKilled(c)
+	default:
+		return c.Send("Please choose one of the options above")
+	}
+}
 
-		msg := "Great name! Now, what species is your tarantula?"
-		markup := &tele.ReplyMarkup{}
-		var buttons [][]tele.InlineButton
-
-		// Show species in rows of 2
-		for i := 0; i < len(species); i += 2 {
-			var row []tele.InlineButton
-			btn1 := tele.InlineButton{
-				Text: species[i].CommonName,
-				Data: fmt.Sprintf("add_tarantula_species:%d", species[i].ID),
-			}
-			row = append(row, btn1)
+// formDefinitions declares the forms run by the form engine, by the state
+// they keep the session in
+func (t *TarantulaBot) formDefinitions() map[FormState]*form {
+	return map[FormState]*form{
+		StateAddingTarantula: t.tarantulaForm(),
+		StateAddingMolt:      t.moltForm(),
+		StateAddingColony:    t.feederColonyForm(),
+		StateAddingCrickets:  t.cricketCountForm(),
+		StateCreatingColony:  t.tarantulaColonyForm(),
+	}
+}
 
-			if i+1 < len(species) {
-				btn2 := tele.InlineButton{
-					Text: species[i+1].CommonName,
-					Data: fmt.Sprintf("add_tarantula_species:%d", species[i+1].ID),
-				}
-				row = append(row, btn2)
+func (t *TarantulaBot) tarantulaForm() *form {
+	species := newField(FieldSpecies, "Species", "", parseWholeNumber, nil, func(s *UserSession, id int) {
+		s.TarantulaData.SpeciesID = id
+	})
+	species.ask = func(c tele.Context) (string, [][]tele.InlineButton, error) {
+		return t.speciesSearchPrompt(c, "What species is your tarantula?")
+	}
+	species.input = t.handleSpeciesSearchInput
+
+	acquired := newField(FieldAcquisitionDate, "Acquired", "When did you acquire this tarantula? (YYYY-MM-DD)", parseFormDate, notInFuture, func(s *UserSession, date time.Time) {
+		s.TarantulaData.AcquisitionDate = date
+	})
+	acquired.def = "today"
+
+	age := newField(FieldAge, "Age (months)", "What's the estimated age in months?", parseWholeNumber, notNegative, func(s *UserSession, months int) {
+		s.TarantulaData.EstimatedAgeMonths = months
+	})
+	age.optional = true
+
+	health := newField(FieldHealthStatus, "Health", "How is their health?", parseWholeNumber, nil, func(s *UserSession, id int) {
+		s.TarantulaData.CurrentHealthStatusID = id
+	})
+	health.choices = staticChoices(healthStatusChoices())
+	health.choiceOnly = true
+	health.def = strconv.Itoa(int(models.HealthStatusHealthy))
+
+	notes := newField(FieldNotes, "Notes", "Any additional notes?", parseText, nil, func(s *UserSession, notes string) {
+		s.TarantulaData.Notes = notes
+	})
+	notes.optional = true
+
+	return &form{
+		intro: "Let's add a new tarantula!",
+		fields: []formField{
+			newField(FieldName, "Name", "What's their name?", parseText, nil, func(s *UserSession, name string) {
+				s.TarantulaData.Name = name
+			}),
+			species,
+			acquired,
+			age,
+			newField(FieldCurrentSize, "Size (cm)", "What's the current size in cm?", parseDecimal, lengthCM, func(s *UserSession, size float64) {
+				s.TarantulaData.CurrentSize = size
+			}),
+			health,
+			notes,
+		},
+		save: func(c tele.Context, s *UserSession) (string, error) {
+			s.TarantulaData.UserID = ownerID(c)
+			s.TarantulaData.CurrentMoltStageID = int(models.MoltStageNormal)
+			if err := t.db.AddTarantula(t.reqCtx(c), s.TarantulaData); err != nil {
+				return "", fmt.Errorf("failed to save tarantula: %w", err)
 			}
-			buttons = append(buttons, row)
-		}
-
-		markup.InlineKeyboard = buttons
-		return c.Send(msg, markup)
-
-	case FieldSpecies:
-		// This case is now handled by callback, but keep for backward compatibility
-		speciesID, err := strconv.Atoi(c.Text())
-		if err != nil {
-			return c.Send("Please use the buttons to select a species")
-		}
-		session.TarantulaData.SpeciesID = speciesID
-		session.CurrentField = FieldAcquisitionDate
-		err = c.Send("When did you acquire this tarantula? (YYYY-MM-DD)")
-		if err != nil {
-			return err
-		}
-
-	case FieldAcquisitionDate:
-		date, ok := t.parseDate(c)
-		if !ok {
-			return nil
-		}
-		session.TarantulaData.AcquisitionDate = date
-		session.CurrentField = FieldAge
-		err := c.Send("What's the estimated age in months?")
-		if err != nil {
-			return err
-		}
-
-	case FieldAge:
-		age, err := strconv.Atoi(c.Text())
-		if err != nil {
-			return c.Send("Please enter a valid number for age in months")
-		}
-		session.TarantulaData.EstimatedAgeMonths = age
-		session.CurrentField = FieldCurrentSize
-		err = c.Send("What's the current size in cm?")
-		if err != nil {
-			return err
-		}
-
-	case FieldCurrentSize:
-		size, err := strconv.ParseFloat(c.Text(), 64)
-		if err != nil {
-			return c.Send("Please enter a valid size in centimeters")
-		}
-		session.TarantulaData.CurrentMoltStageID = int(models.MoltStageNormal)
-		session.TarantulaData.CurrentSize = size
-		session.CurrentField = FieldHealthStatus
-		err = c.Send("What's the current health status ID?")
-		if err != nil {
-			return err
-		}
-
-	case FieldHealthStatus:
-		healthStatus, err := strconv.Atoi(c.Text())
-		if err != nil {
-			return c.Send("Please enter a valid health status ID")
-		}
-		session.TarantulaData.CurrentHealthStatusID = healthStatus
-		session.CurrentField = FieldNotes
-		err = c.Send("Any additional notes? (or type 'skip' to leave empty)")
-		if err != nil {
-			return err
-		}
-
-	case FieldNotes:
-		if c.Text() != "skip" {
-			session.TarantulaData.Notes = c.Text()
-		}
-		session.TarantulaData.UserID = c.Sender().ID
-		err := t.db.AddTarantula(context.Background(), session.TarantulaData)
-		if err != nil {
-			return fmt.Errorf("failed to save tarantula: %w", err)
-		}
-
-		session.reset()
-
-		err = sendSuccess(c, "Tarantula added!")
-		if err != nil {
-			return err
-		}
+			return "Tarantula added!", nil
+		},
 	}
-
-	t.sessions.UpdateSession(c.Sender().ID, session)
-	return nil
 }
 
-func (t *TarantulaBot) parseDate(c tele.Context) (time.Time, bool) {
-	date, err := time.Parse("2006-01-02", c.Text())
-	if err != nil {
-		_ = c.Send("Please enter the date in YYYY-MM-DD format")
+func healthStatusChoices() []formChoice {
+	var choices []formChoice
+	for _, status := range []models.HealthStatusEnum{models.HealthStatusHealthy, models.HealthStatusMonitor, models.HealthStatusCritical} {
+		choices = append(choices, formChoice{label: status.ToDBName(), value: strconv.Itoa(int(status))})
 	}
-	return date, err == nil
+	return choices
 }
 
-func (t *TarantulaBot) handleMoltFormInput(c tele.Context, session *UserSession) error {
-	var err error
+func staticChoices(choices []formChoice) func(tele.Context) ([]formChoice, error) {
+	return func(tele.Context) ([]formChoice, error) { return choices, nil }
+}
 
-	switch session.CurrentField {
-	case FieldPreMoltLengthCM:
-		session.MoltData.MoltDate = time.Now()
-		session.MoltData.PreMoltLengthCM, err = strconv.ParseFloat(c.Text(), 64)
-		if err != nil {
-			return c.Send("I'm sorry, I didn't understand that number. Please enter the length in centimeters.")
-		}
-		session.CurrentField = FieldPostMoltLengthCM
-		err = c.Send("How long is your tarantula now (in cm)?")
+func (t *TarantulaBot) moltForm() *form {
+	notes := newField(FieldMoltNotes, "Notes", "Do you have any notes or observations you'd like to add?", parseText, nil, func(s *UserSession, notes string) {
+		s.MoltData.Notes = notes
+	})
+	notes.optional = true
 
-	case FieldPostMoltLengthCM:
-		session.MoltData.PostMoltLengthCM, err = strconv.ParseFloat(c.Text(), 64)
-		if err != nil {
-			return c.Send("I'm sorry, I didn't understand that number. Please enter the length in centimeters.")
-		}
-		session.CurrentField = FieldMoltNotes
-		err = c.Send("Do you have any notes or observations you'd like to add?")
-
-	case FieldMoltNotes:
-		session.MoltData.Notes = c.Text()
-		session.MoltData.UserID = c.Sender().ID
-		session.CurrentField = FieldSuccess
-		err = c.Send("Was the molt successful? (true/false)")
-
-	case FieldSuccess:
-		success, err := strconv.ParseBool(c.Text())
-		if err != nil {
-			return c.Send("Please enter a valid boolean value (true/false)")
-		}
+	success := newField(FieldSuccess, "Successful", "Was the molt successful?", parseYesNo, nil, func(s *UserSession, success bool) {
+		s.MoltData.MoltStageID = int(models.MoltStageFailed)
 		if success {
-			session.MoltData.MoltStageID = int(models.MoltStagePostMolt)
-		} else {
-			session.MoltData.MoltStageID = int(models.MoltStageFailed)
-		}
-		err = t.db.RecordMolt(context.Background(), session.MoltData)
-		if err != nil {
-			_ = sendError(c, err.Error())
-			return nil
-		}
-		err = sendSuccess(c, "Molt recorded!")
-		session.reset()
+			s.MoltData.MoltStageID = int(models.MoltStagePostMolt)
+		}
+	})
+	success.choices = staticChoices([]formChoice{{label: "Yes", value: "yes"}, {label: "No", value: "no"}})
+	success.def = "yes"
+
+	return &form{
+		fields: []formField{
+			newField(FieldPreMoltLengthCM, "Length before (cm)", "How long was your tarantula before it molted (in cm)?", parseDecimal, lengthCM, func(s *UserSession, length float64) {
+				s.MoltData.PreMoltLengthCM = length
+			}),
+			newField(FieldPostMoltLengthCM, "Length after (cm)", "How long is your tarantula now (in cm)?", parseDecimal, lengthCM, func(s *UserSession, length float64) {
+				s.MoltData.PostMoltLengthCM = length
+			}),
+			notes,
+			success,
+		},
+		save: func(c tele.Context, s *UserSession) (string, error) {
+			s.MoltData.MoltDate = time.Now()
+			s.MoltData.UserID = ownerID(c)
+			recordedBy := c.Sender().ID
+			s.MoltData.RecordedBy = &recordedBy
+			if _, err := t.db.RecordMolt(t.reqCtx(c), s.MoltData); err != nil {
+				return "", fmt.Errorf("failed to record molt: %w", err)
+			}
+			return "Molt recorded!", nil
+		},
 	}
-	t.sessions.UpdateSession(c.Sender().ID, session)
-	return err
 }
 
-func (t *TarantulaBot) handleColonyFormInput(c tele.Context, session *UserSession) error {
-	var err error
-
-	switch session.CurrentField {
-	case FieldColonyName:
-		session.Colony.ColonyName = c.Text()
-		session.CurrentField = FieldColonyCount
-		err = c.Send("How many crickets are in the colony?")
-
-	case FieldColonyCount:
-		count, err := strconv.Atoi(c.Text())
-		if err != nil {
-			return c.Send("Please enter a valid number for the colony count")
-		}
-		session.Colony.CurrentCount = count
-		session.Colony.UserID = c.Sender().ID
-		session.Colony.Notes = "Initial colony setup"
-		session.Colony.LastCountDate = time.Now()
-		err = t.db.AddColony(context.Background(), session.Colony)
-		if err != nil {
-			return fmt.Errorf("failed to save colony: %w", err)
-		}
-
-		session.reset()
-		err = sendSuccess(c, "Colony added!")
+const mixedSizes = "mixed"
+
+func (t *TarantulaBot) feederColonyForm() *form {
+	feeder := newField(FieldColonyFeeder, "Feeder", "Which feeder insect does this colony hold?", parseWholeNumber, nil, func(s *UserSession, id int) {
+		s.Colony.FeederSpeciesID = id
+	})
+	feeder.choices = t.feederSpeciesChoices
+	feeder.choiceOnly = true
+
+	// Mixed colonies leave the size empty and can supply any size in the
+	// demand forecast
+	size := newField(FieldColonySize, "Size", "📏 Which size does the colony hold?", parsePreySize, nil, func(s *UserSession, size string) {
+		s.Colony.PreySize = size
+	})
+	var sizes []formChoice
+	for _, preySize := range models.PreySizes {
+		sizes = append(sizes, formChoice{label: preySize, value: preySize})
+	}
+	size.choices = staticChoices(append(sizes, formChoice{label: "🔀 Mixed sizes", value: mixedSizes}))
+	size.choiceOnly = true
+	size.def = mixedSizes
+
+	return &form{
+		fields: []formField{
+			newField(FieldColonyName, "Name", "What's the name of the new feeder colony?", parseText, nil, func(s *UserSession, name string) {
+				s.Colony.ColonyName = name
+			}),
+			feeder,
+			size,
+			newField(FieldColonyCount, "Count", "How many insects are in the colony?", parseWholeNumber, notNegative, func(s *UserSession, count int) {
+				s.Colony.CurrentCount = count
+			}),
+		},
+		save: func(c tele.Context, s *UserSession) (string, error) {
+			s.Colony.UserID = ownerID(c)
+			s.Colony.Notes = "Initial colony setup"
+			s.Colony.LastCountDate = time.Now()
+			if err := t.db.AddColony(t.reqCtx(c), s.Colony); err != nil {
+				return "", fmt.Errorf("failed to save colony: %w", err)
+			}
+			return "Feeder colony added!", nil
+		},
 	}
-
-	t.sessions.UpdateSession(c.Sender().ID, session)
-	return err
 }
 
-func (t *TarantulaBot) handleFeedingFormInput(c tele.Context, session *UserSession) error {
-	var err error
-
-	switch session.CurrentField {
-	case FieldColonyID:
-		colonyID, err := strconv.Atoi(c.Text())
-		if err != nil {
-			return c.Send("Please enter a valid colony ID")
-		}
-		session.FeedEvent.CricketColonyID = colonyID
-		session.CurrentField = FieldFeedingCount
-		err = c.Send("How many crickets did you feed?")
-	case FieldFeedingCount:
-		count, err := strconv.Atoi(c.Text())
-		if err != nil {
-			return c.Send("Please enter a valid number for the feeding count")
-		}
-		session.FeedEvent.NumberOfCrickets = count
-		session.FeedEvent.FeedingDate = time.Now()
-		session.FeedEvent.UserID = c.Sender().ID
-		session.FeedEvent.FeedingStatusID = int(models.FeedingStatusAccepted)
-
-		// Check if this is colony feeding or individual feeding
-		if session.SelectedColonyID > 0 {
-			colonyID := session.SelectedColonyID
-			session.FeedEvent.TarantulaColonyID = &colonyID
-			session.FeedEvent.TarantulaID = nil // Not individual feeding
-		}
-
-		_, err = t.db.RecordFeeding(context.Background(), session.FeedEvent)
-		if err != nil {
-			return fmt.Errorf("failed to save feeding event: %w", err)
-		}
-
-		// Check if it was colony feeding before reset
-		isColonyFeeding := session.FeedEvent.TarantulaColonyID != nil
-		session.reset()
-
-		if isColonyFeeding {
-			err = sendSuccess(c, "Colony feeding recorded!")
-		} else {
-			err = sendSuccess(c, "Feeding event recorded!")
-		}
+// parsePreySize reads a prey size, with mixed sizes as none
+func parsePreySize(text string) (string, error) {
+	if text == mixedSizes {
+		return "", nil
 	}
-	t.sessions.UpdateSession(c.Sender().ID, session)
-
-	return err
+	return parseChoice(text, models.PreySizes...)
 }
 
-func (t *TarantulaBot) handleCricketsFormInput(c tele.Context, session *UserSession) error {
-	var err error
-
-	switch session.CurrentField {
-	case FieldColonyCount:
-		count, err := strconv.Atoi(c.Text())
-		if err != nil {
-			return c.Send("Please enter a valid number for the cricket count")
-		}
-
-		colonies, err := t.db.GetColonyStatus(t.ctx, c.Sender().ID)
-		if err != nil {
-			return fmt.Errorf("failed to get colony: %w", err)
-		}
-
-		if len(colonies) == 0 {
+func (t *TarantulaBot) feederSpeciesChoices(c tele.Context) ([]formChoice, error) {
+	feeders, err := t.db.GetFeederSpecies(t.reqCtx(c))
+	if err != nil {
+		return nil, err
+	}
+	var choices []formChoice
+	for _, f := range feeders {
+		choices = append(choices, formChoice{label: f.Name, value: strconv.Itoa(f.ID)})
+	}
+	return choices, nil
+}
 
-			colony := models.CricketColony{
-				ColonyName:    "Cricket Colony",
-				CurrentCount:  count,
-				LastCountDate: time.Now(),
-				UserID:        c.Sender().ID,
-				Notes:         "Initial setup",
-			}
-			err = t.db.AddColony(context.Background(), colony)
+// cricketCountForm updates the first feeder colony, creating a cricket
+// colony when there's none yet
+func (t *TarantulaBot) cricketCountForm() *form {
+	return &form{
+		fields: []formField{
+			newField(FieldColonyCount, "Crickets", "🦗 Enter your current cricket count:", parseWholeNumber, notNegative, func(s *UserSession, n int) {
+				s.Colony.CurrentCount = n
+			}),
+		},
+		save: func(c tele.Context, s *UserSession) (string, error) {
+			count := s.Colony.CurrentCount
+			colonies, err := t.db.GetColonyStatus(t.reqCtx(c), ownerID(c))
 			if err != nil {
-				return fmt.Errorf("failed to create colony: %w", err)
+				return "", fmt.Errorf("failed to get colony: %w", err)
 			}
-		} else {
 
-			colonyID := colonies[0].ID
-			err = t.db.UpdateColonyCount(t.ctx, colonyID, int32(count), c.Sender().ID)
-			if err != nil {
-				return fmt.Errorf("failed to update colony count: %w", err)
+			if len(colonies) == 0 {
+				colony := models.CricketColony{
+					ColonyName:      "Cricket Colony",
+					FeederSpeciesID: models.DefaultFeederSpeciesID,
+					CurrentCount:    count,
+					LastCountDate:   time.Now(),
+					UserID:          ownerID(c),
+					Notes:           "Initial setup",
+				}
+				if err := t.db.AddColony(t.reqCtx(c), colony); err != nil {
+					return "", fmt.Errorf("failed to create colony: %w", err)
+				}
+			} else if err := t.db.UpdateColonyCount(t.reqCtx(c), colonies[0].ID, int32(count), ownerID(c)); err != nil {
+				return "", fmt.Errorf("failed to update colony count: %w", err)
 			}
-		}
-
-		session.reset()
-		err = sendSuccess(c, fmt.Sprintf("Cricket count updated to %d!", count))
+			return fmt.Sprintf("Cricket count updated to %d!", count), nil
+		},
 	}
-
-	return err
 }
 
-func (t *TarantulaBot) handleTarantulaColonyFormInput(c tele.Context, session *UserSession) error {
-	var err error
-
-	switch session.CurrentField {
-	case FieldColonyName:
-		session.TarantulaColony.ColonyName = c.Text()
-		session.CurrentField = FieldSpecies
-		t.sessions.UpdateSession(c.Sender().ID, session)
-
-		// Show species selection (communal species only)
-		// Get all species and filter for communal ones
-		species, err := t.db.GetAllSpecies(context.Background())
-		if err != nil {
-			return c.Send("Failed to load species list. Please try again.")
-		}
-
-		msg := "Great! Now select the species for this colony:"
-		markup := &tele.ReplyMarkup{}
-		var buttons [][]tele.InlineButton
-
-		// Show only communal species
-		for _, sp := range species {
-			if sp.IsCommunal {
-				btn := tele.InlineButton{
-					Text: sp.CommonName + " (" + sp.ScientificName + ")",
-					Data: fmt.Sprintf("colony_species:%d", sp.ID),
-				}
-				buttons = append(buttons, []tele.InlineButton{btn})
+func (t *TarantulaBot) tarantulaColonyForm() *form {
+	species := newField(FieldSpecies, "Species", "Great! Now select the species for this colony:", parseWholeNumber, nil, func(s *UserSession, id int) {
+		s.TarantulaColony.SpeciesID = id
+	})
+	species.choices = t.colonySpeciesChoices
+	species.choiceOnly = true
+
+	formed := newField(FieldFormationDate, "Formed", "When was this colony formed? (YYYY-MM-DD)", parseFormDate, notInFuture, func(s *UserSession, date time.Time) {
+		s.TarantulaColony.FormationDate = date
+	})
+	formed.def = "today"
+
+	return &form{
+		intro: "👥 Let's create a tarantula colony!",
+		fields: []formField{
+			newField(FieldColonyName, "Name", "What would you like to name this colony?\n(e.g., 'Balfouri Group', 'Main Colony')", parseText, nil, func(s *UserSession, name string) {
+				s.TarantulaColony.ColonyName = name
+			}),
+			species,
+			formed,
+		},
+		save: func(c tele.Context, s *UserSession) (string, error) {
+			s.TarantulaColony.UserID = ownerID(c)
+			if _, err := t.db.CreateColony(t.reqCtx(c), s.TarantulaColony); err != nil {
+				return "", fmt.Errorf("failed to create colony: %w", err)
 			}
-		}
-
-		// If no communal species found, show all species as fallback
-		if len(buttons) == 0 {
-			for _, sp := range species {
-				btn := tele.InlineButton{
-					Text: sp.CommonName,
-					Data: fmt.Sprintf("colony_species:%d", sp.ID),
-				}
-				buttons = append(buttons, []tele.InlineButton{btn})
-			}
-		}
+			return fmt.Sprintf("Colony '%s' created successfully! You can now add tarantulas to it.", s.TarantulaColony.ColonyName), nil
+		},
+	}
+}
 
-		markup.InlineKeyboard = buttons
-		return c.Send(msg, markup)
+// colonySpeciesChoices offers the communal species, or every species when
+// none are marked communal
+func (t *TarantulaBot) colonySpeciesChoices(c tele.Context) ([]formChoice, error) {
+	species, err := t.db.GetAvailableSpecies(t.reqCtx(c), ownerID(c))
+	if err != nil {
+		return nil, err
+	}
 
-	case FieldFormationDate:
-		date, ok := t.parseDate(c)
-		if !ok {
-			return nil
+	var choices []formChoice
+	for _, sp := range species {
+		if sp.IsCommunal {
+			choices = append(choices, formChoice{label: speciesLabel(sp), value: strconv.Itoa(sp.ID)})
 		}
-		session.TarantulaColony.FormationDate = date
-		session.TarantulaColony.UserID = c.Sender().ID
-
-		// Create the colony
-		_, err = t.db.CreateColony(context.Background(), session.TarantulaColony)
-		if err != nil {
-			session.reset()
-			return SendError(c, fmt.Sprintf("Failed to create colony: %v", err))
+	}
+	if len(choices) == 0 {
+		for _, sp := range species {
+			choices = append(choices, formChoice{label: speciesLabel(sp), value: strconv.Itoa(sp.ID)})
 		}
-
-		// Save colony name before reset
-		colonyName := session.TarantulaColony.ColonyName
-		session.reset()
-		return sendSuccess(c, fmt.Sprintf("Colony '%s' created successfully! You can now add tarantulas to it.", colonyName))
 	}
-
-	t.sessions.UpdateSession(c.Sender().ID, session)
-	return err
+	return choices, nil
 }
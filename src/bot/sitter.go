@@ -0,0 +1,154 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"tarantulago/models"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// Pet-sitter handover: the owner invites a sitter for a set number of days,
+// leaves them a printable care summary, and gets a digest of what they logged
+// once the access runs out.
+
+// sitterStints are the access lengths offered when inviting a sitter, in days
+var sitterStints = []int{3, 7, 14, 21}
+
+func (t *TarantulaBot) handleSitterStintChoice(c tele.Context) error {
+	if ownerID(c) != c.Sender().ID {
+		return SendError(c, "Only the collection owner can invite people")
+	}
+
+	var row []tele.InlineButton
+	for _, days := range sitterStints {
+		row = append(row, shareSitterDaysAction.Button(fmt.Sprintf("%d days", days), days))
+	}
+	return c.Send("🧳 How long will the sitter look after your collection? Their access ends automatically.",
+		&tele.ReplyMarkup{InlineKeyboard: [][]tele.InlineButton{row}})
+}
+
+func (t *TarantulaBot) handleSitterInvite(c tele.Context, days int) error {
+	if ownerID(c) != c.Sender().ID {
+		return SendError(c, "Only the collection owner can invite people")
+	}
+
+	invite, err := t.db.CreateSitterInvite(t.reqCtx(c), c.Sender().ID, time.Now().AddDate(0, 0, days))
+	if err != nil {
+		return fmt.Errorf("failed to create invite: %w", err)
+	}
+
+	link := fmt.Sprintf("https://t.me/%s?start=%s%s", t.bot.Me.Username, joinPayloadPrefix, invite.Code)
+	return c.Send(fmt.Sprintf("📨 Send this link to your sitter. They can record feedings, watering and observations "+
+		"until %s, and reminders go to them meanwhile. You'll get a digest of everything they logged.\n\n%s",
+		invite.AccessUntil.Format("Mon Jan 2 15:04"), link))
+}
+
+// handleCareSummary sends the collection's care summary as a text file ready to print
+func (t *TarantulaBot) handleCareSummary(c tele.Context) error {
+	access := collectionAccess(c)
+	if !access.CanEdit() && access.Role != models.CollectionRoleSitter {
+		return SendError(c, "The care summary is for keepers and sitters")
+	}
+
+	items, err := t.db.GetCareSummary(t.reqCtx(c), access.OwnerID)
+	if err != nil {
+		return fmt.Errorf("failed to build care summary: %w", err)
+	}
+	if len(items) == 0 {
+		return SendInfo(c, "There are no tarantulas in this collection yet.")
+	}
+
+	ownerName := access.OwnerName
+	if ownerName == "" {
+		ownerName = c.Sender().FirstName
+	}
+	summary := FormatCareSummary(items, ownerName, time.Now())
+
+	return c.Send(&tele.Document{
+		File:     tele.FromReader(strings.NewReader(summary)),
+		FileName: "care-summary.txt",
+		MIME:     "text/plain",
+		Caption:  "🖨 Care summary: print it and leave it by the enclosures.",
+	})
+}
+
+func (t *TarantulaBot) handleCareLogWater(c tele.Context, tarantulaID int) error {
+	recordedBy := c.Sender().ID
+	err := t.db.RecordCareLog(t.reqCtx(c), models.CareLog{
+		TarantulaID: tarantulaID,
+		Kind:        models.CareLogWater,
+		LoggedAt:    time.Now(),
+		UserID:      ownerID(c),
+		RecordedBy:  &recordedBy,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to log watering: %w", err)
+	}
+	return c.Respond(&tele.CallbackResponse{Text: "💧 Watering logged"})
+}
+
+func (t *TarantulaBot) handleCareNoteStart(c tele.Context, tarantulaID int) error {
+	session := t.sessions.GetSession(sessionKey(c))
+	session.reset()
+	session.CurrentState = StateCareNote
+	session.SelectedTarantulaID = tarantulaID
+	t.sessions.UpdateSession(sessionKey(c), session)
+
+	return c.Send("📝 What did you notice? (behaviour, webbing, leftover prey, anything unusual)")
+}
+
+func (t *TarantulaBot) handleCareNoteInput(c tele.Context, session *UserSession) error {
+	note := strings.TrimSpace(c.Text())
+	if note == "" {
+		return c.Send("Please describe what you noticed.")
+	}
+
+	recordedBy := c.Sender().ID
+	err := t.db.RecordCareLog(t.reqCtx(c), models.CareLog{
+		TarantulaID: session.SelectedTarantulaID,
+		Kind:        models.CareLogObservation,
+		Notes:       note,
+		LoggedAt:    time.Now(),
+		UserID:      ownerID(c),
+		RecordedBy:  &recordedBy,
+	})
+	session.reset()
+	t.sessions.UpdateSession(sessionKey(c), session)
+	if err != nil {
+		return fmt.Errorf("failed to save observation: %w", err)
+	}
+
+	return sendSuccess(c, "Observation saved!")
+}
+
+// sendSitterDigests tells owners what their sitter logged once the sitter's
+// access has run out
+func (n *NotificationSystem) sendSitterDigests(ctx context.Context) {
+	now := time.Now()
+	sitters, err := n.db.GetExpiredSitters(ctx, now)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error checking expired sitters", "error", err)
+		return
+	}
+
+	for _, sitter := range sitters {
+		digest, err := n.db.GetSitterDigest(ctx, sitter)
+		if err != nil {
+			slog.ErrorContext(ctx, "Error building sitter digest", "user_id", sitter.OwnerID, "sitter_id", sitter.MemberID, "error", err)
+			continue
+		}
+
+		n.send(ctx, sitter.OwnerID, []int64{sitter.Owner.ChatID}, FormatSitterDigest(*digest), "sitter digest")
+		n.send(ctx, sitter.MemberID, []int64{sitter.Member.ChatID},
+			fmt.Sprintf("🧳 Your sitting for %s has ended, thank you! Your access to the collection is closed.", sitter.Owner.DisplayName()),
+			"sitter farewell")
+
+		if err := n.db.MarkSitterDigestSent(ctx, sitter.ID, now); err != nil {
+			slog.ErrorContext(ctx, "Error marking sitter digest sent", "sitter_id", sitter.MemberID, "error", err)
+		}
+	}
+}
@@ -0,0 +1,697 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"tarantulago/models"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// speciesFieldPrompts lists the editable care parameters in form order
+var speciesFieldPrompts = []struct {
+	field  TarantulaFormField
+	label  string
+	prompt string
+}{
+	{FieldCommonName, "Common name", "What's the common name? (or 'skip')"},
+	{FieldSynonyms, "Other names", "Any other scientific names it's known by, comma separated? (or 'skip')"},
+	{FieldAdultSize, "Adult size", "What's the adult body length in cm? This is used to generate feeding schedules."},
+	{FieldTemperament, "Temperament", "How would you describe its temperament (e.g. Docile, Defensive, Fast)? (or 'skip')"},
+	{FieldHumidity, "Humidity", "What humidity does it need, in percent? (or 'skip')"},
+	{FieldTemperature, "Temperature", "What temperature does it need, in °C? (or 'skip')"},
+	{FieldCommunal, "Communal", "Can it be kept communally? (yes/no)"},
+	{FieldHabitat, "Habitat", "Is it terrestrial, arboreal or fossorial? (or 'skip')"},
+	{FieldUrticating, "Urticating hairs", "Does it have urticating hairs? (yes/no)"},
+	{FieldVenom, "Venom", "How potent is its venom: mild, moderate or strong? (or 'skip')"},
+	{FieldGrowthRate, "Growth rate", "Is it slow, medium or fast growing? (or 'skip')"},
+	{FieldOrigin, "Origin", "Where does it come from? (or 'skip')"},
+}
+
+func speciesFieldPrompt(field TarantulaFormField) string {
+	for _, p := range speciesFieldPrompts {
+		if p.field == field {
+			return p.prompt
+		}
+	}
+	return ""
+}
+
+// applySpeciesField parses user input for a single species care parameter.
+// Errors are meant to be shown to the user as-is.
+func applySpeciesField(species *models.TarantulaSpecies, field TarantulaFormField, text string) error {
+	text = strings.TrimSpace(text)
+	skip := strings.EqualFold(text, "skip")
+
+	switch field {
+	case FieldScientificName:
+		if len(text) < 3 || skip {
+			return errors.New("Please enter the scientific name, e.g. Tliltocatl albopilosus")
+		}
+		species.ScientificName = text
+
+	case FieldCommonName:
+		if !skip {
+			species.CommonName = text
+		}
+
+	case FieldSynonyms:
+		if !skip {
+			species.Synonyms = text
+		}
+
+	case FieldAdultSize:
+		size, err := strconv.ParseFloat(strings.Replace(text, ",", ".", 1), 64)
+		if err != nil || size <= 0 || size > 40 {
+			return errors.New("Please enter the adult size in centimeters")
+		}
+		species.AdultSizeCM = size
+
+	case FieldTemperament:
+		if !skip {
+			species.Temperament = text
+		}
+
+	case FieldHumidity:
+		if skip {
+			return nil
+		}
+		humidity, err := strconv.Atoi(strings.TrimSuffix(text, "%"))
+		if err != nil || humidity < 0 || humidity > 100 {
+			return errors.New("Please enter humidity as a percentage between 0 and 100")
+		}
+		species.HumidityRequirementPercent = humidity
+
+	case FieldTemperature:
+		if skip {
+			return nil
+		}
+		temp, err := strconv.ParseFloat(strings.Replace(text, ",", ".", 1), 64)
+		if err != nil || temp < 5 || temp > 45 {
+			return errors.New("Please enter a temperature in °C")
+		}
+		species.TemperatureRequirementCelsius = temp
+
+	case FieldCommunal:
+		communal, err := parseYesNo(text)
+		if err != nil {
+			return err
+		}
+		species.IsCommunal = communal
+
+	case FieldHabitat:
+		if skip {
+			return nil
+		}
+		habitat, err := parseChoice(text, models.HabitatTerrestrial, models.HabitatArboreal, models.HabitatFossorial)
+		if err != nil {
+			return err
+		}
+		species.HabitatType = habitat
+
+	case FieldUrticating:
+		urticating, err := parseYesNo(text)
+		if err != nil {
+			return err
+		}
+		species.UrticatingHairs = urticating
+
+	case FieldVenom:
+		if skip {
+			return nil
+		}
+		venom, err := parseChoice(text, models.VenomMild, models.VenomModerate, models.VenomStrong)
+		if err != nil {
+			return err
+		}
+		species.VenomPotency = venom
+
+	case FieldGrowthRate:
+		if skip {
+			return nil
+		}
+		rate, err := parseChoice(text, models.GrowthSlow, models.GrowthMedium, models.GrowthFast)
+		if err != nil {
+			return err
+		}
+		species.GrowthRate = rate
+
+	case FieldOrigin:
+		if !skip {
+			species.Origin = text
+		}
+
+	default:
+		return errors.New("Unknown species field")
+	}
+	return nil
+}
+
+func parseYesNo(text string) (bool, error) {
+	switch strings.ToLower(text) {
+	case "yes", "y", "true":
+		return true, nil
+	case "no", "n", "false", "skip":
+		return false, nil
+	default:
+		return false, errors.New("Please answer yes or no")
+	}
+}
+
+// parseChoice matches text case-insensitively against the allowed values
+func parseChoice(text string, choices ...string) (string, error) {
+	for _, choice := range choices {
+		if strings.EqualFold(text, choice) {
+			return choice, nil
+		}
+	}
+	return "", fmt.Errorf("Please answer one of: %s", strings.ToLower(strings.Join(choices, ", ")))
+}
+
+// canEditSpecies allows admins to edit any species and the collection's
+// owner and editors to edit its species until they join the shared catalogue
+func (t *TarantulaBot) canEditSpecies(c tele.Context, species *models.TarantulaSpecies) bool {
+	if t.isAdmin(c.Sender().ID) {
+		return true
+	}
+	return collectionAccess(c).CanEdit() &&
+		species.OwnerUserID != nil && *species.OwnerUserID == ownerID(c) &&
+		species.Status != models.SpeciesStatusApproved
+}
+
+func (t *TarantulaBot) handleAddSpecies(c tele.Context) error {
+	session := t.sessions.GetSession(sessionKey(c))
+	// Keep any tarantula in progress so the form can continue with the new species
+	if session.CurrentState != StateAddingTarantula {
+		session.reset()
+	}
+	session.CurrentState = StateAddingSpecies
+	session.CurrentField = FieldScientificName
+	session.SpeciesData = models.TarantulaSpecies{}
+	t.sessions.UpdateSession(sessionKey(c), session)
+
+	return c.Send("🧬 Let's add a new species. What's its scientific name?")
+}
+
+func (t *TarantulaBot) handleSpeciesFormInput(c tele.Context, session *UserSession) error {
+	if err := applySpeciesField(&session.SpeciesData, session.CurrentField, c.Text()); err != nil {
+		return c.Send(err.Error())
+	}
+
+	next := FieldSpeciesSharing
+	if session.CurrentField == FieldScientificName {
+		next = speciesFieldPrompts[0].field
+	} else {
+		for i, p := range speciesFieldPrompts {
+			if p.field == session.CurrentField && i+1 < len(speciesFieldPrompts) {
+				next = speciesFieldPrompts[i+1].field
+			}
+		}
+	}
+	session.CurrentField = next
+	t.sessions.UpdateSession(sessionKey(c), session)
+
+	if next != FieldSpeciesSharing {
+		return c.Send(speciesFieldPrompt(next))
+	}
+
+	markup := &tele.ReplyMarkup{
+		InlineKeyboard: [][]tele.InlineButton{
+			{speciesShareAction.Button("🔒 Keep private", false)},
+			{speciesShareAction.Button("🌍 Propose for shared catalogue", true)},
+		},
+	}
+	return c.Send("Should this species stay private, or be proposed for everyone? Shared species are reviewed by an admin first.", markup)
+}
+
+func (t *TarantulaBot) handleSpeciesSharingSelected(c tele.Context, propose bool) error {
+	session := t.sessions.GetSession(sessionKey(c))
+	if session.CurrentState != StateAddingSpecies || session.CurrentField != FieldSpeciesSharing {
+		return SendError(c, "Invalid session state. Please start over.")
+	}
+
+	userID := ownerID(c)
+	species := session.SpeciesData
+	species.OwnerUserID = &userID
+	species.Status = models.SpeciesStatusPrivate
+	if propose {
+		species.Status = models.SpeciesStatusPending
+	}
+
+	id, err := t.db.AddUserSpecies(t.reqCtx(c), species)
+	if err != nil {
+		return fmt.Errorf("failed to add species: %w", err)
+	}
+	species.ID = int(id)
+
+	if propose {
+		t.notifyAdminsOfProposal(species, c.Sender())
+	}
+
+	msg := fmt.Sprintf("Species %s added with default feeding schedules.", species.ScientificName)
+	if propose {
+		msg += " It's available to you now and will be shared once an admin approves it."
+	}
+
+	// Continue adding the tarantula that prompted the new species
+	if len(session.FormAnswers) > 0 {
+		session.CurrentState = StateAddingTarantula
+		session.CurrentField = FieldSpecies
+		session.SpeciesData = models.TarantulaSpecies{}
+
+		if err := sendSuccess(c, msg); err != nil {
+			return err
+		}
+		return t.answerForm(c, session, formAnswer{value: species.ID, display: speciesLabel(species)})
+	}
+
+	session.reset()
+	t.sessions.UpdateSession(sessionKey(c), session)
+	return sendSuccess(c, msg)
+}
+
+func (t *TarantulaBot) notifyAdminsOfProposal(species models.TarantulaSpecies, sender *tele.User) {
+	msg := fmt.Sprintf("🧬 New species proposed by %s:\n\n%s", sender.FirstName, FormatSpecies(species))
+	markup := buildSpeciesReviewMarkup(species.ID)
+
+	for adminID := range t.admins {
+		if _, err := t.bot.Send(&tele.User{ID: adminID}, msg, markup); err != nil {
+			slog.Error("Failed to notify admin of species proposal", "admin_id", adminID, "error", err)
+		}
+	}
+}
+
+func buildSpeciesReviewMarkup(speciesID int) *tele.ReplyMarkup {
+	return &tele.ReplyMarkup{
+		InlineKeyboard: [][]tele.InlineButton{{
+			speciesReviewAction.Button("✅ Approve", int32(speciesID), true),
+			speciesReviewAction.Button("❌ Reject", int32(speciesID), false),
+		}},
+	}
+}
+
+func (t *TarantulaBot) handleMySpecies(c tele.Context) error {
+	species, err := t.db.GetUserSpecies(t.reqCtx(c), ownerID(c))
+	if err != nil {
+		return fmt.Errorf("failed to get species: %w", err)
+	}
+
+	markup := &tele.ReplyMarkup{}
+	var buttons [][]tele.InlineButton
+
+	var msg strings.Builder
+	msg.WriteString("🧬 *Your Species*\n\n")
+	if len(species) == 0 {
+		msg.WriteString("You haven't added any species yet. Add one if yours is missing from the catalogue.\n")
+	}
+
+	for _, sp := range species {
+		msg.WriteString(FormatSpecies(sp) + "\n")
+
+		if t.canEditSpecies(c, &sp) {
+			row := []tele.InlineButton{speciesEditAction.Button("✏️ "+sp.ScientificName, int32(sp.ID))}
+			if sp.Status == models.SpeciesStatusPrivate {
+				row = append(row, speciesProposeAction.Button("🌍 Propose", int32(sp.ID)))
+			}
+			buttons = append(buttons, row)
+		}
+	}
+
+	buttons = append(buttons, []tele.InlineButton{addSpeciesAction.Button("➕ Add Species")})
+	markup.InlineKeyboard = buttons
+
+	return c.Send(msg.String(), markup, tele.ModeMarkdown)
+}
+
+func (t *TarantulaBot) handleEditSpecies(c tele.Context, speciesID int32) error {
+	species, err := t.db.GetSpeciesByID(t.reqCtx(c), speciesID)
+	if err != nil {
+		return fmt.Errorf("failed to get species: %w", err)
+	}
+	if !t.canEditSpecies(c, species) {
+		return SendError(c, "You can't edit this species.")
+	}
+
+	markup := &tele.ReplyMarkup{}
+	var buttons [][]tele.InlineButton
+	for i := 0; i < len(speciesFieldPrompts); i += 2 {
+		var row []tele.InlineButton
+		for _, p := range speciesFieldPrompts[i:min(i+2, len(speciesFieldPrompts))] {
+			row = append(row, speciesFieldAction.Button(p.label, int32(species.ID), p.field))
+		}
+		buttons = append(buttons, row)
+	}
+	markup.InlineKeyboard = buttons
+
+	return c.Send(FormatSpecies(*species)+"\nWhich care parameter do you want to change?", markup, tele.ModeMarkdown)
+}
+
+func (t *TarantulaBot) handleEditSpeciesField(c tele.Context, speciesID int32, field TarantulaFormField) error {
+	prompt := speciesFieldPrompt(field)
+	if prompt == "" {
+		return SendError(c, "Unknown species field")
+	}
+
+	session := t.sessions.GetSession(sessionKey(c))
+	session.reset()
+	session.CurrentState = StateEditingSpecies
+	session.CurrentField = field
+	session.SelectedSpeciesID = int(speciesID)
+	t.sessions.UpdateSession(sessionKey(c), session)
+
+	return c.Send(prompt)
+}
+
+func (t *TarantulaBot) handleEditSpeciesInput(c tele.Context, session *UserSession) error {
+	species, err := t.db.GetSpeciesByID(t.reqCtx(c), int32(session.SelectedSpeciesID))
+	if err != nil {
+		return fmt.Errorf("failed to get species: %w", err)
+	}
+	if !t.canEditSpecies(c, species) {
+		session.reset()
+		t.sessions.UpdateSession(sessionKey(c), session)
+		return SendError(c, "You can't edit this species.")
+	}
+
+	if err := applySpeciesField(species, session.CurrentField, c.Text()); err != nil {
+		return c.Send(err.Error())
+	}
+
+	if err := t.db.UpdateSpecies(t.reqCtx(c), *species); err != nil {
+		return fmt.Errorf("failed to update species: %w", err)
+	}
+
+	session.reset()
+	t.sessions.UpdateSession(sessionKey(c), session)
+	return sendSuccess(c, fmt.Sprintf("%s updated!", species.ScientificName))
+}
+
+func (t *TarantulaBot) handleProposeSpecies(c tele.Context, speciesID int32) error {
+	if err := t.db.ProposeSpecies(t.reqCtx(c), speciesID, ownerID(c)); err != nil {
+		return fmt.Errorf("failed to propose species: %w", err)
+	}
+
+	species, err := t.db.GetSpeciesByID(t.reqCtx(c), speciesID)
+	if err == nil {
+		t.notifyAdminsOfProposal(*species, c.Sender())
+	}
+
+	return sendSuccess(c, "Species proposed for the shared catalogue. An admin will review it.")
+}
+
+func (t *TarantulaBot) handlePendingSpecies(c tele.Context) error {
+	if !t.isAdmin(c.Sender().ID) {
+		return SendError(c, "This command is only available to admins.")
+	}
+
+	pending, err := t.db.GetPendingSpecies(t.reqCtx(c))
+	if err != nil {
+		return fmt.Errorf("failed to get pending species: %w", err)
+	}
+	if len(pending) == 0 {
+		return SendInfo(c, "No species waiting for review.")
+	}
+
+	for _, sp := range pending {
+		if err := c.Send(FormatSpecies(sp), buildSpeciesReviewMarkup(sp.ID), tele.ModeMarkdown); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *TarantulaBot) handleReviewSpecies(c tele.Context, speciesID int32, approve bool) error {
+	if !t.isAdmin(c.Sender().ID) {
+		return SendError(c, "Only admins can review species.")
+	}
+
+	species, err := t.db.ReviewSpecies(t.reqCtx(c), speciesID, approve)
+	if err != nil {
+		return fmt.Errorf("failed to review species: %w", err)
+	}
+
+	outcome := "approved and added to the shared catalogue"
+	if !approve {
+		outcome = "not accepted for the shared catalogue; it stays available in your private list"
+	}
+
+	if species.OwnerUserID != nil {
+		msg := fmt.Sprintf("🧬 Your species %s was %s.", species.ScientificName, outcome)
+		if _, err := t.bot.Send(&tele.User{ID: *species.OwnerUserID}, msg); err != nil {
+			slog.ErrorContext(t.reqCtx(c), "Failed to notify species owner", "user_id", *species.OwnerUserID, "error", err)
+		}
+	}
+
+	if approve {
+		return sendSuccess(c, fmt.Sprintf("%s approved.", species.ScientificName))
+	}
+	return sendSuccess(c, fmt.Sprintf("%s rejected.", species.ScientificName))
+}
+
+const recentSpeciesLimit = 6
+
+// speciesSearchPrompt asks for a species name, offering the user's recently
+// used species as shortcuts
+func (t *TarantulaBot) speciesSearchPrompt(c tele.Context, intro string) (string, [][]tele.InlineButton, error) {
+	species, err := t.db.GetAvailableSpecies(t.reqCtx(c), ownerID(c))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to load species: %w", err)
+	}
+	recent, err := t.db.GetRecentSpeciesIDs(t.reqCtx(c), ownerID(c), recentSpeciesLimit)
+	if err != nil {
+		recent = nil
+	}
+
+	var msg strings.Builder
+	msg.WriteString(intro)
+	msg.WriteString("\n\nType part of its scientific, common or former name to search (e.g. 'hamorii' or 'red knee').")
+	if t.bot.Me != nil && t.bot.Me.Username != "" {
+		msg.WriteString(fmt.Sprintf(" You can also type @%s followed by a name in any chat.", t.bot.Me.Username))
+	}
+
+	var buttons [][]tele.InlineButton
+
+	byID := make(map[int]models.TarantulaSpecies, len(species))
+	for _, sp := range species {
+		byID[sp.ID] = sp
+	}
+	for _, id := range recent {
+		if sp, ok := byID[int(id)]; ok {
+			buttons = append(buttons, []tele.InlineButton{addTarantulaSpeciesAction.Button("🕘 "+speciesLabel(sp), sp.ID)})
+		}
+	}
+
+	buttons = append(buttons, []tele.InlineButton{
+		speciesBrowseAction.Button("📚 Browse all"),
+		addSpeciesAction.Button("➕ Not listed"),
+	})
+	return msg.String(), buttons, nil
+}
+
+// handleSpeciesSearchInput searches for the typed name, selecting the species
+// straight away when the name matches exactly
+func (t *TarantulaBot) handleSpeciesSearchInput(c tele.Context, session *UserSession) error {
+	species, err := t.db.GetAvailableSpecies(t.reqCtx(c), ownerID(c))
+	if err != nil {
+		return c.Send("Failed to load species list. Please try again.")
+	}
+	recent, _ := t.db.GetRecentSpeciesIDs(t.reqCtx(c), ownerID(c), recentSpeciesLimit)
+
+	matches := SearchSpecies(species, c.Text(), recent)
+	if best, ok := bestSpeciesMatch(matches); ok {
+		return t.handleTarantulaSpeciesSelected(c, best.Species.ID)
+	}
+
+	session.SpeciesQuery = c.Text()
+	t.sessions.UpdateSession(sessionKey(c), session)
+
+	return t.sendSpeciesPage(c, matches, 0, false)
+}
+
+// handleSpeciesPage shows a page of the last search results, or of every
+// species when browsing
+func (t *TarantulaBot) handleSpeciesPage(c tele.Context, page int, browse bool) error {
+	session := t.sessions.GetSession(sessionKey(c))
+	if session.CurrentState != StateAddingTarantula || session.CurrentField != FieldSpecies {
+		return SendError(c, "Invalid session state. Please start over.")
+	}
+	if browse {
+		session.SpeciesQuery = ""
+		t.sessions.UpdateSession(sessionKey(c), session)
+	}
+
+	species, err := t.db.GetAvailableSpecies(t.reqCtx(c), ownerID(c))
+	if err != nil {
+		return c.Send("Failed to load species list. Please try again.")
+	}
+	recent, _ := t.db.GetRecentSpeciesIDs(t.reqCtx(c), ownerID(c), recentSpeciesLimit)
+
+	return t.sendSpeciesPage(c, SearchSpecies(species, session.SpeciesQuery, recent), page, !browse)
+}
+
+func (t *TarantulaBot) sendSpeciesPage(c tele.Context, matches []SpeciesMatch, page int, edit bool) error {
+	if len(matches) == 0 {
+		markup := &tele.ReplyMarkup{InlineKeyboard: [][]tele.InlineButton{
+			{addSpeciesAction.Button("➕ Add a new species")},
+		}}
+		return c.Send("No species matched. Try another name, or add it as a new species.", markup)
+	}
+
+	pages := (len(matches) + speciesPageSize - 1) / speciesPageSize
+	page = max(0, min(page, pages-1))
+
+	markup := &tele.ReplyMarkup{}
+	var buttons [][]tele.InlineButton
+	for _, m := range matches[page*speciesPageSize : min((page+1)*speciesPageSize, len(matches))] {
+		buttons = append(buttons, []tele.InlineButton{addTarantulaSpeciesAction.Button(speciesLabel(m.Species), m.Species.ID)})
+	}
+
+	var nav []tele.InlineButton
+	if page > 0 {
+		nav = append(nav, speciesPageAction.Button("◀️ Prev", page-1))
+	}
+	if page < pages-1 {
+		nav = append(nav, speciesPageAction.Button("Next ▶️", page+1))
+	}
+	if len(nav) > 0 {
+		buttons = append(buttons, nav)
+	}
+	buttons = append(buttons, []tele.InlineButton{addSpeciesAction.Button("➕ Not listed")})
+	markup.InlineKeyboard = buttons
+
+	msg := fmt.Sprintf("Found %d species (page %d of %d). Pick one or type another name:", len(matches), page+1, pages)
+	if edit {
+		return t.sendOrEdit(c, msg, markup)
+	}
+	return c.Send(msg, markup)
+}
+
+const inlineSpeciesResults = 20
+
+// handleSpeciesInlineQuery suggests species for "@bot <name>" queries. The
+// chosen result sends the scientific name, which the species step of the
+// add tarantula form selects directly.
+func (t *TarantulaBot) handleSpeciesInlineQuery(c tele.Context) error {
+	query := c.Query()
+
+	species, err := t.db.GetAvailableSpecies(t.reqCtx(c), ownerID(c))
+	if err != nil {
+		return err
+	}
+	recent, _ := t.db.GetRecentSpeciesIDs(t.reqCtx(c), ownerID(c), recentSpeciesLimit)
+
+	offset, _ := strconv.Atoi(query.Offset)
+	matches := SearchSpecies(species, query.Text, recent)
+	if offset > len(matches) {
+		offset = len(matches)
+	}
+	end := min(offset+inlineSpeciesResults, len(matches))
+
+	results := make(tele.Results, 0, end-offset)
+	for _, m := range matches[offset:end] {
+		description := m.Species.CommonName
+		if m.Species.Synonyms != "" {
+			description += " · " + m.Species.Synonyms
+		}
+		result := &tele.ArticleResult{
+			Title:       m.Species.ScientificName,
+			Description: description,
+			Text:        m.Species.ScientificName,
+		}
+		result.SetResultID(strconv.Itoa(m.Species.ID))
+		results = append(results, result)
+	}
+
+	nextOffset := ""
+	if end < len(matches) {
+		nextOffset = strconv.Itoa(end)
+	}
+
+	return c.Answer(&tele.QueryResponse{
+		Results:    results,
+		CacheTime:  60,
+		IsPersonal: true,
+		NextOffset: nextOffset,
+	})
+}
+
+// speciesVisibleTo reports whether a species is in the user's catalogue
+func speciesVisibleTo(species *models.TarantulaSpecies, userID int64) bool {
+	return species.Status == models.SpeciesStatusApproved ||
+		(species.OwnerUserID != nil && *species.OwnerUserID == userID)
+}
+
+func (t *TarantulaBot) sendCareSheet(c tele.Context, speciesID int32) error {
+	species, err := t.db.GetSpeciesByID(t.reqCtx(c), speciesID)
+	if err != nil || !speciesVisibleTo(species, ownerID(c)) {
+		return SendError(c, "Species not found")
+	}
+
+	schedules, err := t.db.GetFeedingSchedules(t.reqCtx(c), speciesID)
+	if err != nil {
+		return fmt.Errorf("failed to get feeding schedules: %w", err)
+	}
+
+	return c.Send(FormatCareSheet(*species, schedules), tele.ModeHTML)
+}
+
+// handleTarantulaCareSheet shows the care sheet for a tarantula's species
+func (t *TarantulaBot) handleTarantulaCareSheet(c tele.Context, tarantulaID int32) error {
+	tarantula, err := t.db.GetTarantulaByID(t.reqCtx(c), ownerID(c), tarantulaID)
+	if err != nil {
+		return fmt.Errorf("failed to get tarantula: %w", err)
+	}
+	return t.sendCareSheet(c, int32(tarantula.SpeciesID))
+}
+
+// handleCareSheetCommand opens the species browser, or searches it when a
+// name is given, e.g. /caresheet hamorii
+func (t *TarantulaBot) handleCareSheetCommand(c tele.Context) error {
+	return t.sendCareSheetBrowser(c, strings.TrimSpace(c.Message().Payload), 0)
+}
+
+func (t *TarantulaBot) sendCareSheetBrowser(c tele.Context, query string, page int) error {
+	species, err := t.db.GetAvailableSpecies(t.reqCtx(c), ownerID(c))
+	if err != nil {
+		return fmt.Errorf("failed to load species: %w", err)
+	}
+	recent, _ := t.db.GetRecentSpeciesIDs(t.reqCtx(c), ownerID(c), recentSpeciesLimit)
+
+	matches := SearchSpecies(species, query, recent)
+	if best, ok := bestSpeciesMatch(matches); ok && query != "" {
+		return t.sendCareSheet(c, int32(best.Species.ID))
+	}
+	if len(matches) == 0 {
+		return SendInfo(c, "No species matched. Try /caresheet followed by another name.")
+	}
+
+	pages := (len(matches) + speciesPageSize - 1) / speciesPageSize
+	page = max(0, min(page, pages-1))
+
+	markup := &tele.ReplyMarkup{}
+	var buttons [][]tele.InlineButton
+	for _, m := range matches[page*speciesPageSize : min((page+1)*speciesPageSize, len(matches))] {
+		buttons = append(buttons, []tele.InlineButton{careSheetAction.Button(speciesLabel(m.Species), int32(m.Species.ID))})
+	}
+
+	var nav []tele.InlineButton
+	if page > 0 {
+		nav = append(nav, careBrowseAction.Button("◀️ Prev", page-1, query))
+	}
+	if page < pages-1 {
+		nav = append(nav, careBrowseAction.Button("Next ▶️", page+1, query))
+	}
+	if len(nav) > 0 {
+		buttons = append(buttons, nav)
+	}
+	markup.InlineKeyboard = buttons
+
+	msg := fmt.Sprintf("📖 Care sheets (page %d of %d). Use /caresheet <name> to search.", page+1, pages)
+	if c.Callback() != nil {
+		return c.Edit(msg, markup)
+	}
+	return c.Send(msg, markup)
+}
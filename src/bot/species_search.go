@@ -0,0 +1,203 @@
+package bot
+
+import (
+	"sort"
+	"strings"
+	"tarantulago/models"
+	"unicode"
+	"unicode/utf8"
+)
+
+const speciesPageSize = 8
+
+// Match scores, best first. Fuzzy matches score below fuzzyScore by their
+// edit distance.
+const (
+	exactScore     = 100
+	prefixScore    = 90
+	wordPrefix     = 80
+	substringScore = 70
+	allWordsScore  = 60
+	fuzzyScore     = 50
+)
+
+// SpeciesMatch is a species with its search relevance
+type SpeciesMatch struct {
+	Species models.TarantulaSpecies
+	Score   int
+}
+
+// SearchSpecies ranks species against a free-text query, matching scientific
+// names, common names and synonyms, and tolerating small typos. Species in
+// recent (most recently used first) are ranked ahead of equally good matches.
+// An empty query returns every species with recent ones first.
+func SearchSpecies(species []models.TarantulaSpecies, query string, recent []int32) []SpeciesMatch {
+	q := normalizeSpeciesName(query)
+
+	recentRank := make(map[int]int, len(recent))
+	for i, id := range recent {
+		if _, seen := recentRank[int(id)]; !seen {
+			recentRank[int(id)] = i
+		}
+	}
+
+	var matches []SpeciesMatch
+	for _, sp := range species {
+		score := 1
+		if q != "" {
+			score = 0
+			for _, name := range speciesNames(sp) {
+				score = max(score, matchScore(normalizeSpeciesName(name), q))
+			}
+		}
+		if score > 0 {
+			matches = append(matches, SpeciesMatch{Species: sp, Score: score})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		ra, aRecent := recentRank[a.Species.ID]
+		rb, bRecent := recentRank[b.Species.ID]
+		if aRecent != bRecent {
+			return aRecent
+		}
+		if aRecent && ra != rb {
+			return ra < rb
+		}
+		return a.Species.ScientificName < b.Species.ScientificName
+	})
+
+	return matches
+}
+
+// bestSpeciesMatch returns the match to pick without asking: an exact name, or
+// the only result when it matched the start of a name or of one of its words.
+// A lone typo-tolerant hit is still offered as a choice rather than picked.
+func bestSpeciesMatch(matches []SpeciesMatch) (SpeciesMatch, bool) {
+	if len(matches) == 0 {
+		return SpeciesMatch{}, false
+	}
+	if matches[0].Score == exactScore || (len(matches) == 1 && matches[0].Score >= wordPrefix) {
+		return matches[0], true
+	}
+	return SpeciesMatch{}, false
+}
+
+func speciesNames(sp models.TarantulaSpecies) []string {
+	names := []string{sp.ScientificName, sp.CommonName}
+	for _, synonym := range strings.Split(sp.Synonyms, ",") {
+		if synonym = strings.TrimSpace(synonym); synonym != "" {
+			names = append(names, synonym)
+		}
+	}
+	return names
+}
+
+// normalizeSpeciesName lowercases and strips punctuation so "B. hamorii"
+// and "b hamorii" compare equal
+func normalizeSpeciesName(s string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if space && b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			space = false
+			b.WriteRune(r)
+		default:
+			space = true
+		}
+	}
+	return b.String()
+}
+
+func matchScore(name, q string) int {
+	if name == "" {
+		return 0
+	}
+	switch {
+	case name == q:
+		return exactScore
+	case strings.HasPrefix(name, q):
+		return prefixScore
+	case strings.Contains(" "+name, " "+q):
+		return wordPrefix
+	case strings.Contains(name, q):
+		return substringScore
+	}
+
+	words := strings.Fields(name)
+	if wordsPrefixMatch(words, strings.Fields(q)) {
+		return allWordsScore
+	}
+
+	// Allow roughly one typo per four letters against any word or the
+	// name as a whole
+	qLen := utf8.RuneCountInString(q)
+	allowed := qLen / 4
+	if allowed == 0 {
+		return 0
+	}
+	best := levenshtein(name, q)
+	for _, w := range words {
+		best = min(best, levenshtein(w, q))
+		// Compare the word's start too, in runes so non-ASCII names aren't cut mid-letter
+		if rw := []rune(w); len(rw) > qLen {
+			best = min(best, levenshtein(string(rw[:qLen]), q))
+		}
+	}
+	if best <= allowed {
+		return fuzzyScore - best*10
+	}
+	return 0
+}
+
+// wordsPrefixMatch reports whether every query word is a prefix of a
+// distinct name word, in order, e.g. "b ham" for "brachypelma hamorii"
+func wordsPrefixMatch(words, query []string) bool {
+	if len(query) < 2 {
+		return false
+	}
+	i := 0
+	for _, w := range words {
+		if i < len(query) && strings.HasPrefix(w, query[i]) {
+			i++
+		}
+	}
+	return i == len(query)
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// speciesLabel shows both names so species sharing a common name can be told apart
+func speciesLabel(sp models.TarantulaSpecies) string {
+	if sp.CommonName == "" {
+		return sp.ScientificName
+	}
+	return sp.ScientificName + " (" + sp.CommonName + ")"
+}
@@ -0,0 +1,96 @@
+package bot
+
+import (
+	"tarantulago/models"
+	"testing"
+)
+
+var testSpecies = []models.TarantulaSpecies{
+	{ID: 1, ScientificName: "Brachypelma hamorii", CommonName: "Mexican Red Knee", Synonyms: "Brachypelma smithi"},
+	{ID: 4, ScientificName: "Tliltocatl albopilosus", CommonName: "Curly Hair", Synonyms: "Brachypelma albopilosum"},
+	{ID: 12, ScientificName: "Brachypelma emilia", CommonName: "Mexican Red Leg"},
+	{ID: 32, ScientificName: "Pamphobeteus sp. machala", CommonName: "Purple Bloom"},
+	{ID: 34, ScientificName: "Xenesthis immanis", CommonName: "Colombian Purple Bloom"},
+}
+
+func topMatch(t *testing.T, query string, recent []int32) int {
+	t.Helper()
+	matches := SearchSpecies(testSpecies, query, recent)
+	if len(matches) == 0 {
+		t.Fatalf("no matches for %q", query)
+	}
+	return matches[0].Species.ID
+}
+
+func TestSearchSpecies(t *testing.T) {
+	cases := []struct {
+		query string
+		want  int
+	}{
+		{"Brachypelma hamorii", 1},
+		{"hamorii", 1},
+		{"B. hamorii", 1},
+		{"red knee", 1},
+		{"smithi", 1},
+		{"albopilosum", 4},
+		{"curly", 4},
+		{"hamori", 1},
+		{"brachypelma hamori", 1},
+		{"albopilossus", 4},
+	}
+	for _, c := range cases {
+		if got := topMatch(t, c.query, nil); got != c.want {
+			t.Errorf("SearchSpecies(%q) top = %d, want %d", c.query, got, c.want)
+		}
+	}
+}
+
+func TestSearchSpeciesRanksRecentFirst(t *testing.T) {
+	if got := topMatch(t, "purple bloom", nil); got != 32 {
+		t.Fatalf("top = %d, want exact common name match 32", got)
+	}
+	if got := topMatch(t, "bloom", []int32{34}); got != 34 {
+		t.Errorf("top = %d, want recently used 34", got)
+	}
+	if got := topMatch(t, "", []int32{12}); got != 12 {
+		t.Errorf("empty query top = %d, want recently used 12", got)
+	}
+}
+
+func TestSearchSpeciesNoMatch(t *testing.T) {
+	if matches := SearchSpecies(testSpecies, "qwerty", nil); len(matches) != 0 {
+		t.Errorf("expected no matches, got %d", len(matches))
+	}
+}
+
+func TestSearchSpeciesNonASCII(t *testing.T) {
+	species := []models.TarantulaSpecies{{ID: 7, ScientificName: "Äcanthoscurria"}}
+	// Cutting the name at the query's byte length would leave "äcant",
+	// two edits away instead of one
+	matches := SearchSpecies(species, "acanth", nil)
+	if len(matches) != 1 || matches[0].Score != fuzzyScore-10 {
+		t.Errorf("matches = %+v, want one fuzzy match one edit away", matches)
+	}
+}
+
+func TestBestSpeciesMatch(t *testing.T) {
+	cases := []struct {
+		query string
+		want  int // 0 when the keeper should choose
+	}{
+		{"Purple Bloom", 32},
+		{"hamorii", 1},
+		{"xenesthis", 34},
+		{"albopilossus", 0},
+		{"brachypelma", 0},
+	}
+	for _, c := range cases {
+		got := 0
+		if best, ok := bestSpeciesMatch(SearchSpecies(testSpecies, c.query, nil)); ok {
+			got = best.Species.ID
+		}
+		if got != c.want {
+			t.Errorf("bestSpeciesMatch(%q) = %d, want %d", c.query, got, c.want)
+		}
+	}
+}
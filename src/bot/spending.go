@@ -0,0 +1,319 @@
+package bot
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"tarantulago/models"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// parseAmount reads a price such as "12.50" or "$12.50"
+func parseAmount(text string) (float64, bool) {
+	text = strings.TrimPrefix(strings.TrimSpace(text), "$")
+	amount, err := strconv.ParseFloat(strings.ReplaceAll(text, ",", "."), 64)
+	if err != nil || amount < 0 {
+		return 0, false
+	}
+	return amount, true
+}
+
+func (t *TarantulaBot) handleSpendingOverview(c tele.Context) error {
+	year := time.Now().Year()
+	report, err := t.db.GetSpendReport(t.reqCtx(c), ownerID(c), year)
+	if err != nil {
+		return fmt.Errorf("failed to get spending: %w", err)
+	}
+
+	markup := &tele.ReplyMarkup{
+		InlineKeyboard: [][]tele.InlineButton{{
+			logPurchaseAction.Button("🛒 Log Feeder Purchase"),
+			logExpenseAction.Button("🧾 Log Expense"),
+		}},
+	}
+	return c.Send(FormatSpendReport(*report), markup, tele.ModeMarkdown)
+}
+
+// The purchase form asks for the feeder species, size, quantity, price,
+// shipping and supplier, then which feeder colony the insects went into.
+
+func (t *TarantulaBot) handleLogPurchase(c tele.Context) error {
+	session := t.sessions.GetSession(sessionKey(c))
+	session.reset()
+	session.CurrentState = StateRecordingPurchase
+	session.CurrentField = FieldPurchaseFeeder
+	t.sessions.UpdateSession(sessionKey(c), session)
+
+	markup, err := t.buildFeederSpeciesMarkup(purchaseFeederAction)
+	if err != nil {
+		return fmt.Errorf("failed to load feeder species: %w", err)
+	}
+	return c.Send("🛒 Which feeders did you buy?", markup)
+}
+
+func (t *TarantulaBot) handlePurchaseFeederSelected(c tele.Context, feederSpeciesID int) error {
+	session := t.sessions.GetSession(sessionKey(c))
+	if session.CurrentState != StateRecordingPurchase || session.CurrentField != FieldPurchaseFeeder {
+		return SendError(c, "Invalid session state. Please start over.")
+	}
+
+	session.Purchase.FeederSpeciesID = feederSpeciesID
+	session.CurrentField = FieldPurchaseSize
+	t.sessions.UpdateSession(sessionKey(c), session)
+
+	markup := &tele.ReplyMarkup{}
+	var row []tele.InlineButton
+	for _, size := range models.PreySizes {
+		row = append(row, purchaseSizeAction.Button(size, size))
+	}
+	markup.InlineKeyboard = [][]tele.InlineButton{row, {purchaseSizeAction.Button("🔀 Mixed sizes", "mixed")}}
+
+	return c.Send("📏 What size?", markup)
+}
+
+func (t *TarantulaBot) handlePurchaseSizeSelected(c tele.Context, size string) error {
+	session := t.sessions.GetSession(sessionKey(c))
+	if session.CurrentState != StateRecordingPurchase || session.CurrentField != FieldPurchaseSize {
+		return SendError(c, "Invalid session state. Please start over.")
+	}
+
+	session.Purchase.PreySize = ""
+	if size != "mixed" {
+		if !slices.Contains(models.PreySizes, size) {
+			return SendError(c, "Unknown prey size")
+		}
+		session.Purchase.PreySize = size
+	}
+	session.CurrentField = FieldPurchaseQuantity
+	t.sessions.UpdateSession(sessionKey(c), session)
+
+	return c.Send("How many did you buy?")
+}
+
+func (t *TarantulaBot) handlePurchaseFormInput(c tele.Context, session *UserSession) error {
+	text := strings.TrimSpace(c.Text())
+
+	switch session.CurrentField {
+	case FieldPurchaseQuantity:
+		quantity, err := strconv.Atoi(text)
+		if err != nil || quantity <= 0 {
+			return c.Send("Please enter a valid number of feeders")
+		}
+		session.Purchase.Quantity = quantity
+		session.CurrentField = FieldPurchasePrice
+		t.sessions.UpdateSession(sessionKey(c), session)
+		return c.Send("💵 What did they cost in total, before shipping?")
+
+	case FieldPurchasePrice:
+		price, ok := parseAmount(text)
+		if !ok {
+			return c.Send("Please enter a valid price, e.g. 12.50")
+		}
+		session.Purchase.Price = price
+		session.CurrentField = FieldPurchaseShipping
+		t.sessions.UpdateSession(sessionKey(c), session)
+		return c.Send("📦 How much was shipping? (0 if none)")
+
+	case FieldPurchaseShipping:
+		shipping, ok := parseAmount(text)
+		if !ok {
+			return c.Send("Please enter a valid amount, or 0")
+		}
+		session.Purchase.Shipping = shipping
+		session.CurrentField = FieldPurchaseSupplier
+		t.sessions.UpdateSession(sessionKey(c), session)
+		return c.Send("🏪 Which supplier? (or 'skip')")
+
+	case FieldPurchaseSupplier:
+		if !strings.EqualFold(text, "skip") {
+			session.Purchase.Supplier = text
+		}
+		return t.promptPurchaseColony(c, session)
+
+	default:
+		return c.Send("Please choose one of the options above")
+	}
+}
+
+// promptPurchaseColony offers the feeder colonies of the bought species,
+// saving straight away when there are none
+func (t *TarantulaBot) promptPurchaseColony(c tele.Context, session *UserSession) error {
+	colonies, err := t.db.GetColonyStatus(t.reqCtx(c), ownerID(c))
+	if err != nil {
+		return fmt.Errorf("failed to load feeder colonies: %w", err)
+	}
+
+	var rows [][]tele.InlineButton
+	for _, colony := range colonies {
+		if int(colony.FeederSpeciesID) != session.Purchase.FeederSpeciesID {
+			continue
+		}
+		rows = append(rows, []tele.InlineButton{purchaseColonyAction.Button("🏠 "+colony.ColonyName, int(colony.ID))})
+	}
+	if len(rows) == 0 {
+		return t.savePurchase(c, session)
+	}
+
+	rows = append(rows, []tele.InlineButton{purchaseColonyAction.Button("🚫 Not added to a colony", 0)})
+	session.CurrentField = FieldPurchaseColony
+	t.sessions.UpdateSession(sessionKey(c), session)
+
+	return c.Send("Did they go into a feeder colony?", &tele.ReplyMarkup{InlineKeyboard: rows})
+}
+
+func (t *TarantulaBot) handlePurchaseColonySelected(c tele.Context, colonyID int) error {
+	session := t.sessions.GetSession(sessionKey(c))
+	if session.CurrentState != StateRecordingPurchase || session.CurrentField != FieldPurchaseColony {
+		return SendError(c, "Invalid session state. Please start over.")
+	}
+
+	session.Purchase.ColonyID = nil
+	if colonyID > 0 {
+		session.Purchase.ColonyID = &colonyID
+	}
+	return t.savePurchase(c, session)
+}
+
+func (t *TarantulaBot) savePurchase(c tele.Context, session *UserSession) error {
+	purchase := session.Purchase
+	purchase.PurchaseDate = time.Now()
+	purchase.UserID = ownerID(c)
+
+	session.reset()
+	t.sessions.UpdateSession(sessionKey(c), session)
+
+	if _, err := t.db.RecordFeederPurchase(t.reqCtx(c), purchase); err != nil {
+		return fmt.Errorf("failed to save purchase: %w", err)
+	}
+
+	msg := fmt.Sprintf("Purchase recorded: %d for $%.2f ($%.3f each)", purchase.Quantity, purchase.Price+purchase.Shipping, purchase.UnitCost())
+	if purchase.ColonyID != nil {
+		msg += " and added to the colony"
+	}
+	return sendSuccess(c, msg)
+}
+
+// The expense form asks for a category, amount and description, then which
+// tarantula it was for, if any.
+
+func (t *TarantulaBot) handleLogExpense(c tele.Context) error {
+	session := t.sessions.GetSession(sessionKey(c))
+	session.reset()
+	session.CurrentState = StateRecordingExpense
+	session.CurrentField = FieldExpenseCategory
+	t.sessions.UpdateSession(sessionKey(c), session)
+
+	var rows [][]tele.InlineButton
+	var row []tele.InlineButton
+	for _, category := range models.ExpenseCategories {
+		row = append(row, expenseCategoryAction.Button(category, category))
+		if len(row) == 2 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+
+	return c.Send("🧾 What kind of expense?", &tele.ReplyMarkup{InlineKeyboard: rows})
+}
+
+func (t *TarantulaBot) handleExpenseCategorySelected(c tele.Context, category string) error {
+	session := t.sessions.GetSession(sessionKey(c))
+	if session.CurrentState != StateRecordingExpense || session.CurrentField != FieldExpenseCategory {
+		return SendError(c, "Invalid session state. Please start over.")
+	}
+	if !slices.Contains(models.ExpenseCategories, category) {
+		return SendError(c, "Unknown expense category")
+	}
+
+	session.Expense.Category = category
+	session.CurrentField = FieldExpenseAmount
+	t.sessions.UpdateSession(sessionKey(c), session)
+
+	return c.Send("💵 How much was it?")
+}
+
+func (t *TarantulaBot) handleExpenseFormInput(c tele.Context, session *UserSession) error {
+	text := strings.TrimSpace(c.Text())
+
+	switch session.CurrentField {
+	case FieldExpenseAmount:
+		amount, ok := parseAmount(text)
+		if !ok {
+			return c.Send("Please enter a valid amount, e.g. 25.00")
+		}
+		session.Expense.Amount = amount
+		session.CurrentField = FieldExpenseDescription
+		t.sessions.UpdateSession(sessionKey(c), session)
+		return c.Send("📝 What was it for? (or 'skip')")
+
+	case FieldExpenseDescription:
+		if !strings.EqualFold(text, "skip") {
+			session.Expense.Description = text
+		}
+		return t.promptExpenseTarantula(c, session)
+
+	default:
+		return c.Send("Please choose one of the options above")
+	}
+}
+
+func (t *TarantulaBot) promptExpenseTarantula(c tele.Context, session *UserSession) error {
+	tarantulas, err := t.db.GetAllTarantulas(t.reqCtx(c), ownerID(c))
+	if err != nil {
+		return fmt.Errorf("failed to load tarantulas: %w", err)
+	}
+	if len(tarantulas) == 0 {
+		return t.saveExpense(c, session)
+	}
+
+	rows := [][]tele.InlineButton{{expenseTarantulaAction.Button("🏠 Whole collection", 0)}}
+	var row []tele.InlineButton
+	for _, tarantula := range tarantulas {
+		row = append(row, expenseTarantulaAction.Button(tarantula.Name, int(tarantula.ID)))
+		if len(row) == 2 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+
+	session.CurrentField = FieldExpenseTarantula
+	t.sessions.UpdateSession(sessionKey(c), session)
+
+	return c.Send("Was it for one tarantula?", &tele.ReplyMarkup{InlineKeyboard: rows})
+}
+
+func (t *TarantulaBot) handleExpenseTarantulaSelected(c tele.Context, tarantulaID int) error {
+	session := t.sessions.GetSession(sessionKey(c))
+	if session.CurrentState != StateRecordingExpense || session.CurrentField != FieldExpenseTarantula {
+		return SendError(c, "Invalid session state. Please start over.")
+	}
+
+	session.Expense.TarantulaID = nil
+	if tarantulaID > 0 {
+		session.Expense.TarantulaID = &tarantulaID
+	}
+	return t.saveExpense(c, session)
+}
+
+func (t *TarantulaBot) saveExpense(c tele.Context, session *UserSession) error {
+	expense := session.Expense
+	expense.ExpenseDate = time.Now()
+	expense.UserID = ownerID(c)
+
+	session.reset()
+	t.sessions.UpdateSession(sessionKey(c), session)
+
+	if _, err := t.db.RecordExpense(t.reqCtx(c), expense); err != nil {
+		return fmt.Errorf("failed to save expense: %w", err)
+	}
+	return sendSuccess(c, fmt.Sprintf("%s expense of $%.2f recorded!", expense.Category, expense.Amount))
+}
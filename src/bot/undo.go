@@ -0,0 +1,82 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// undoWindow is how long something logged can be taken back
+const undoWindow = 15 * time.Minute
+
+type undoEntry struct {
+	userID  int64
+	expires time.Time
+	undo    func(ctx context.Context) error
+}
+
+// undoLog keeps what recent quick logs need to take them back. It lives in
+// memory, so Undo buttons stop working when the bot restarts.
+type undoLog struct {
+	mu      sync.Mutex
+	entries map[int64]undoEntry
+}
+
+func newUndoLog() *undoLog {
+	return &undoLog{entries: make(map[int64]undoEntry)}
+}
+
+// add keeps undo for userID and returns the ID its button carries
+func (l *undoLog) add(userID int64, undo func(context.Context) error, now time.Time) int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for id, entry := range l.entries {
+		if now.After(entry.expires) {
+			delete(l.entries, id)
+		}
+	}
+
+	id := rand.Int64()
+	for _, taken := l.entries[id]; taken; _, taken = l.entries[id] {
+		id = rand.Int64()
+	}
+	l.entries[id] = undoEntry{userID: userID, expires: now.Add(undoWindow), undo: undo}
+	return id
+}
+
+// take removes and returns the undo behind id, if userID logged it and it
+// hasn't expired
+func (l *undoLog) take(id, userID int64, now time.Time) (func(context.Context) error, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry, ok := l.entries[id]
+	if !ok || entry.userID != userID || now.After(entry.expires) {
+		return nil, false
+	}
+	delete(l.entries, id)
+	return entry.undo, true
+}
+
+// sendWithUndo confirms something was logged, with a button to take it back
+func (t *TarantulaBot) sendWithUndo(c tele.Context, msg string, undo func(context.Context) error) error {
+	id := t.undos.add(c.Sender().ID, undo, time.Now())
+	markup := &tele.ReplyMarkup{InlineKeyboard: [][]tele.InlineButton{{undoAction.Button("↩️ Undo", id)}}}
+	return c.Send("✅ "+msg, markup)
+}
+
+func (t *TarantulaBot) handleUndo(c tele.Context, id int64) error {
+	undo, ok := t.undos.take(id, c.Sender().ID, time.Now())
+	if !ok {
+		return c.RespondAlert(fmt.Sprintf("Only whoever logged this can undo it, within %d minutes.", int(undoWindow.Minutes())))
+	}
+	if err := undo(t.reqCtx(c)); err != nil {
+		return fmt.Errorf("failed to undo: %w", err)
+	}
+	return c.Edit(c.Message().Text + "\n\n↩️ Undone")
+}
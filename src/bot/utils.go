@@ -2,6 +2,7 @@ package bot
 
 import (
 	"fmt"
+	"html"
 	"strconv"
 	"strings"
 	"tarantulago/models"
@@ -143,44 +144,195 @@ func ValidateCricketCount(input string) (int, error) {
 }
 
 func TarantulaListToMarkup(tarantulas []models.TarantulaListItem) *tele.ReplyMarkup {
-	markup := &tele.ReplyMarkup{}
-
-	var rows []tele.Row
+	var rows [][]tele.InlineButton
 	for _, t := range tarantulas {
 		emoji, _ := GetFeedingStatusWithMolt(int(t.DaysSinceFeeding), int(t.MinDays), int(t.MaxDays), t.CurrentStatus)
-		btn := markup.Data(
-			fmt.Sprintf("%s %s", emoji, t.Name),
-			"select", strconv.Itoa(int(t.ID)),
-		)
-		rows = append(rows, markup.Row(btn))
+		rows = append(rows, []tele.InlineButton{selectAction.Button(fmt.Sprintf("%s %s", emoji, t.Name), int(t.ID))})
 	}
-
-	markup.Inline(rows...)
-	return markup
+	return &tele.ReplyMarkup{InlineKeyboard: rows}
 }
 
 func BuildTarantulaActionsMarkup(tarantulaID int32) *tele.ReplyMarkup {
-	markup := &tele.ReplyMarkup{}
+	id := int(tarantulaID)
+	return &tele.ReplyMarkup{InlineKeyboard: [][]tele.InlineButton{
+		{
+			feedAction.Button("🍽️", id),
+			weightAction.Button("⚖️", id),
+			addPhotoAction.Button("📸", tarantulaID),
+			moltAction.Button("🔄", id),
+		},
+		{
+			weightHistoryAction.Button("📊", tarantulaID),
+			viewPhotosAction.Button("🖼️", tarantulaID),
+			intelAction.Button("🧠", id),
+			moltPredictionAction.Button("🔮", id),
+		},
+		{careWaterAction.Button("💧 Watered", id), careNoteAction.Button("📝 Observation", id)},
+		{careAction.Button("📖 Care Sheet", tarantulaID), backToListAction.Button("⬅️ Back")},
+	}}
+}
+
+// FormatPrey describes the prey of a feeding, e.g. "3× Medium Dubia roach (pre-killed)"
+func FormatPrey(event models.FeedingEvent) string {
+	name := event.FeederSpecies.Name
+	if name == "" {
+		name = "prey"
+	}
+	prey := fmt.Sprintf("%d× ", event.PreyCount)
+	if event.PreySize != "" {
+		prey += event.PreySize + " "
+	}
+	prey += name
+	if event.PreKilled {
+		prey += " (pre-killed)"
+	}
+	return prey
+}
+
+// FormatColonyStatuses lists feeder colonies with their recent usage
+func FormatColonyStatuses(colonies []models.ColonyStatus) string {
+	var msg strings.Builder
+	msg.WriteString("🦗 *Feeder Colonies*\n\n")
+	for _, colony := range colonies {
+		if colony.SizeName != "" {
+			msg.WriteString(fmt.Sprintf("*%s* (%s, %s)\n", colony.ColonyName, colony.FeederName, strings.ToLower(colony.SizeName)))
+		} else {
+			msg.WriteString(fmt.Sprintf("*%s* (%s)\n", colony.ColonyName, colony.FeederName))
+		}
+		msg.WriteString(fmt.Sprintf("Current count: *%d*\n", colony.CurrentCount))
+		msg.WriteString(fmt.Sprintf("Used in last 7 days: *%d*\n", colony.UsedLast7Days))
+		if colony.WeeksRemaining != nil {
+			if *colony.WeeksRemaining < 2 {
+				msg.WriteString(fmt.Sprintf("⚠️ Low stock: ~%.1f weeks remaining\n", *colony.WeeksRemaining))
+			} else {
+				msg.WriteString(fmt.Sprintf("✅ Stock: ~%.1f weeks remaining\n", *colony.WeeksRemaining))
+			}
+		}
+		msg.WriteString("\n")
+	}
+	return msg.String()
+}
+
+// FormatFeederProjection lists breeding clutches and compares projected
+// feeders per size class with scheduled demand
+func FormatFeederProjection(p models.FeederProjection) string {
+	var msg strings.Builder
+	msg.WriteString("🥚 *Feeder Breeding*\n\n")
+
+	if len(p.Clutches) == 0 {
+		msg.WriteString("No clutches in progress. Put egg-laying substrate in a colony to start one.\n\n")
+	}
+	for _, cp := range p.Clutches {
+		clutch := cp.Clutch
+		msg.WriteString(fmt.Sprintf("*#%d %s* (%s)\n", clutch.ID, clutch.Colony.ColonyName, clutch.Colony.FeederSpecies.Name))
+		switch clutch.Stage() {
+		case models.ClutchStageLaying:
+			msg.WriteString(fmt.Sprintf("📥 Laying since %s\n", FormatDate(&clutch.SubstrateInDate)))
+			msg.WriteString(fmt.Sprintf("🐣 Hatching around %s\n", FormatDate(&cp.ExpectedHatch)))
+		case models.ClutchStageIncubating:
+			msg.WriteString(fmt.Sprintf("🌡️ Incubating since %s\n", FormatDate(clutch.SubstrateOutDate)))
+			msg.WriteString(fmt.Sprintf("🐣 Hatching around %s\n", FormatDate(&cp.ExpectedHatch)))
+		case models.ClutchStageGrowing:
+			msg.WriteString(fmt.Sprintf("🦗 ~%d %s, hatched %s\n", cp.Count, strings.ToLower(cp.CurrentSize.ToDBName()), FormatDate(clutch.HatchDate)))
+		}
+		if cp.ProjectedSize != models.CricketSizeUnknown {
+			msg.WriteString(fmt.Sprintf("📏 %s in %d weeks\n", cp.ProjectedSize.ToDBName(), p.Weeks))
+		}
+		msg.WriteString("\n")
+	}
+
+	msg.WriteString(fmt.Sprintf("📈 *In %d weeks (%s)*\n", p.Weeks, FormatDate(&p.Date)))
+	if len(p.Sizes) == 0 {
+		msg.WriteString("No feeders projected and no scheduled demand.\n")
+	}
+	for _, s := range p.Sizes {
+		status := "✅"
+		if float64(s.Supply) < s.WeeklyDemand {
+			status = "⚠️"
+		}
+		msg.WriteString(fmt.Sprintf("%s %s: ~%d from clutches, need ~%.1f/week (%.0f over %d weeks)\n",
+			status, s.Size.ToDBName(), s.Supply, s.WeeklyDemand, s.PeriodDemand, p.Weeks))
+	}
+
+	return msg.String()
+}
+
+// FormatFeederForecast shows weekly demand per size class over the forecast
+// and when to reorder sizes that will run short
+func FormatFeederForecast(f models.FeederForecast) string {
+	var msg strings.Builder
+	msg.WriteString(fmt.Sprintf("📦 *Feeder Forecast* (next %d weeks)\n\n", f.Weeks))
+
+	if len(f.Sizes) == 0 {
+		msg.WriteString("No feeding schedules to forecast from yet.\n")
+		return msg.String()
+	}
+	if f.MixedStock > 0 {
+		msg.WriteString(fmt.Sprintf("🔀 Mixed-size stock: %d\n\n", f.MixedStock))
+	}
+
+	for _, s := range f.Sizes {
+		total := 0.0
+		weekly := make([]string, len(s.WeeklyDemand))
+		for i, d := range s.WeeklyDemand {
+			total += d
+			weekly[i] = fmt.Sprintf("%.0f", d)
+		}
+		msg.WriteString(fmt.Sprintf("*%s*: need ~%.0f, %d in stock\n", s.Size.ToDBName(), total, s.Stock))
+		msg.WriteString(fmt.Sprintf("Weekly: %s\n", strings.Join(weekly, " · ")))
+		if s.RunOutDate != nil {
+			msg.WriteString(fmt.Sprintf("⚠️ Runs out around %s\n", FormatDate(s.RunOutDate)))
+			msg.WriteString(fmt.Sprintf("🛒 Order %d by %s\n", s.OrderQuantity, FormatDate(s.OrderByDate)))
+		} else {
+			msg.WriteString("✅ Enough stock\n")
+		}
+		msg.WriteString("\n")
+	}
+
+	msg.WriteString("_Demand pauses while tarantulas fast around predicted molts._")
+	return msg.String()
+}
+
+// FormatSpendReport summarises a year's purchases and expenses, the average
+// price paid per feeder, and spend per tarantula
+func FormatSpendReport(r models.SpendReport) string {
+	var msg strings.Builder
+	msg.WriteString(fmt.Sprintf("💰 *Spending %d*\n\n", r.Year))
 
-	feedBtn := markup.Data("🍽️", fmt.Sprintf("feed:%d", tarantulaID))
-	weightBtn := markup.Data("⚖️", fmt.Sprintf("weight:%d", tarantulaID))
-	photoBtn := markup.Data("📸", fmt.Sprintf("photo:%d", tarantulaID))
-	moltBtn := markup.Data("🔄", fmt.Sprintf("molt:%d", tarantulaID))
+	if r.Total == 0 && len(r.Tarantulas) == 0 {
+		msg.WriteString("Nothing recorded yet. Log feeder purchases and expenses to see where the money goes.\n")
+		return msg.String()
+	}
 
-	historyBtn := markup.Data("📊", fmt.Sprintf("weight_history:%d", tarantulaID))
-	photosBtn := markup.Data("🖼️", fmt.Sprintf("view_photos:%d", tarantulaID))
-	intelligenceBtn := markup.Data("🧠", fmt.Sprintf("intel:%d", tarantulaID))
-	predictionBtn := markup.Data("🔮", fmt.Sprintf("molt_pred:%d", tarantulaID))
+	if r.FeederSpend > 0 {
+		msg.WriteString(fmt.Sprintf("🦗 Feeders: $%.2f (%d insects)\n", r.FeederSpend, r.FeederItems))
+	}
+	for _, category := range models.ExpenseCategories {
+		if amount := r.ExpenseSpend[category]; amount > 0 {
+			msg.WriteString(fmt.Sprintf("🧾 %s: $%.2f\n", category, amount))
+		}
+	}
+	msg.WriteString(fmt.Sprintf("*Total: $%.2f*\n", r.Total))
 
-	backBtn := markup.Data("⬅️ Back", "back_to_list")
+	if len(r.FeederUnitCosts) > 0 {
+		msg.WriteString("\n🏷️ *Average price paid*\n")
+		for _, uc := range r.FeederUnitCosts {
+			msg.WriteString(fmt.Sprintf("%s %s: $%.3f each (%d bought)\n", uc.PreySize, strings.ToLower(uc.FeederName), uc.UnitCost, uc.Quantity))
+		}
+	}
 
-	markup.Inline(
-		markup.Row(feedBtn, weightBtn, photoBtn, moltBtn),
-		markup.Row(historyBtn, photosBtn, intelligenceBtn, predictionBtn),
-		markup.Row(backBtn),
-	)
+	if len(r.Tarantulas) > 0 {
+		msg.WriteString("\n🕷 *Per tarantula*\n")
+		for _, ts := range r.Tarantulas {
+			msg.WriteString(fmt.Sprintf("%s: $%.2f prey", ts.TarantulaName, ts.PreyCost))
+			if ts.ExpenseCost > 0 {
+				msg.WriteString(fmt.Sprintf(", $%.2f other", ts.ExpenseCost))
+			}
+			msg.WriteString("\n")
+		}
+	}
 
-	return markup
+	return msg.String()
 }
 
 func FormatFeedingPattern(pattern models.FeedingPattern) string {
@@ -191,8 +343,8 @@ func FormatFeedingPattern(pattern models.FeedingPattern) string {
 		msg += fmt.Sprintf("• Average interval: %.1f days\n", pattern.AverageInterval)
 	}
 	msg += fmt.Sprintf("• Regularity: %s\n", pattern.FeedingRegularity)
-	if pattern.CricketsPerWeek > 0 {
-		msg += fmt.Sprintf("• Consumption: %.1f crickets/week\n", pattern.CricketsPerWeek)
+	if pattern.PreyItemsPerWeek > 0 {
+		msg += fmt.Sprintf("• Consumption: %.1f prey/week (%.2fg/week)\n", pattern.PreyItemsPerWeek, pattern.PreyMassPerWeek)
 	}
 	msg += fmt.Sprintf("• Days since feeding: %d\n", pattern.DaysSinceLastFeeding)
 	return msg
@@ -220,6 +372,121 @@ func FormatGrowthData(data models.GrowthData) string {
 	return msg
 }
 
+func FormatSpecies(species models.TarantulaSpecies) string {
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("*%s*", species.ScientificName))
+	if species.CommonName != "" {
+		b.WriteString(fmt.Sprintf(" (%s)", species.CommonName))
+	}
+	switch species.Status {
+	case models.SpeciesStatusPending:
+		b.WriteString(" ⏳ pending review")
+	case models.SpeciesStatusPrivate:
+		b.WriteString(" 🔒 private")
+	}
+	b.WriteString("\n")
+
+	if species.Synonyms != "" {
+		b.WriteString(fmt.Sprintf("• Also known as: %s\n", species.Synonyms))
+	}
+	b.WriteString(fmt.Sprintf("• Adult size: %.1f cm\n", species.AdultSizeCM))
+	if species.Temperament != "" {
+		b.WriteString(fmt.Sprintf("• Temperament: %s\n", species.Temperament))
+	}
+	if species.HumidityRequirementPercent > 0 {
+		b.WriteString(fmt.Sprintf("• Humidity: %d%%\n", species.HumidityRequirementPercent))
+	}
+	if species.TemperatureRequirementCelsius > 0 {
+		b.WriteString(fmt.Sprintf("• Temperature: %.0f°C\n", species.TemperatureRequirementCelsius))
+	}
+	if species.IsCommunal {
+		b.WriteString("• Communal\n")
+	}
+
+	return b.String()
+}
+
+// FormatCareSheet combines species care parameters with its feeding schedule.
+// It is HTML: user-submitted species carry any characters, and Markdown
+// can't escape them inside bold or italics.
+func FormatCareSheet(species models.TarantulaSpecies, schedules []models.FeedingSchedule) string {
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("📖 <b>%s</b>\n", html.EscapeString(species.ScientificName)))
+	if species.CommonName != "" {
+		b.WriteString(fmt.Sprintf("<i>%s</i>\n", html.EscapeString(species.CommonName)))
+	}
+	if species.Synonyms != "" {
+		b.WriteString(fmt.Sprintf("Also known as: %s\n", html.EscapeString(species.Synonyms)))
+	}
+	b.WriteString("\n")
+
+	b.WriteString("🌍 <b>Overview</b>\n")
+	if species.Origin != "" {
+		b.WriteString(fmt.Sprintf("• Origin: %s\n", html.EscapeString(species.Origin)))
+	}
+	if species.HabitatType != "" {
+		b.WriteString(fmt.Sprintf("• Habitat: %s\n", html.EscapeString(species.HabitatType)))
+	}
+	if species.AdultSizeCM > 0 {
+		b.WriteString(fmt.Sprintf("• Adult size: %.1f cm\n", species.AdultSizeCM))
+	}
+	if species.GrowthRate != "" {
+		b.WriteString(fmt.Sprintf("• Growth rate: %s\n", html.EscapeString(species.GrowthRate)))
+	}
+	if species.IsCommunal {
+		b.WriteString("• Can be kept communally\n")
+	}
+	b.WriteString("\n")
+
+	b.WriteString("🌡️ <b>Enclosure</b>\n")
+	if species.TemperatureRequirementCelsius > 0 {
+		b.WriteString(fmt.Sprintf("• Temperature: %.0f°C\n", species.TemperatureRequirementCelsius))
+	}
+	if species.HumidityRequirementPercent > 0 {
+		b.WriteString(fmt.Sprintf("• Humidity: %d%%\n", species.HumidityRequirementPercent))
+	}
+	switch species.HabitatType {
+	case models.HabitatArboreal:
+		b.WriteString("• Tall enclosure with cork bark and cross ventilation\n")
+	case models.HabitatFossorial:
+		b.WriteString("• Deep substrate for burrowing\n")
+	case models.HabitatTerrestrial:
+		b.WriteString("• Floor space over height, with a hide\n")
+	}
+	b.WriteString("\n")
+
+	b.WriteString("🛡️ <b>Handling &amp; Safety</b>\n")
+	if species.Temperament != "" {
+		b.WriteString(fmt.Sprintf("• Temperament: %s\n", html.EscapeString(species.Temperament)))
+	}
+	if species.UrticatingHairs {
+		b.WriteString("• Has urticating hairs; avoid contact with eyes and skin\n")
+	} else if species.HabitatType != "" {
+		b.WriteString("• No urticating hairs; relies on speed and bite\n")
+	}
+	if species.VenomPotency != "" {
+		b.WriteString(fmt.Sprintf("• Venom: %s\n", html.EscapeString(species.VenomPotency)))
+	}
+
+	if len(schedules) > 0 {
+		b.WriteString("\n🍽️ <b>Feeding by Stage</b>\n")
+		for _, s := range schedules {
+			b.WriteString(fmt.Sprintf("• <b>%s</b> (up to %.1f cm): %s", html.EscapeString(s.SizeCategory), s.BodyLengthCM, html.EscapeString(s.PreySize)))
+			if s.Frequency.FrequencyName != "" {
+				b.WriteString(", " + html.EscapeString(strings.ToLower(s.Frequency.FrequencyName)))
+			}
+			b.WriteString("\n")
+			if s.Notes != "" {
+				b.WriteString(fmt.Sprintf("  <i>%s</i>\n", html.EscapeString(s.Notes)))
+			}
+		}
+	}
+
+	return b.String()
+}
+
 func FormatMoltPrediction(prediction models.MoltPrediction) string {
 	msg := fmt.Sprintf("*%s*\n", prediction.TarantulaName)
 
@@ -229,6 +496,9 @@ func FormatMoltPrediction(prediction models.MoltPrediction) string {
 
 	if prediction.PredictedMoltDate != nil {
 		msg += fmt.Sprintf("• Predicted molt: %s\n", FormatDate(prediction.PredictedMoltDate))
+		if prediction.EarliestMoltDate != nil && prediction.LatestMoltDate != nil {
+			msg += fmt.Sprintf("• Likely window: %s (%s)\n", FormatMoltWindow(prediction), FormatConfidence(prediction))
+		}
 		if prediction.DaysUntilMolt != nil {
 			if *prediction.DaysUntilMolt > 0 {
 				msg += fmt.Sprintf("• Days until: %d\n", *prediction.DaysUntilMolt)
@@ -238,7 +508,9 @@ func FormatMoltPrediction(prediction models.MoltPrediction) string {
 		}
 	}
 
-	msg += fmt.Sprintf("• Confidence: %s\n", prediction.ConfidenceLevel)
+	if prediction.SampleSize > 0 {
+		msg += fmt.Sprintf("• Based on: %d molt intervals\n", prediction.SampleSize)
+	}
 	msg += fmt.Sprintf("• Size: %s\n", prediction.SizeIndicator)
 	msg += fmt.Sprintf("• Feeding: %s\n", prediction.FeedingBehavior)
 
@@ -253,6 +525,133 @@ func FormatMoltPrediction(prediction models.MoltPrediction) string {
 	return msg
 }
 
+// FormatMoltWindow renders the earliest..latest molt dates of a prediction
+func FormatMoltWindow(prediction models.MoltPrediction) string {
+	return fmt.Sprintf("%s – %s",
+		prediction.EarliestMoltDate.Format("Jan 2"),
+		prediction.LatestMoltDate.Format("Jan 2, 2006"))
+}
+
+// FormatConfidence renders the coverage of the molt window, e.g. "80% confidence"
+func FormatConfidence(prediction models.MoltPrediction) string {
+	return fmt.Sprintf("%.0f%% confidence", prediction.ConfidenceInterval*100)
+}
+
+// FormatRecorder names who logged a record in a shared collection, e.g.
+// " • by Sam"; records the owner logged themselves need no attribution
+func FormatRecorder(recorder *models.TelegramUser, ownerID int64) string {
+	if recorder == nil || recorder.TelegramID == ownerID {
+		return ""
+	}
+	return " • by " + recorder.DisplayName()
+}
+
+// FormatColonyPrey renders a colony feeding suggestion, e.g. "4 small prey"
+func FormatColonyPrey(plan models.ColonyFeedingPlan) string {
+	if plan.PreySize == "" {
+		return fmt.Sprintf("%d prey", plan.PreyCount)
+	}
+	return fmt.Sprintf("%d %s prey", plan.PreyCount, strings.ToLower(plan.PreySize))
+}
+
+// FormatCareSummary is a plain-text care sheet for the whole collection, meant
+// to be printed and left with a sitter
+func FormatCareSummary(items []models.CareSummaryItem, ownerName string, now time.Time) string {
+	var doc strings.Builder
+	doc.WriteString(fmt.Sprintf("CARE SUMMARY - %s's collection\n", ownerName))
+	doc.WriteString(fmt.Sprintf("Printed %s\n\n", now.Format("Mon Jan 2, 2006")))
+
+	for _, item := range items {
+		t := item.Tarantula
+		doc.WriteString(fmt.Sprintf("%s - %s", t.Name, t.Species.ScientificName))
+		if t.Species.CommonName != "" {
+			doc.WriteString(fmt.Sprintf(" (%s)", t.Species.CommonName))
+		}
+		doc.WriteString("\n")
+		if t.CurrentSize > 0 {
+			doc.WriteString(fmt.Sprintf("  Size: %.1f cm\n", t.CurrentSize))
+		}
+
+		switch {
+		case item.Fasting:
+			doc.WriteString(fmt.Sprintf("  Feeding: DO NOT FEED - %s. Remove any uneaten prey.\n", strings.ToLower(t.CurrentMoltStage.StageName)))
+		case item.Schedule != nil:
+			doc.WriteString(fmt.Sprintf("  Feeding: %s every %d-%d days\n",
+				item.Schedule.PreySize, item.Schedule.Frequency.MinDays, item.Schedule.Frequency.MaxDays))
+			if item.LastFedDate != nil {
+				due := item.LastFedDate.AddDate(0, 0, item.Schedule.Frequency.MinDays)
+				if due.Before(now) {
+					due = now
+				}
+				doc.WriteString(fmt.Sprintf("  Last fed %s, next feed from %s\n",
+					item.LastFedDate.Format("Jan 2"), due.Format("Mon Jan 2")))
+			}
+		default:
+			doc.WriteString("  Feeding: no schedule on record, ask before feeding\n")
+		}
+
+		if t.Species.HumidityRequirementPercent > 0 || t.Species.TemperatureRequirementCelsius > 0 {
+			doc.WriteString(fmt.Sprintf("  Keep at: %.0f°C, %d%% humidity\n",
+				t.Species.TemperatureRequirementCelsius, t.Species.HumidityRequirementPercent))
+		}
+		if t.Species.Temperament != "" {
+			doc.WriteString(fmt.Sprintf("  Temperament: %s\n", t.Species.Temperament))
+		}
+		if t.Species.UrticatingHairs {
+			doc.WriteString("  Has urticating hairs: avoid touching, wash hands after maintenance\n")
+		}
+		if t.Notes != "" {
+			doc.WriteString(fmt.Sprintf("  Notes: %s\n", t.Notes))
+		}
+		doc.WriteString("\n")
+	}
+
+	doc.WriteString("Keep water dishes full. Log every feeding, watering and anything unusual in the bot.\n")
+	return doc.String()
+}
+
+// FormatSitterDigest reports to the owner everything a sitter logged
+func FormatSitterDigest(digest models.SitterDigest) string {
+	var msg strings.Builder
+	msg.WriteString(fmt.Sprintf("🧳 *%s's sitting is over*\n", digest.Sitter.DisplayName()))
+	msg.WriteString(fmt.Sprintf("%s – %s\n\n", digest.From.Format("Jan 2"), digest.Until.Format("Jan 2")))
+
+	if len(digest.Feedings) == 0 && len(digest.CareLogs) == 0 {
+		msg.WriteString("Nothing was logged.")
+		return msg.String()
+	}
+
+	if len(digest.Feedings) > 0 {
+		msg.WriteString(fmt.Sprintf("🍽️ *Feedings (%d)*\n", len(digest.Feedings)))
+		for _, feeding := range digest.Feedings {
+			name := "?"
+			switch {
+			case feeding.Tarantula != nil:
+				name = feeding.Tarantula.Name
+			case feeding.TarantulaColony != nil:
+				name = feeding.TarantulaColony.ColonyName
+			}
+			msg.WriteString(fmt.Sprintf("• %s %s: %s\n", feeding.FeedingDate.Format("Jan 2"), name, FormatPrey(feeding)))
+		}
+		msg.WriteString("\n")
+	}
+
+	if len(digest.CareLogs) > 0 {
+		msg.WriteString(fmt.Sprintf("📝 *Care (%d)*\n", len(digest.CareLogs)))
+		for _, log := range digest.CareLogs {
+			line := fmt.Sprintf("• %s %s: ", log.LoggedAt.Format("Jan 2"), log.Tarantula.Name)
+			if log.Kind == models.CareLogWater {
+				line += "💧 watered"
+			} else {
+				line += log.Notes
+			}
+			msg.WriteString(line + "\n")
+		}
+	}
+
+	return msg.String()
+}
+
 func IsValidState(state FormState, validStates ...FormState) bool {
 	for _, valid := range validStates {
 		if state == valid {
@@ -448,8 +847,11 @@ func GetMoltPredictionSummary(predictions []models.MoltPrediction) string {
 			status = "Unknown"
 		}
 
-		msg += fmt.Sprintf("%s **%s** - %s (%s confidence)\n",
-			emoji, pred.TarantulaName, status, pred.ConfidenceLevel)
+		if pred.EarliestMoltDate != nil && pred.LatestMoltDate != nil {
+			status += fmt.Sprintf(", window %s", FormatMoltWindow(pred))
+		}
+
+		msg += fmt.Sprintf("%s **%s** - %s\n", emoji, pred.TarantulaName, status)
 	}
 
 	msg += "\n"
@@ -463,8 +865,20 @@ func GetMoltPredictionSummary(predictions []models.MoltPrediction) string {
 	return msg
 }
 
+// FormatLastFed says when a tarantula was last fed and by whom
+func FormatLastFed(feeding *models.FeedingEvent) string {
+	if feeding == nil {
+		return ""
+	}
+	line := fmt.Sprintf("🍽️ **Last fed:** %s", FormatDaysAgo(&feeding.FeedingDate))
+	if feeding.Recorder != nil {
+		line += " by " + feeding.Recorder.DisplayName()
+	}
+	return line + "\n"
+}
+
 // Format enhanced tarantula details with photos and weight
-func FormatTarantulaDetailsEnhanced(tarantula *models.Tarantula, photos []models.TarantulaPhoto, _ *models.WeightRecord) string {
+func FormatTarantulaDetailsEnhanced(tarantula *models.Tarantula, photos []models.TarantulaPhoto, lastFeeding *models.FeedingEvent, _ *models.WeightRecord) string {
 	msg := fmt.Sprintf("🕷️ **%s**\n", tarantula.Name)
 	msg += fmt.Sprintf("*%s*\n\n", tarantula.Species.ScientificName)
 
@@ -485,6 +899,7 @@ func FormatTarantulaDetailsEnhanced(tarantula *models.Tarantula, photos []models
 	if tarantula.LastMoltDate != nil {
 		msg += fmt.Sprintf("🦋 **Last molt:** %s\n", FormatDaysAgo(tarantula.LastMoltDate))
 	}
+	msg += FormatLastFed(lastFeeding)
 
 	// Photos information
 	if len(photos) > 0 {
@@ -0,0 +1,26 @@
+package bot
+
+import (
+	"strings"
+	"tarantulago/models"
+	"testing"
+)
+
+func TestFormatCareSheetEscapesUserText(t *testing.T) {
+	species := models.TarantulaSpecies{
+		ScientificName: "Grammostola <b>pulchra",
+		CommonName:     "Brazilian_black",
+		Origin:         "Brazil & Uruguay",
+	}
+	schedules := []models.FeedingSchedule{{SizeCategory: "Sling", PreySize: "1/2 body", Notes: "pre-kill <small> prey"}}
+
+	sheet := FormatCareSheet(species, schedules)
+	for _, want := range []string{"Grammostola &lt;b&gt;pulchra", "<i>Brazilian_black</i>", "Brazil &amp; Uruguay", "pre-kill &lt;small&gt; prey"} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("care sheet is missing %q:\n%s", want, sheet)
+		}
+	}
+	if strings.Contains(sheet, "<b>pulchra") || strings.Contains(sheet, "<small>") {
+		t.Errorf("care sheet passes user text through as markup:\n%s", sheet)
+	}
+}
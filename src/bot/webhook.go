@@ -0,0 +1,167 @@
+package bot
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"tarantulago/config"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// Update delivery. Polling is the default; in webhook mode Telegram pushes
+// updates to our own HTTP server. Switching either way is safe: the long
+// poller deletes a leftover webhook first, and setting a webhook keeps any
+// updates that queued up while polling stopped.
+
+const (
+	// SecretTokenHeader carries the secret token Telegram was given in setWebhook
+	SecretTokenHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+	maxUpdateSize          = 1 << 20
+	webhookShutdownTimeout = 10 * time.Second
+
+	setWebhookFirstRetry = time.Second
+	setWebhookMaxRetry   = 5 * time.Minute
+)
+
+// NewPoller picks how updates are received according to the config
+func NewPoller(cfg config.UpdatesConfig) tele.Poller {
+	if cfg.Mode == config.UpdateModeWebhook {
+		return &WebhookPoller{cfg: cfg.Webhook}
+	}
+	return &longPoller{LongPoller: &tele.LongPoller{Timeout: cfg.PollTimeout}}
+}
+
+// longPoller removes a webhook left over from webhook mode, since Telegram
+// refuses getUpdates while one is set
+type longPoller struct {
+	*tele.LongPoller
+}
+
+func (p *longPoller) Poll(b *tele.Bot, dest chan tele.Update, stop chan struct{}) {
+	if err := b.RemoveWebhook(); err != nil {
+		slog.Error("Failed to remove webhook before polling", "error", err)
+	}
+	p.LongPoller.Poll(b, dest, stop)
+}
+
+// WebhookPoller registers the webhook with Telegram and serves it until the
+// bot stops. The webhook is left registered on stop so updates queue up
+// until the next start.
+type WebhookPoller struct {
+	cfg config.WebhookConfig
+}
+
+func (p *WebhookPoller) Poll(b *tele.Bot, dest chan tele.Update, stop chan struct{}) {
+	webhook := &tele.Webhook{
+		SecretToken: p.cfg.SecretToken,
+		Endpoint:    &tele.WebhookEndpoint{PublicURL: p.cfg.PublicURL, Cert: p.cfg.TLSCertFile},
+	}
+	// Without the webhook no updates arrive at all, so keep trying rather
+	// than leave the bot running deaf
+	set := retryWithBackoff(stop, setWebhookFirstRetry, setWebhookMaxRetry, func() error {
+		err := b.SetWebhook(webhook)
+		if err != nil {
+			slog.Error("Failed to set webhook, retrying", "url", p.cfg.PublicURL, "error", err)
+		}
+		return err
+	})
+	if !set {
+		return
+	}
+
+	server := &http.Server{
+		Addr:              p.cfg.ListenAddr,
+		Handler:           NewWebhookHandler(p.cfg.SecretToken, dest, stop),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	served := make(chan error, 1)
+	go func() {
+		if p.cfg.TLSCertFile != "" {
+			served <- server.ListenAndServeTLS(p.cfg.TLSCertFile, p.cfg.TLSKeyFile)
+		} else {
+			served <- server.ListenAndServe()
+		}
+	}()
+	slog.Info("Receiving updates by webhook", "listen", p.cfg.ListenAddr, "url", p.cfg.PublicURL)
+
+	select {
+	case err := <-served:
+		slog.Error("Webhook server stopped", "error", err)
+	case <-stop:
+		ctx, cancel := context.WithTimeout(context.Background(), webhookShutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(ctx); err != nil {
+			slog.Error("Failed to shut down webhook server", "error", err)
+		}
+	}
+}
+
+// retryWithBackoff calls try until it succeeds, doubling the wait between
+// attempts up to maxWait. It gives up and reports false once stop closes.
+func retryWithBackoff(stop <-chan struct{}, wait, maxWait time.Duration, try func() error) bool {
+	for {
+		if try() == nil {
+			return true
+		}
+		select {
+		case <-stop:
+			return false
+		case <-time.After(wait):
+		}
+		wait = min(wait*2, maxWait)
+	}
+}
+
+// WebhookHandler accepts the updates Telegram posts and hands them to the bot
+type WebhookHandler struct {
+	secretToken string
+	dest        chan<- tele.Update
+	done        <-chan struct{}
+}
+
+func NewWebhookHandler(secretToken string, dest chan<- tele.Update, done <-chan struct{}) *WebhookHandler {
+	return &WebhookHandler{secretToken: secretToken, dest: dest, done: done}
+}
+
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	token := r.Header.Get(SecretTokenHeader)
+	if subtle.ConstantTimeCompare([]byte(token), []byte(h.secretToken)) != 1 {
+		slog.Warn("Rejected webhook request with wrong secret token", "remote", r.RemoteAddr)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var update tele.Update
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxUpdateSize)).Decode(&update); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "update too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "malformed update", http.StatusBadRequest)
+		return
+	}
+
+	// Telegram retries anything that isn't a 2xx, so an update that arrives
+	// while shutting down is redelivered after the restart
+	select {
+	case h.dest <- update:
+		w.WriteHeader(http.StatusOK)
+	case <-h.done:
+		http.Error(w, "shutting down", http.StatusServiceUnavailable)
+	case <-r.Context().Done():
+	}
+}
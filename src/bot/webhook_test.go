@@ -0,0 +1,79 @@
+package bot
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+func TestWebhookHandler(t *testing.T) {
+	dest := make(chan tele.Update, 1)
+	done := make(chan struct{})
+	handler := NewWebhookHandler("s3cret", dest, done)
+
+	post := func(method, secret, body string) int {
+		req := httptest.NewRequest(method, "/", strings.NewReader(body))
+		req.Header.Set(SecretTokenHeader, secret)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	update := `{"update_id":7,"message":{"message_id":1,"text":"/start","chat":{"id":5,"type":"private"},"from":{"id":5}}}`
+
+	if code := post(http.MethodPost, "wrong", update); code != http.StatusUnauthorized {
+		t.Errorf("wrong secret: status = %d, want 401", code)
+	}
+	if code := post(http.MethodGet, "s3cret", ""); code != http.StatusMethodNotAllowed {
+		t.Errorf("GET: status = %d, want 405", code)
+	}
+	if code := post(http.MethodPost, "s3cret", "{not json"); code != http.StatusBadRequest {
+		t.Errorf("malformed update: status = %d, want 400", code)
+	}
+	if len(dest) != 0 {
+		t.Fatal("rejected requests reached the bot")
+	}
+
+	if code := post(http.MethodPost, "s3cret", update); code != http.StatusOK {
+		t.Fatalf("valid update: status = %d, want 200", code)
+	}
+	if got := <-dest; got.ID != 7 || got.Message == nil || got.Message.Text != "/start" {
+		t.Errorf("forwarded update = %+v, want update 7 with /start", got)
+	}
+
+	close(done)
+	dest <- tele.Update{}
+	if code := post(http.MethodPost, "s3cret", update); code != http.StatusServiceUnavailable {
+		t.Errorf("while stopping: status = %d, want 503 so Telegram retries", code)
+	}
+}
+
+func TestRetryWithBackoff(t *testing.T) {
+	stop := make(chan struct{})
+	attempts := 0
+	ok := retryWithBackoff(stop, time.Millisecond, 4*time.Millisecond, func() error {
+		attempts++
+		if attempts < 4 {
+			return errors.New("telegram unreachable")
+		}
+		return nil
+	})
+	if !ok || attempts != 4 {
+		t.Errorf("retry = %v after %d attempts, want success after 4", ok, attempts)
+	}
+
+	close(stop)
+	attempts = 0
+	ok = retryWithBackoff(stop, time.Millisecond, time.Millisecond, func() error {
+		attempts++
+		return errors.New("telegram unreachable")
+	})
+	if ok || attempts != 1 {
+		t.Errorf("retry after stop = %v after %d attempts, want to give up after 1", ok, attempts)
+	}
+}
@@ -1,35 +1,134 @@
 package main
 
 import (
-	"log"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
 	"tarantulago/bot"
 	"tarantulago/config"
 	"tarantulago/db"
+	"tarantulago/logging"
+	"tarantulago/metrics"
 )
 
 func main() {
-	cfg, err := config.LoadConfig()
+	args := os.Args[1:]
+	if len(args) > 0 && args[0] == "config" {
+		if len(args) < 2 || args[1] != "print" {
+			fmt.Fprintln(os.Stderr, "usage: bot config print [flags]")
+			os.Exit(2)
+		}
+		os.Exit(printConfig(args[2:]))
+	}
+
+	cfg, err := config.Load(args, os.LookupEnv)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err == nil {
+		err = cfg.Validate()
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	level, _ := config.ParseLogLevel(cfg.LogLevel)
+	logger, err := logging.New(os.Stderr, logging.Options{Format: cfg.LogFormat, Level: level, UserIDKey: cfg.LogUserIDKey})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	slog.SetDefault(logger)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, cfg); err != nil {
+		slog.Error(err.Error())
+		stop()
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, cfg *config.Config) error {
+	defaults := cfg.UserDefaults.Settings()
+	database, err := db.NewTarantulaDB(ctx, cfg.Database.URL, db.Options{
+		MaxOpenConns:       cfg.Database.MaxOpenConns,
+		MaxIdleConns:       cfg.Database.MaxIdleConns,
+		ConnMaxLifetime:    cfg.Database.ConnMaxLifetime,
+		SlowQueryThreshold: cfg.Database.SlowQueryThreshold,
+		DefaultSettings:    &defaults,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer func() {
+		if err := database.Close(); err != nil {
+			slog.Error("Failed to close database", "error", err)
+		}
+	}()
+
+	tarantulaBot, err := bot.NewTarantulaBot(ctx, cfg, database)
 	if err != nil {
-		log.Fatal("Failed to load config:", err)
+		return fmt.Errorf("failed to create bot: %w", err)
+	}
+
+	if cfg.Metrics.ListenAddr != "" {
+		// The listener outlives the signal so probes see the bot draining
+		metricsCtx, stopMetrics := context.WithCancel(context.WithoutCancel(ctx))
+		defer stopMetrics()
+		go serveMetrics(metricsCtx, ctx, cfg.Metrics.ListenAddr, database, tarantulaBot)
 	}
 
-	switch cfg.LogLevel {
-	case "debug":
-		log.SetFlags(log.Ldate | log.Ltime | log.Lmicroseconds | log.Llongfile)
-	case "info":
-		log.SetFlags(log.Ldate | log.Ltime)
+	slog.Info("Starting tarantula management bot", "update_mode", cfg.Updates.Mode)
+	if err := tarantulaBot.Run(ctx, cfg.ShutdownTimeout); err != nil {
+		return fmt.Errorf("shutdown did not finish cleanly: %w", err)
 	}
+	slog.Info("Stopped")
+	return nil
+}
 
-	database, err := db.NewTarantulaDB(cfg.PostgresURL)
+func serveMetrics(ctx, running context.Context, addr string, database *db.TarantulaDB, tarantulaBot *bot.TarantulaBot) {
+	err := metrics.Serve(ctx, addr,
+		metrics.Check{Name: "shutdown", Run: func(context.Context) error {
+			if running.Err() != nil {
+				return errors.New("shutting down")
+			}
+			return nil
+		}},
+		metrics.Check{Name: "database", Liveness: true, Run: database.Ping},
+		metrics.Check{Name: "telegram", Run: tarantulaBot.Ping},
+	)
 	if err != nil {
-		log.Fatal("Failed to initialize database:", err)
+		slog.Error("Metrics server stopped", "error", err)
 	}
+}
 
-	tarantulaBot, err := bot.NewTarantulaBot(cfg.TelegramToken, database)
+// printConfig shows the effective config with secrets masked, followed by
+// anything that would stop the bot from starting
+func printConfig(args []string) int {
+	cfg, err := config.Load(args, os.LookupEnv)
+	if errors.Is(err, flag.ErrHelp) {
+		return 0
+	}
 	if err != nil {
-		log.Fatal("Failed to create tarantulaBot:", err)
+		fmt.Fprintln(os.Stderr, err)
+		return 1
 	}
 
-	log.Println("Starting tarantula management tarantulaBot...")
-	tarantulaBot.Start()
+	if err := cfg.Print(os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
 }
@@ -0,0 +1,54 @@
+// Command moltbacktest replays historical molt records through the molt
+// prediction model and reports how far its predictions were from the molts
+// that actually happened.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"tarantulago/db"
+	"text/tabwriter"
+)
+
+func main() {
+	postgresURL := flag.String("postgres", os.Getenv("POSTGRES_URL"), "PostgreSQL connection string (defaults to POSTGRES_URL)")
+	flag.Parse()
+
+	if *postgresURL == "" {
+		log.Fatal("POSTGRES_URL environment variable is not set")
+	}
+
+	database, err := db.NewTarantulaDB(context.Background(), *postgresURL, db.Options{})
+	if err != nil {
+		log.Fatal("Failed to initialize database:", err)
+	}
+
+	report, err := database.BacktestMoltPredictions(context.Background())
+	if err != nil {
+		log.Fatal("Failed to backtest molt predictions:", err)
+	}
+
+	if report.Overall.Predictions == 0 {
+		fmt.Println("No consecutive molt records to evaluate.")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "Species\tSize\tN\tMAE (days)\tBias (days)\tHit rate\t")
+	for _, g := range report.Groups {
+		printStats(w, g.SpeciesName, g.Bracket, g)
+	}
+	printStats(w, "All", "", report.Overall)
+	w.Flush()
+
+	fmt.Printf("\nHit rate is the share of molts inside the %.0f%% prediction window; "+
+		"positive bias means molts came earlier than predicted.\n", db.MoltConfidenceLevel*100)
+}
+
+func printStats(w *tabwriter.Writer, species, bracket string, s db.MoltBacktestStats) {
+	fmt.Fprintf(w, "%s\t%s\t%d\t%.1f\t%+.1f\t%.0f%%\t\n",
+		species, bracket, s.Predictions, s.MAE(), s.Bias(), s.HitRate()*100)
+}
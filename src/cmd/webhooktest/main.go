@@ -0,0 +1,113 @@
+// Command webhooktest posts synthetic Telegram updates to a bot running in
+// webhook mode, so the webhook can be tried locally without Telegram. Point it
+// at the bot's listen address, e.g.
+//
+//	webhooktest -url http://localhost:8443/ -user 123456789 -text /start
+//	webhooktest -user 123456789 -callback "1|select|1"
+package main
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"tarantulago/bot"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+func main() {
+	target := flag.String("url", "http://localhost:8443/", "webhook address the bot listens on")
+	secret := flag.String("secret", os.Getenv("WEBHOOK_SECRET_TOKEN"), "secret token (defaults to WEBHOOK_SECRET_TOKEN)")
+	userID := flag.Int64("user", 0, "Telegram user ID the update comes from")
+	chatID := flag.Int64("chat", 0, "chat ID (defaults to the user's private chat)")
+	text := flag.String("text", "", "message text to send; each line is a separate update")
+	callback := flag.String("callback", "", "callback data to press instead of sending text, e.g. 1|select|1")
+	insecure := flag.Bool("insecure", false, "accept the bot's self-signed TLS certificate")
+	flag.Parse()
+
+	if *userID == 0 {
+		log.Fatal("-user is required")
+	}
+	if *chatID == 0 {
+		*chatID = *userID
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	if *insecure {
+		client.Transport = &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+	}
+
+	sender := &tele.User{ID: *userID, FirstName: "Tester"}
+	chat := &tele.Chat{ID: *chatID, Type: tele.ChatPrivate}
+	if *chatID != *userID {
+		chat.Type = tele.ChatSuperGroup
+	}
+
+	var updates []tele.Update
+	updateID := int(time.Now().Unix())
+	switch {
+	case *callback != "":
+		updates = append(updates, tele.Update{
+			ID: updateID,
+			Callback: &tele.Callback{
+				ID:      fmt.Sprint(updateID),
+				Sender:  sender,
+				Message: &tele.Message{ID: 1, Chat: chat, Sender: sender, Unixtime: time.Now().Unix()},
+				Data:    *callback,
+			},
+		})
+	case *text != "":
+		for i, line := range strings.Split(*text, "\n") {
+			updates = append(updates, tele.Update{
+				ID: updateID + i,
+				Message: &tele.Message{
+					ID:       updateID + i,
+					Sender:   sender,
+					Chat:     chat,
+					Text:     line,
+					Unixtime: time.Now().Unix(),
+				},
+			})
+		}
+	default:
+		log.Fatal("either -text or -callback is required")
+	}
+
+	for _, update := range updates {
+		if err := post(client, *target, *secret, update); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+func post(client *http.Client, target, secret string, update tele.Update) error {
+	body, err := json.Marshal(update)
+	if err != nil {
+		return fmt.Errorf("failed to encode update: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(bot.SecretTokenHeader, secret)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post update %d: %w", update.ID, err)
+	}
+	defer resp.Body.Close()
+
+	reply, _ := io.ReadAll(resp.Body)
+	fmt.Printf("update %d: %s %s\n", update.ID, resp.Status, strings.TrimSpace(string(reply)))
+	return nil
+}
@@ -2,34 +2,308 @@ package config
 
 import (
 	"fmt"
-	"os"
+	"io"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"strings"
+	"tarantulago/logging"
+	"tarantulago/models"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// How the bot receives updates from Telegram
+const (
+	UpdateModePolling = "polling"
+	UpdateModeWebhook = "webhook"
 )
 
 type Config struct {
-	TelegramToken string
-	PostgresURL   string
-	LogLevel      string
+	TelegramToken string `yaml:"telegram_token"`
+	LogLevel      string `yaml:"log_level"`
+	LogFormat     string `yaml:"log_format"`
+	// LogUserIDKey keys the hash user IDs are logged as; without it hashes
+	// change on every restart
+	LogUserIDKey    string        `yaml:"log_user_id_key"`
+	AdminUserIDs    []int64       `yaml:"admin_user_ids"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+
+	Database      DatabaseConfig     `yaml:"database"`
+	Updates       UpdatesConfig      `yaml:"updates"`
+	Notifications NotificationConfig `yaml:"notifications"`
+	Metrics       MetricsConfig      `yaml:"metrics"`
+	UserDefaults  UserDefaults       `yaml:"user_defaults"`
+	Features      Features           `yaml:"features"`
+}
+
+// DatabaseConfig sizes the connection pool; zero keeps the driver's default
+type DatabaseConfig struct {
+	URL             string        `yaml:"url"`
+	MaxOpenConns    int           `yaml:"max_open_conns"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+	// SlowQueryThreshold is when a statement gets logged as slow; zero
+	// turns that off
+	SlowQueryThreshold time.Duration `yaml:"slow_query_threshold"`
+}
+
+type UpdatesConfig struct {
+	Mode        string        `yaml:"mode"`
+	PollTimeout time.Duration `yaml:"poll_timeout"`
+	Webhook     WebhookConfig `yaml:"webhook"`
+}
+
+// WebhookConfig is where Telegram pushes updates in webhook mode. The cert
+// and key are only needed when the bot terminates TLS itself instead of
+// sitting behind a proxy.
+type WebhookConfig struct {
+	ListenAddr  string `yaml:"listen_addr"`
+	PublicURL   string `yaml:"public_url"`
+	SecretToken string `yaml:"secret_token"`
+	TLSCertFile string `yaml:"tls_cert"`
+	TLSKeyFile  string `yaml:"tls_key"`
+}
+
+type NotificationConfig struct {
+	// TickInterval is how often the scheduler looks for notifications due
+	TickInterval time.Duration `yaml:"tick_interval"`
+}
+
+// MetricsConfig is where /metrics, /healthz and /readyz are served; empty
+// turns the listener off
+type MetricsConfig struct {
+	ListenAddr string `yaml:"listen_addr"`
+}
+
+// UserDefaults are the settings a new user starts with
+type UserDefaults struct {
+	NotificationTimeUTC string `yaml:"notification_time_utc"`
+	FeedingReminderDays int    `yaml:"feeding_reminder_days"`
+	LowColonyThreshold  int    `yaml:"low_colony_threshold"`
+	MoltPredictionDays  int    `yaml:"molt_prediction_days"`
+	PostMoltMuteDays    int    `yaml:"post_molt_mute_days"`
+}
+
+// Features switches optional parts of the bot on or off per deployment
+type Features struct {
+	Sharing         bool `yaml:"sharing"`
+	GroupChats      bool `yaml:"group_chats"`
+	MoltPredictions bool `yaml:"molt_predictions"`
 }
 
-func LoadConfig() (*Config, error) {
-	token := os.Getenv("TELEGRAM_BOT_TOKEN")
-	if token == "" {
-		return nil, fmt.Errorf("TELEGRAM_BOT_TOKEN environment variable is not set")
+// Defaults is the config before any file, environment variable or flag
+func Defaults() Config {
+	return Config{
+		LogLevel:        "info",
+		LogFormat:       logging.FormatText,
+		ShutdownTimeout: 30 * time.Second,
+		Database: DatabaseConfig{
+			MaxOpenConns:       10,
+			MaxIdleConns:       5,
+			ConnMaxLifetime:    30 * time.Minute,
+			SlowQueryThreshold: 200 * time.Millisecond,
+		},
+		Updates: UpdatesConfig{
+			Mode:        UpdateModePolling,
+			PollTimeout: 10 * time.Second,
+			Webhook:     WebhookConfig{ListenAddr: ":8443"},
+		},
+		Notifications: NotificationConfig{TickInterval: time.Minute},
+		UserDefaults: UserDefaults{
+			NotificationTimeUTC: "12:00",
+			FeedingReminderDays: 7,
+			LowColonyThreshold:  50,
+			MoltPredictionDays:  5,
+			PostMoltMuteDays:    7,
+		},
+		Features: Features{
+			Sharing:         true,
+			GroupChats:      true,
+			MoltPredictions: true,
+		},
+	}
+}
+
+// ValidationError lists every problem found in a config at once
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid config:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// Validate checks the whole config and reports all problems together
+func (c *Config) Validate() error {
+	var problems []string
+	add := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if c.TelegramToken == "" {
+		add("telegram_token is required")
+	}
+	if _, err := ParseLogLevel(c.LogLevel); err != nil {
+		add("log_level: %v", err)
+	}
+	if c.LogFormat != logging.FormatText && c.LogFormat != logging.FormatJSON {
+		add("log_format %q: use %q or %q", c.LogFormat, logging.FormatText, logging.FormatJSON)
+	}
+	for _, id := range c.AdminUserIDs {
+		if id <= 0 {
+			add("admin_user_ids: %d is not a user ID", id)
+		}
+	}
+	if c.ShutdownTimeout <= 0 {
+		add("shutdown_timeout must be positive")
 	}
 
-	dbPath := os.Getenv("POSTGRES_URL")
-	if dbPath == "" {
-		return nil, fmt.Errorf("POSTGRES_URL environment variable is not set")
+	if c.Database.URL == "" {
+		add("database.url is required")
+	}
+	if c.Database.MaxOpenConns < 0 || c.Database.MaxIdleConns < 0 || c.Database.ConnMaxLifetime < 0 || c.Database.SlowQueryThreshold < 0 {
+		add("database pool settings can't be negative")
+	}
+	if c.Database.MaxOpenConns > 0 && c.Database.MaxIdleConns > c.Database.MaxOpenConns {
+		add("database.max_idle_conns (%d) is more than database.max_open_conns (%d)", c.Database.MaxIdleConns, c.Database.MaxOpenConns)
+	}
+
+	switch c.Updates.Mode {
+	case UpdateModePolling:
+		if c.Updates.PollTimeout <= 0 {
+			add("updates.poll_timeout must be positive")
+		}
+	case UpdateModeWebhook:
+		problems = append(problems, c.Updates.Webhook.problems()...)
+	default:
+		add("updates.mode %q: use %q or %q", c.Updates.Mode, UpdateModePolling, UpdateModeWebhook)
 	}
 
-	logLevel := os.Getenv("LOG_LEVEL")
-	if logLevel == "" {
-		logLevel = "info"
+	if tick := c.Notifications.TickInterval; tick < 10*time.Second || tick > time.Hour {
+		add("notifications.tick_interval %s: use between 10s and 1h", tick)
 	}
 
-	return &Config{
-		TelegramToken: token,
-		PostgresURL:   dbPath,
-		LogLevel:      logLevel,
-	}, nil
+	if _, err := time.Parse("15:04", c.UserDefaults.NotificationTimeUTC); err != nil {
+		add("user_defaults.notification_time_utc %q: use HH:MM", c.UserDefaults.NotificationTimeUTC)
+	}
+	if c.UserDefaults.FeedingReminderDays < 1 || c.UserDefaults.MoltPredictionDays < 1 ||
+		c.UserDefaults.PostMoltMuteDays < 0 || c.UserDefaults.LowColonyThreshold < 0 {
+		add("user_defaults: reminder and prediction days must be at least 1, mute days and colony threshold can't be negative")
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// Validate checks the webhook settings against what Telegram accepts
+func (w WebhookConfig) Validate() error {
+	if problems := w.problems(); len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+func (w WebhookConfig) problems() []string {
+	var problems []string
+
+	if w.PublicURL == "" {
+		problems = append(problems, "updates.webhook.public_url is required in webhook mode")
+	} else if u, err := url.Parse(w.PublicURL); err != nil || u.Scheme != "https" || u.Host == "" {
+		problems = append(problems, fmt.Sprintf("updates.webhook.public_url %q: Telegram only delivers to https URLs", w.PublicURL))
+	}
+
+	if w.SecretToken == "" {
+		problems = append(problems, "updates.webhook.secret_token is required in webhook mode")
+	} else if len(w.SecretToken) > 256 {
+		problems = append(problems, "updates.webhook.secret_token: at most 256 characters")
+	} else if strings.IndexFunc(w.SecretToken, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-')
+	}) >= 0 {
+		problems = append(problems, "updates.webhook.secret_token: only letters, digits, _ and - are allowed")
+	}
+
+	if (w.TLSCertFile == "") != (w.TLSKeyFile == "") {
+		problems = append(problems, "updates.webhook.tls_cert and tls_key must be set together")
+	}
+	return problems
+}
+
+// ParseLogLevel maps a log_level setting onto a slog level
+func ParseLogLevel(level string) (slog.Level, error) {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return 0, fmt.Errorf("%q: use debug, info, warn or error", level)
+	}
+	return l, nil
+}
+
+// Settings are the user settings a new user starts with
+func (d UserDefaults) Settings() models.UserSettings {
+	settings := models.UserSettings{}.Defaults()
+	settings.NotificationTimeUTC = d.NotificationTimeUTC
+	settings.FeedingReminderDays = d.FeedingReminderDays
+	settings.LowColonyThreshold = d.LowColonyThreshold
+	settings.MoltPredictionEnabled = true
+	settings.MoltPredictionDays = d.MoltPredictionDays
+	settings.PostMoltMuteDays = d.PostMoltMuteDays
+	return settings
+}
+
+const mask = "********"
+
+// Masked returns a copy safe to show: the token, the database password, the
+// webhook secret and the user ID hash key are hidden
+func (c Config) Masked() Config {
+	if c.TelegramToken != "" {
+		c.TelegramToken = mask
+	}
+	c.Database.URL = maskDatabaseURL(c.Database.URL)
+	if c.LogUserIDKey != "" {
+		c.LogUserIDKey = mask
+	}
+	if c.Updates.Webhook.SecretToken != "" {
+		c.Updates.Webhook.SecretToken = mask
+	}
+	c.AdminUserIDs = append([]int64(nil), c.AdminUserIDs...)
+	return c
+}
+
+// passwordParams are the connection settings that hold a password
+var passwordParams = []string{"password", "sslpassword"}
+
+// keywordPassword matches the passwords in a key=value connection string
+var keywordPassword = regexp.MustCompile(`(\b(?:ssl)?password\s*=\s*)('(?:[^'\\]|\\.)*'|\S+)`)
+
+// maskDatabaseURL hides the passwords in a connection string, whether in the
+// URL's user info, its query or key=value settings
+func maskDatabaseURL(dsn string) string {
+	if !strings.Contains(dsn, "://") {
+		return keywordPassword.ReplaceAllString(dsn, "${1}"+mask)
+	}
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return mask
+	}
+	query := u.Query()
+	for _, param := range passwordParams {
+		if query.Has(param) {
+			query.Set(param, mask)
+		}
+	}
+	u.RawQuery = query.Encode()
+	return u.Redacted()
+}
+
+// Print writes the effective config as YAML with secrets masked
+func (c Config) Print(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(c.Masked()); err != nil {
+		return fmt.Errorf("failed to print config: %w", err)
+	}
+	return enc.Close()
 }
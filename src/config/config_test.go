@@ -0,0 +1,129 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWebhookConfigValidate(t *testing.T) {
+	valid := WebhookConfig{ListenAddr: ":8443", PublicURL: "https://bot.example.com/hook", SecretToken: "abc_DEF-123"}
+
+	tests := []struct {
+		name    string
+		modify  func(w *WebhookConfig)
+		wantErr bool
+	}{
+		{"valid", func(w *WebhookConfig) {}, false},
+		{"with tls", func(w *WebhookConfig) { w.TLSCertFile, w.TLSKeyFile = "cert.pem", "key.pem" }, false},
+		{"missing url", func(w *WebhookConfig) { w.PublicURL = "" }, true},
+		{"plain http", func(w *WebhookConfig) { w.PublicURL = "http://bot.example.com/hook" }, true},
+		{"missing secret", func(w *WebhookConfig) { w.SecretToken = "" }, true},
+		{"secret with spaces", func(w *WebhookConfig) { w.SecretToken = "not allowed" }, true},
+		{"cert without key", func(w *WebhookConfig) { w.TLSCertFile = "cert.pem" }, true},
+	}
+
+	for _, tt := range tests {
+		w := valid
+		tt.modify(&w)
+		if err := w.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: err = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "bot.yaml")
+	err := os.WriteFile(file, []byte(`
+log_level: warn
+database:
+  url: postgres://file
+  max_open_conns: 20
+notifications:
+  tick_interval: 30s
+features:
+  sharing: false
+`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	env := map[string]string{
+		"CONFIG_FILE":  file,
+		"POSTGRES_URL": "postgres://env",
+		"LOG_LEVEL":    "debug",
+	}
+	lookup := func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}
+
+	cfg, err := Load([]string{"-log-level", "error", "-feature-sharing"}, lookup)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Database.MaxOpenConns != 20 || cfg.Notifications.TickInterval != 30*time.Second {
+		t.Errorf("file values not applied: %+v", cfg)
+	}
+	if cfg.Database.URL != "postgres://env" {
+		t.Errorf("database url = %q, want the environment to override the file", cfg.Database.URL)
+	}
+	if cfg.LogLevel != "error" || !cfg.Features.Sharing {
+		t.Errorf("log level %q, sharing %v: want flags to override everything", cfg.LogLevel, cfg.Features.Sharing)
+	}
+	if cfg.Database.MaxIdleConns != 5 {
+		t.Errorf("max idle conns = %d, want the default kept", cfg.Database.MaxIdleConns)
+	}
+}
+
+func TestValidateListsAllProblems(t *testing.T) {
+	cfg := Defaults()
+	cfg.LogLevel = "loud"
+	cfg.Updates.Mode = UpdateModeWebhook
+
+	var verr *ValidationError
+	if !errors.As(cfg.Validate(), &verr) {
+		t.Fatal("want a ValidationError")
+	}
+	// token, database url, log level, webhook url and webhook secret
+	if len(verr.Problems) != 5 {
+		t.Errorf("problems = %q, want 5", verr.Problems)
+	}
+}
+
+func TestMasked(t *testing.T) {
+	cfg := Defaults()
+	cfg.TelegramToken = "123:secret"
+	cfg.Database.URL = "postgres://bot:hunter2@db/tarantulas"
+	cfg.Updates.Webhook.SecretToken = "s3cret"
+
+	masked := cfg.Masked()
+	if strings.Contains(masked.TelegramToken+masked.Database.URL+masked.Updates.Webhook.SecretToken, "secret") ||
+		strings.Contains(masked.Database.URL, "hunter2") {
+		t.Errorf("secrets leaked: %+v", masked)
+	}
+	if !strings.Contains(masked.Database.URL, "bot:") || !strings.Contains(masked.Database.URL, "@db/tarantulas") {
+		t.Errorf("database url = %q, want only the password hidden", masked.Database.URL)
+	}
+
+	for _, dsn := range []string{
+		"postgres://bot:hunter2@db/x",
+		"postgres://bot@db/x?password=hunter2",
+		"postgres://bot@db/x?sslmode=verify-full&sslpassword=hunter2",
+		"host=db user=bot password=hunter2 dbname=x",
+		"host=db user=bot password='hunter2 \\' x' dbname=x",
+		"host=db sslkey=/k sslpassword=hunter2 dbname=x",
+	} {
+		got := maskDatabaseURL(dsn)
+		if strings.Contains(got, "hunter2") {
+			t.Errorf("%s: password leaked: %q", dsn, got)
+		}
+		if !strings.Contains(got, "db") {
+			t.Errorf("%s: masked to %q, want only the password hidden", dsn, got)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// A config is built from defaults, then a YAML file, then environment
+// variables, then command-line flags, each overriding the one before.
+
+// setting binds one config value to its environment variable and flag
+type setting struct {
+	env   string
+	flag  string
+	usage string
+	set   setter
+}
+
+// setter parses a value into its config field
+type setter struct {
+	parse  func(c *Config, value string) error
+	isBool bool
+}
+
+var settings = []setting{
+	{"TELEGRAM_BOT_TOKEN", "token", "Telegram bot token", str(func(c *Config) *string { return &c.TelegramToken })},
+	{"LOG_LEVEL", "log-level", "debug, info, warn or error", str(func(c *Config) *string { return &c.LogLevel })},
+	{"LOG_FORMAT", "log-format", "text or json", str(func(c *Config) *string { return &c.LogFormat })},
+	{"LOG_USER_ID_KEY", "log-user-id-key", "key for hashing user IDs in logs, keeps hashes stable across restarts", str(func(c *Config) *string { return &c.LogUserIDKey })},
+	{"ADMIN_USER_IDS", "admins", "comma-separated Telegram users who review proposed species", ids(func(c *Config) *[]int64 { return &c.AdminUserIDs })},
+	{"SHUTDOWN_TIMEOUT", "shutdown-timeout", "how long handlers and reminders may take to finish on stop", duration(func(c *Config) *time.Duration { return &c.ShutdownTimeout })},
+
+	{"POSTGRES_URL", "postgres", "PostgreSQL connection string", str(func(c *Config) *string { return &c.Database.URL })},
+	{"DB_MAX_OPEN_CONNS", "db-max-open-conns", "most open database connections (0 for no limit)", integer(func(c *Config) *int { return &c.Database.MaxOpenConns })},
+	{"DB_MAX_IDLE_CONNS", "db-max-idle-conns", "most idle database connections kept", integer(func(c *Config) *int { return &c.Database.MaxIdleConns })},
+	{"DB_CONN_MAX_LIFETIME", "db-conn-max-lifetime", "how long a database connection is reused (0 for ever)", duration(func(c *Config) *time.Duration { return &c.Database.ConnMaxLifetime })},
+
+	{"DB_SLOW_QUERY_THRESHOLD", "db-slow-query-threshold", "log statements slower than this as warnings (0 to turn off)", duration(func(c *Config) *time.Duration { return &c.Database.SlowQueryThreshold })},
+
+	{"UPDATE_MODE", "update-mode", "polling or webhook", str(func(c *Config) *string { return &c.Updates.Mode })},
+	{"POLL_TIMEOUT", "poll-timeout", "long polling timeout", duration(func(c *Config) *time.Duration { return &c.Updates.PollTimeout })},
+	{"WEBHOOK_LISTEN_ADDR", "webhook-listen", "address the webhook server listens on", str(func(c *Config) *string { return &c.Updates.Webhook.ListenAddr })},
+	{"WEBHOOK_PUBLIC_URL", "webhook-url", "HTTPS address Telegram posts updates to", str(func(c *Config) *string { return &c.Updates.Webhook.PublicURL })},
+	{"WEBHOOK_SECRET_TOKEN", "webhook-secret", "secret token Telegram sends with each update", str(func(c *Config) *string { return &c.Updates.Webhook.SecretToken })},
+	{"WEBHOOK_TLS_CERT", "webhook-tls-cert", "TLS certificate file for serving the webhook directly", str(func(c *Config) *string { return &c.Updates.Webhook.TLSCertFile })},
+	{"WEBHOOK_TLS_KEY", "webhook-tls-key", "TLS key file for serving the webhook directly", str(func(c *Config) *string { return &c.Updates.Webhook.TLSKeyFile })},
+
+	{"NOTIFICATION_TICK_INTERVAL", "notification-tick", "how often due notifications are checked", duration(func(c *Config) *time.Duration { return &c.Notifications.TickInterval })},
+
+	{"METRICS_LISTEN_ADDR", "metrics-listen", "address serving /metrics, /healthz and /readyz (off when empty)", str(func(c *Config) *string { return &c.Metrics.ListenAddr })},
+
+	{"DEFAULT_NOTIFICATION_TIME", "default-notification-time", "new users' notification time, HH:MM UTC", str(func(c *Config) *string { return &c.UserDefaults.NotificationTimeUTC })},
+	{"DEFAULT_FEEDING_REMINDER_DAYS", "default-feeding-reminder-days", "new users' feeding reminder days", integer(func(c *Config) *int { return &c.UserDefaults.FeedingReminderDays })},
+	{"DEFAULT_LOW_COLONY_THRESHOLD", "default-low-colony-threshold", "new users' low feeder colony threshold", integer(func(c *Config) *int { return &c.UserDefaults.LowColonyThreshold })},
+	{"DEFAULT_MOLT_PREDICTION_DAYS", "default-molt-prediction-days", "new users' molt prediction lead days", integer(func(c *Config) *int { return &c.UserDefaults.MoltPredictionDays })},
+	{"DEFAULT_POST_MOLT_MUTE_DAYS", "default-post-molt-mute-days", "new users' post-molt feeding mute days", integer(func(c *Config) *int { return &c.UserDefaults.PostMoltMuteDays })},
+
+	{"FEATURE_SHARING", "feature-sharing", "allow sharing collections and sitters", boolean(func(c *Config) *bool { return &c.Features.Sharing })},
+	{"FEATURE_GROUP_CHATS", "feature-group-chats", "allow linking group chats", boolean(func(c *Config) *bool { return &c.Features.GroupChats })},
+	{"FEATURE_MOLT_PREDICTIONS", "feature-molt-predictions", "send molt prediction notifications", boolean(func(c *Config) *bool { return &c.Features.MoltPredictions })},
+}
+
+// Load reads the config for the given command-line arguments and environment.
+// The file comes from -config or CONFIG_FILE. Values are parsed but not
+// validated; call Validate before using them.
+func Load(args []string, lookupEnv func(string) (string, bool)) (*Config, error) {
+	fs := flag.NewFlagSet("tarantulago", flag.ContinueOnError)
+	configFile := fs.String("config", "", "YAML config file (defaults to CONFIG_FILE)")
+
+	flagValues := make(map[string]string)
+	for _, s := range settings {
+		name := s.flag
+		usage := fmt.Sprintf("%s (env %s)", s.usage, s.env)
+		record := func(value string) error {
+			flagValues[name] = value
+			return nil
+		}
+		if s.set.isBool {
+			fs.BoolFunc(name, usage, record)
+		} else {
+			fs.Func(name, usage, record)
+		}
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	cfg := Defaults()
+
+	path := *configFile
+	if path == "" {
+		path, _ = lookupEnv("CONFIG_FILE")
+	}
+	if path != "" {
+		if err := loadFile(&cfg, path); err != nil {
+			return nil, err
+		}
+	}
+
+	var problems []string
+	for _, s := range settings {
+		if value, ok := lookupEnv(s.env); ok && value != "" {
+			if err := s.set.parse(&cfg, value); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %v", s.env, err))
+			}
+		}
+	}
+	for _, s := range settings {
+		if value, ok := flagValues[s.flag]; ok {
+			if err := s.set.parse(&cfg, value); err != nil {
+				problems = append(problems, fmt.Sprintf("-%s: %v", s.flag, err))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return nil, &ValidationError{Problems: problems}
+	}
+
+	return &cfg, nil
+}
+
+func loadFile(cfg *Config, path string) error {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+	default:
+		return fmt.Errorf("config file %s: only YAML (.yaml, .yml) is supported", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+func str(field func(*Config) *string) setter {
+	return setter{parse: func(c *Config, value string) error {
+		*field(c) = value
+		return nil
+	}}
+}
+
+func integer(field func(*Config) *int) setter {
+	return setter{parse: func(c *Config, value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", value)
+		}
+		*field(c) = n
+		return nil
+	}}
+}
+
+func duration(field func(*Config) *time.Duration) setter {
+	return setter{parse: func(c *Config, value string) error {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a duration such as 30s", value)
+		}
+		*field(c) = d
+		return nil
+	}}
+}
+
+func boolean(field func(*Config) *bool) setter {
+	return setter{parse: func(c *Config, value string) error {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%q is not true or false", value)
+		}
+		*field(c) = b
+		return nil
+	}, isBool: true}
+}
+
+func ids(field func(*Config) *[]int64) setter {
+	return setter{parse: func(c *Config, value string) error {
+		parsed, err := parseUserIDs(value)
+		if err != nil {
+			return err
+		}
+		*field(c) = parsed
+		return nil
+	}}
+}
+
+// parseUserIDs parses a comma-separated list of Telegram user IDs
+func parseUserIDs(value string) ([]int64, error) {
+	var ids []int64
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a user ID", part)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
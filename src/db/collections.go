@@ -0,0 +1,486 @@
+package db
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"tarantulago/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Every row belongs to the collection of the owner in its user_id. Members
+// invited into a collection work on the owner's rows with their role's
+// rights, and whoever is on duty receives the collection's notifications.
+// Sitters are members whose access ends at a set time.
+
+const inviteValidity = 7 * 24 * time.Hour
+
+func newInviteCode() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate invite code: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// CheckInvite reports why an invite can't be accepted by the user, if it can't
+func CheckInvite(invite models.CollectionInvite, userID int64, now time.Time) error {
+	switch {
+	case invite.UsedBy != nil:
+		return models.Conflict("this invite has already been used")
+	case now.After(invite.ExpiresAt):
+		return models.Invalid("this invite has expired")
+	case invite.OwnerID == userID:
+		return models.Invalid("you can't join your own collection")
+	}
+	return nil
+}
+
+// activeMembership limits a collection_members query to access that hasn't run out
+const activeMembership = "(expires_at IS NULL OR expires_at > ?)"
+
+// DutyRecipients picks who gets a collection's notifications: the editors or
+// sitters on duty whose access hasn't run out, or the owner when nobody is
+func DutyRecipients(owner models.TelegramUser, members []models.CollectionMember, now time.Time) []models.TelegramUser {
+	var recipients []models.TelegramUser
+	for _, member := range members {
+		if member.OnDuty && canTakeDuty(member.Role) && !member.Expired(now) {
+			recipients = append(recipients, member.Member)
+		}
+	}
+	if len(recipients) == 0 {
+		return []models.TelegramUser{owner}
+	}
+	return recipients
+}
+
+// GroupRole is the user's role in a group bound to a collection: the owner
+// owns it, invited members keep their own role and everyone else gets the
+// group's role. A sitter whose access has run out can only look, so the
+// group can't give them back more than the invite did.
+func GroupRole(group models.GroupChat, userID int64, member *models.CollectionMember, now time.Time) string {
+	switch {
+	case userID == group.OwnerID:
+		return models.CollectionRoleOwner
+	case member != nil && member.Expired(now):
+		return models.CollectionRoleViewer
+	case member != nil:
+		return member.Role
+	}
+	return group.Role
+}
+
+func canTakeDuty(role string) bool {
+	return role == models.CollectionRoleEditor || role == models.CollectionRoleSitter
+}
+
+// ========== Shared Collections ==========
+
+// GetCollectionAccess returns the collection the user is working in. Users
+// who were removed from a collection fall back to their own.
+func (db *TarantulaDB) GetCollectionAccess(ctx context.Context, userID int64) (*models.CollectionAccess, error) {
+	var user models.TelegramUser
+	err := db.db.WithContext(ctx).Where("telegram_id = ?", userID).First(&user).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	own := &models.CollectionAccess{OwnerID: userID, OwnerName: user.DisplayName(), Role: models.CollectionRoleOwner}
+	if user.ActiveCollection == nil || *user.ActiveCollection == userID {
+		return own, nil
+	}
+
+	var member models.CollectionMember
+	err = db.db.WithContext(ctx).
+		Preload("Owner").
+		Where("owner_id = ? AND member_id = ?", *user.ActiveCollection, userID).
+		Where(activeMembership, time.Now()).
+		First(&member).Error
+	if err == gorm.ErrRecordNotFound {
+		if err := db.db.WithContext(ctx).Model(&user).Update("active_collection", nil).Error; err != nil {
+			return nil, fmt.Errorf("failed to reset active collection: %w", err)
+		}
+		return own, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collection membership: %w", err)
+	}
+
+	return &models.CollectionAccess{
+		OwnerID:   member.OwnerID,
+		OwnerName: member.Owner.DisplayName(),
+		Role:      member.Role,
+		ExpiresAt: member.ExpiresAt,
+	}, nil
+}
+
+func (db *TarantulaDB) CreateCollectionInvite(ctx context.Context, ownerID int64, role string) (*models.CollectionInvite, error) {
+	if role != models.CollectionRoleEditor && role != models.CollectionRoleViewer {
+		return nil, models.Invalid("invites are for editors or viewers, not %q", role)
+	}
+
+	return db.createInvite(ctx, models.CollectionInvite{OwnerID: ownerID, Role: role})
+}
+
+// CreateSitterInvite invites a pet-sitter whose access ends at the given time
+func (db *TarantulaDB) CreateSitterInvite(ctx context.Context, ownerID int64, until time.Time) (*models.CollectionInvite, error) {
+	if !until.After(time.Now()) {
+		return nil, models.Invalid("sitter access must end in the future")
+	}
+	return db.createInvite(ctx, models.CollectionInvite{OwnerID: ownerID, Role: models.CollectionRoleSitter, AccessUntil: &until})
+}
+
+func (db *TarantulaDB) createInvite(ctx context.Context, invite models.CollectionInvite) (*models.CollectionInvite, error) {
+	code, err := newInviteCode()
+	if err != nil {
+		return nil, err
+	}
+	invite.Code = code
+	invite.ExpiresAt = time.Now().Add(inviteValidity)
+	if err := db.db.WithContext(ctx).Create(&invite).Error; err != nil {
+		return nil, fmt.Errorf("failed to create invite: %w", err)
+	}
+
+	return &invite, nil
+}
+
+// AcceptCollectionInvite adds the user to the invite's collection, or changes
+// their role if already a member, and switches them to it. Sitters start a
+// fresh stint and go on duty.
+func (db *TarantulaDB) AcceptCollectionInvite(ctx context.Context, code string, userID int64) (*models.CollectionAccess, error) {
+	var access models.CollectionAccess
+	err := db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the invite so two users redeeming the same code at once can't
+		// both pass the used_by check
+		var invite models.CollectionInvite
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("code = ?", code).First(&invite).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return models.NotFound("invite not found")
+			}
+			return fmt.Errorf("failed to get invite: %w", err)
+		}
+
+		now := time.Now()
+		if err := CheckInvite(invite, userID, now); err != nil {
+			return err
+		}
+
+		assign := map[string]interface{}{
+			"role":           invite.Role,
+			"expires_at":     invite.AccessUntil,
+			"digest_sent_at": nil,
+		}
+		if invite.Role == models.CollectionRoleSitter {
+			assign["joined_at"] = now
+		}
+		member := models.CollectionMember{OwnerID: invite.OwnerID, MemberID: userID}
+		if err := tx.Where(member).
+			Assign(assign).
+			Attrs(models.CollectionMember{JoinedAt: now}).
+			FirstOrCreate(&member).Error; err != nil {
+			return fmt.Errorf("failed to add collection member: %w", err)
+		}
+
+		if invite.Role == models.CollectionRoleSitter {
+			if err := takeDuty(tx, invite.OwnerID, userID); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&invite).Updates(map[string]interface{}{
+			"used_by": userID,
+			"used_at": now,
+		}).Error; err != nil {
+			return fmt.Errorf("failed to use invite: %w", err)
+		}
+
+		if err := tx.Model(&models.TelegramUser{}).
+			Where("telegram_id = ?", userID).
+			Update("active_collection", invite.OwnerID).Error; err != nil {
+			return fmt.Errorf("failed to switch collection: %w", err)
+		}
+
+		var owner models.TelegramUser
+		if err := tx.Where("telegram_id = ?", invite.OwnerID).First(&owner).Error; err != nil {
+			return lookupError(err, "collection owner")
+		}
+		access = models.CollectionAccess{
+			OwnerID:   owner.TelegramID,
+			OwnerName: owner.DisplayName(),
+			Role:      invite.Role,
+			ExpiresAt: invite.AccessUntil,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &access, nil
+}
+
+// GetCollectionMembers lists who has been invited into the owner's collection
+func (db *TarantulaDB) GetCollectionMembers(ctx context.Context, ownerID int64) ([]models.CollectionMember, error) {
+	var members []models.CollectionMember
+
+	result := db.db.WithContext(ctx).
+		Preload("Member").
+		Where("owner_id = ?", ownerID).
+		Order("joined_at").
+		Find(&members)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get collection members: %w", result.Error)
+	}
+
+	return members, nil
+}
+
+// GetUserMemberships lists the other collections the user still has access to
+func (db *TarantulaDB) GetUserMemberships(ctx context.Context, userID int64) ([]models.CollectionMember, error) {
+	var memberships []models.CollectionMember
+
+	result := db.db.WithContext(ctx).
+		Preload("Owner").
+		Where("member_id = ?", userID).
+		Where(activeMembership, time.Now()).
+		Order("joined_at").
+		Find(&memberships)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get collection memberships: %w", result.Error)
+	}
+
+	return memberships, nil
+}
+
+// SwitchCollection makes the user work in the owner's collection; switching to
+// their own ID returns them to their own collection
+func (db *TarantulaDB) SwitchCollection(ctx context.Context, userID, ownerID int64) error {
+	var active interface{}
+	if ownerID != userID {
+		var count int64
+		if err := db.db.WithContext(ctx).Model(&models.CollectionMember{}).
+			Where("owner_id = ? AND member_id = ?", ownerID, userID).
+			Where(activeMembership, time.Now()).
+			Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to check membership: %w", err)
+		}
+		if count == 0 {
+			return models.AccessDenied("you are not a member of that collection")
+		}
+		active = ownerID
+	}
+
+	if err := db.db.WithContext(ctx).Model(&models.TelegramUser{}).
+		Where("telegram_id = ?", userID).
+		Update("active_collection", active).Error; err != nil {
+		return fmt.Errorf("failed to switch collection: %w", err)
+	}
+
+	return nil
+}
+
+func (db *TarantulaDB) SetCollectionRole(ctx context.Context, ownerID, memberID int64, role string) error {
+	if role != models.CollectionRoleEditor && role != models.CollectionRoleViewer {
+		return models.Invalid("members are editors or viewers, not %q", role)
+	}
+
+	updates := map[string]interface{}{"role": role, "expires_at": nil}
+	if role == models.CollectionRoleViewer {
+		updates["on_duty"] = false
+	}
+	result := db.db.WithContext(ctx).Model(&models.CollectionMember{}).
+		Where("owner_id = ? AND member_id = ?", ownerID, memberID).
+		Updates(updates)
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to set role: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return models.NotFound("collection member not found")
+	}
+
+	return nil
+}
+
+// RemoveCollectionMember takes a member out of the owner's collection, whether
+// the owner removes them or they leave
+func (db *TarantulaDB) RemoveCollectionMember(ctx context.Context, ownerID, memberID int64) error {
+	return db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("owner_id = ? AND member_id = ?", ownerID, memberID).Delete(&models.CollectionMember{})
+		if result.Error != nil {
+			return fmt.Errorf("failed to remove collection member: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return models.NotFound("collection member not found")
+		}
+
+		if err := tx.Model(&models.TelegramUser{}).
+			Where("telegram_id = ? AND active_collection = ?", memberID, ownerID).
+			Update("active_collection", nil).Error; err != nil {
+			return fmt.Errorf("failed to reset active collection: %w", err)
+		}
+		return nil
+	})
+}
+
+// TakeDuty routes the collection's notifications to the user alone. When the
+// owner takes duty nobody else is on duty.
+func (db *TarantulaDB) TakeDuty(ctx context.Context, ownerID, userID int64) error {
+	return db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return takeDuty(tx, ownerID, userID)
+	})
+}
+
+func takeDuty(tx *gorm.DB, ownerID, userID int64) error {
+	if err := tx.Model(&models.CollectionMember{}).
+		Where("owner_id = ?", ownerID).
+		Update("on_duty", false).Error; err != nil {
+		return fmt.Errorf("failed to clear duty: %w", err)
+	}
+	if userID == ownerID {
+		return nil
+	}
+
+	result := tx.Model(&models.CollectionMember{}).
+		Where("owner_id = ? AND member_id = ? AND role IN ?", ownerID, userID,
+			[]string{models.CollectionRoleEditor, models.CollectionRoleSitter}).
+		Where(activeMembership, time.Now()).
+		Update("on_duty", true)
+	if result.Error != nil {
+		return fmt.Errorf("failed to take duty: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return models.Invalid("only editors and sitters can be on duty")
+	}
+	return nil
+}
+
+// GetDutyRecipients returns who should receive the owner's collection notifications
+func (db *TarantulaDB) GetDutyRecipients(ctx context.Context, ownerID int64) ([]models.TelegramUser, error) {
+	var owner models.TelegramUser
+	if err := db.db.WithContext(ctx).Where("telegram_id = ?", ownerID).First(&owner).Error; err != nil {
+		return nil, fmt.Errorf("failed to get collection owner: %w", err)
+	}
+
+	members, err := db.GetCollectionMembers(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return DutyRecipients(owner, members, time.Now()), nil
+}
+
+// ========== Group Chats ==========
+
+// BindGroupChat points the group at the owner's collection, replacing any
+// earlier binding
+func (db *TarantulaDB) BindGroupChat(ctx context.Context, group models.GroupChat) error {
+	if group.Role != models.CollectionRoleEditor && group.Role != models.CollectionRoleViewer {
+		return models.Invalid("group members are editors or viewers, not %q", group.Role)
+	}
+
+	result := db.db.WithContext(ctx).
+		Where(models.GroupChat{ChatID: group.ChatID}).
+		Assign(models.GroupChat{
+			OwnerID: group.OwnerID,
+			Title:   group.Title,
+			Role:    group.Role,
+			BoundBy: group.BoundBy,
+		}).
+		Attrs(models.GroupChat{Reminders: true}).
+		FirstOrCreate(&group)
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to bind group chat: %w", result.Error)
+	}
+
+	return nil
+}
+
+func (db *TarantulaDB) UnbindGroupChat(ctx context.Context, chatID int64, ownerID int64) error {
+	result := db.db.WithContext(ctx).
+		Where("chat_id = ? AND owner_id = ?", chatID, ownerID).
+		Delete(&models.GroupChat{})
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to unbind group chat: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return models.NotFound("this group isn't linked to your collection")
+	}
+
+	return nil
+}
+
+// GetGroupAccess returns the collection a group is bound to and the user's
+// role in it; nil if the group isn't bound
+func (db *TarantulaDB) GetGroupAccess(ctx context.Context, chatID int64, userID int64) (*models.CollectionAccess, error) {
+	var group models.GroupChat
+	err := db.db.WithContext(ctx).Preload("Owner").Where("chat_id = ?", chatID).First(&group).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group chat: %w", err)
+	}
+
+	// Expired memberships are looked up too, so they don't fall back to the
+	// group's role
+	var member *models.CollectionMember
+	var found models.CollectionMember
+	err = db.db.WithContext(ctx).
+		Where("owner_id = ? AND member_id = ?", group.OwnerID, userID).
+		First(&found).Error
+	switch {
+	case err == nil:
+		member = &found
+	case err != gorm.ErrRecordNotFound:
+		return nil, fmt.Errorf("failed to get collection membership: %w", err)
+	}
+
+	access := &models.CollectionAccess{
+		OwnerID:   group.OwnerID,
+		OwnerName: group.Owner.DisplayName(),
+		Role:      GroupRole(group, userID, member, time.Now()),
+	}
+	if member != nil && !member.Expired(time.Now()) {
+		access.ExpiresAt = member.ExpiresAt
+	}
+	return access, nil
+}
+
+func (db *TarantulaDB) SetGroupReminders(ctx context.Context, chatID int64, ownerID int64, enabled bool) error {
+	result := db.db.WithContext(ctx).Model(&models.GroupChat{}).
+		Where("chat_id = ? AND owner_id = ?", chatID, ownerID).
+		Update("reminders", enabled)
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to set group reminders: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return models.NotFound("this group isn't linked to your collection")
+	}
+
+	return nil
+}
+
+// GetReminderGroups lists the groups that receive the owner's collection notifications
+func (db *TarantulaDB) GetReminderGroups(ctx context.Context, ownerID int64) ([]models.GroupChat, error) {
+	var groups []models.GroupChat
+
+	result := db.db.WithContext(ctx).
+		Where("owner_id = ? AND reminders = ?", ownerID, true).
+		Find(&groups)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get reminder groups: %w", result.Error)
+	}
+
+	return groups, nil
+}
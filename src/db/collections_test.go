@@ -0,0 +1,179 @@
+package db
+
+import (
+	"context"
+	"tarantulago/models"
+	"testing"
+	"time"
+)
+
+func TestCheckInvite(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	usedBy := int64(7)
+
+	tests := []struct {
+		name    string
+		invite  models.CollectionInvite
+		wantErr bool
+	}{
+		{"valid", models.CollectionInvite{OwnerID: 1, ExpiresAt: now.Add(time.Hour)}, false},
+		{"expired", models.CollectionInvite{OwnerID: 1, ExpiresAt: now.Add(-time.Hour)}, true},
+		{"used", models.CollectionInvite{OwnerID: 1, ExpiresAt: now.Add(time.Hour), UsedBy: &usedBy}, true},
+		{"own collection", models.CollectionInvite{OwnerID: 2, ExpiresAt: now.Add(time.Hour)}, true},
+	}
+
+	for _, tt := range tests {
+		if err := CheckInvite(tt.invite, 2, now); (err != nil) != tt.wantErr {
+			t.Errorf("%s: err = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestDutyRecipients(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	owner := models.TelegramUser{TelegramID: 1, ChatID: 10}
+	editor := models.CollectionMember{MemberID: 2, Role: models.CollectionRoleEditor, Member: models.TelegramUser{TelegramID: 2, ChatID: 20}}
+	viewer := models.CollectionMember{MemberID: 3, Role: models.CollectionRoleViewer, OnDuty: true, Member: models.TelegramUser{TelegramID: 3, ChatID: 30}}
+
+	got := DutyRecipients(owner, []models.CollectionMember{editor, viewer}, now)
+	if len(got) != 1 || got[0].TelegramID != 1 {
+		t.Errorf("nobody on duty: recipients = %v, want the owner", got)
+	}
+
+	editor.OnDuty = true
+	got = DutyRecipients(owner, []models.CollectionMember{editor, viewer}, now)
+	if len(got) != 1 || got[0].TelegramID != 2 {
+		t.Errorf("editor on duty: recipients = %v, want only the editor", got)
+	}
+
+	until := now.AddDate(0, 0, 7)
+	sitter := models.CollectionMember{MemberID: 4, Role: models.CollectionRoleSitter, OnDuty: true, ExpiresAt: &until,
+		Member: models.TelegramUser{TelegramID: 4, ChatID: 40}}
+	got = DutyRecipients(owner, []models.CollectionMember{sitter}, now)
+	if len(got) != 1 || got[0].TelegramID != 4 {
+		t.Errorf("sitter on duty: recipients = %v, want the sitter", got)
+	}
+	got = DutyRecipients(owner, []models.CollectionMember{sitter}, until)
+	if len(got) != 1 || got[0].TelegramID != 1 {
+		t.Errorf("sitter access ended: recipients = %v, want the owner back", got)
+	}
+}
+
+func TestGroupRole(t *testing.T) {
+	group := models.GroupChat{ChatID: -100, OwnerID: 1, Role: models.CollectionRoleEditor}
+
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	viewer := &models.CollectionMember{MemberID: 2, Role: models.CollectionRoleViewer}
+
+	if got := GroupRole(group, 1, nil, now); got != models.CollectionRoleOwner {
+		t.Errorf("owner role = %q, want owner", got)
+	}
+	if got := GroupRole(group, 2, viewer, now); got != models.CollectionRoleViewer {
+		t.Errorf("invited viewer role = %q, want their own viewer role", got)
+	}
+	if got := GroupRole(group, 3, nil, now); got != models.CollectionRoleEditor {
+		t.Errorf("group member role = %q, want the group's editor role", got)
+	}
+
+	until := now.Add(-time.Hour)
+	sitter := &models.CollectionMember{MemberID: 4, Role: models.CollectionRoleSitter, ExpiresAt: &until}
+	if got := GroupRole(group, 4, sitter, now); got != models.CollectionRoleViewer {
+		t.Errorf("expired sitter role = %q, want viewer rather than the group's editor role", got)
+	}
+}
+
+func TestGroupAccessForExpiredSitter(t *testing.T) {
+	database, err := NewTarantulaDB(context.Background(), testConnectionString, Options{})
+	if err != nil {
+		t.Skipf("Database not available: %v", err)
+	}
+	ctx := context.Background()
+
+	owner := &models.TelegramUser{TelegramID: 9101, Username: "group_owner"}
+	sitter := &models.TelegramUser{TelegramID: 9102, Username: "group_sitter"}
+	for _, user := range []*models.TelegramUser{owner, sitter} {
+		if err := database.EnsureUserExists(ctx, user); err != nil {
+			t.Fatalf("Failed to ensure user exists: %v", err)
+		}
+	}
+
+	invite, err := database.CreateSitterInvite(ctx, owner.TelegramID, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Failed to invite sitter: %v", err)
+	}
+	if _, err := database.AcceptCollectionInvite(ctx, invite.Code, sitter.TelegramID); err != nil {
+		t.Fatalf("Failed to accept invite: %v", err)
+	}
+	chatID := int64(-9100)
+	group := models.GroupChat{ChatID: chatID, OwnerID: owner.TelegramID, Role: models.CollectionRoleEditor, BoundBy: owner.TelegramID}
+	if err := database.BindGroupChat(ctx, group); err != nil {
+		t.Fatalf("Failed to bind group: %v", err)
+	}
+	t.Cleanup(func() {
+		database.db.Where("chat_id = ?", chatID).Delete(&models.GroupChat{})
+		database.db.Where("owner_id = ?", owner.TelegramID).Delete(&models.CollectionMember{})
+	})
+
+	access, err := database.GetGroupAccess(ctx, chatID, sitter.TelegramID)
+	if err != nil {
+		t.Fatalf("Failed to get group access: %v", err)
+	}
+	if access.Role != models.CollectionRoleSitter {
+		t.Errorf("sitter role in group = %q, want sitter", access.Role)
+	}
+
+	if err := database.db.Model(&models.CollectionMember{}).
+		Where("owner_id = ? AND member_id = ?", owner.TelegramID, sitter.TelegramID).
+		Update("expires_at", time.Now().Add(-time.Minute)).Error; err != nil {
+		t.Fatalf("Failed to expire sitter: %v", err)
+	}
+	access, err = database.GetGroupAccess(ctx, chatID, sitter.TelegramID)
+	if err != nil {
+		t.Fatalf("Failed to get group access: %v", err)
+	}
+	if access.Role != models.CollectionRoleViewer {
+		t.Errorf("expired sitter role in group = %q, want viewer", access.Role)
+	}
+}
+
+func TestAcceptInviteOnce(t *testing.T) {
+	database, err := NewTarantulaDB(context.Background(), testConnectionString, Options{})
+	if err != nil {
+		t.Skipf("Database not available: %v", err)
+	}
+	ctx := context.Background()
+
+	owner := &models.TelegramUser{TelegramID: 9111, Username: "invite_owner"}
+	first := &models.TelegramUser{TelegramID: 9112, Username: "invite_first"}
+	second := &models.TelegramUser{TelegramID: 9113, Username: "invite_second"}
+	for _, user := range []*models.TelegramUser{owner, first, second} {
+		if err := database.EnsureUserExists(ctx, user); err != nil {
+			t.Fatalf("Failed to ensure user exists: %v", err)
+		}
+	}
+
+	invite, err := database.CreateCollectionInvite(ctx, owner.TelegramID, models.CollectionRoleViewer)
+	if err != nil {
+		t.Fatalf("Failed to create invite: %v", err)
+	}
+	t.Cleanup(func() {
+		database.db.Where("owner_id = ?", owner.TelegramID).Delete(&models.CollectionMember{})
+	})
+
+	errs := make(chan error, 2)
+	for _, user := range []*models.TelegramUser{first, second} {
+		go func(userID int64) {
+			_, err := database.AcceptCollectionInvite(ctx, invite.Code, userID)
+			errs <- err
+		}(user.TelegramID)
+	}
+	accepted := 0
+	for range 2 {
+		if <-errs == nil {
+			accepted++
+		}
+	}
+	if accepted != 1 {
+		t.Errorf("invite accepted %d times, want once", accepted)
+	}
+}
@@ -0,0 +1,256 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"tarantulago/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Communal colonies are hard to count: members hide in burrows for weeks.
+// A census records how many were visible, and member events record
+// individual sightings, molts and losses. A census that accounts for every
+// member counts as seeing all of them.
+
+const (
+	// memberUnseenDays is how long a member can go unobserved before an alert
+	memberUnseenDays = 30
+	// censusAlertDays is how long a drop in the headcount keeps being reported
+	censusAlertDays = 7
+)
+
+// BuildColonyCensusAlerts checks each colony's latest census against the one
+// before, and its active members' last sightings. Members reported missing
+// are flagged until they're seen again. Colonies need active
+// Members with Tarantula loaded; censuses must be newest first.
+func BuildColonyCensusAlerts(colonies []models.TarantulaColony, censuses []models.ColonyCensus, now time.Time) []models.ColonyCensusAlert {
+	byColony := make(map[int][]models.ColonyCensus)
+	for _, census := range censuses {
+		if len(byColony[census.ColonyID]) < 2 {
+			byColony[census.ColonyID] = append(byColony[census.ColonyID], census)
+		}
+	}
+
+	var alerts []models.ColonyCensusAlert
+	for _, colony := range colonies {
+		alert := models.ColonyCensusAlert{ColonyID: colony.ID, ColonyName: colony.ColonyName}
+
+		if recent := byColony[colony.ID]; len(recent) > 0 {
+			latest := recent[0]
+			alert.CensusDate = &latest.CensusDate
+			alert.VisibleCount = latest.VisibleCount
+			alert.ExpectedCount = latest.ExpectedCount
+			if len(recent) > 1 && now.Sub(latest.CensusDate) <= censusAlertDays*24*time.Hour {
+				alert.PreviousCount = recent[1].VisibleCount
+				alert.CountDropped = latest.VisibleCount < recent[1].VisibleCount
+			}
+		}
+
+		for _, member := range colony.Members {
+			if !member.IsActive {
+				continue
+			}
+			if member.MissingSince != nil {
+				alert.MissingMembers = append(alert.MissingMembers, member.Tarantula.Name)
+				continue
+			}
+			seen := member.JoinedDate
+			if member.LastSeenDate != nil {
+				seen = *member.LastSeenDate
+			}
+			if now.Sub(seen) > memberUnseenDays*24*time.Hour {
+				alert.UnseenMembers = append(alert.UnseenMembers, member.Tarantula.Name)
+			}
+		}
+
+		if alert.CountDropped || len(alert.UnseenMembers) > 0 || len(alert.MissingMembers) > 0 {
+			alerts = append(alerts, alert)
+		}
+	}
+
+	return alerts
+}
+
+// ========== Colony Census ==========
+
+// RecordColonyCensus saves a headcount against the colony's active members.
+// When everyone was visible they all count as seen.
+func (db *TarantulaDB) RecordColonyCensus(ctx context.Context, census models.ColonyCensus) (int64, error) {
+	err := db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var colony models.TarantulaColony
+		if err := tx.Where("id = ? AND user_id = ?", census.ColonyID, census.UserID).First(&colony).Error; err != nil {
+			return lookupError(err, "colony")
+		}
+
+		var active int64
+		if err := tx.Model(&models.TarantulaColonyMember{}).
+			Where("colony_id = ? AND is_active = ?", census.ColonyID, true).
+			Count(&active).Error; err != nil {
+			return fmt.Errorf("failed to count colony members: %w", err)
+		}
+		census.ExpectedCount = int(active)
+
+		if err := tx.Create(&census).Error; err != nil {
+			return fmt.Errorf("failed to create colony census: %w", err)
+		}
+
+		if active > 0 && census.VisibleCount >= census.ExpectedCount {
+			if err := tx.Model(&models.TarantulaColonyMember{}).
+				Where("colony_id = ? AND is_active = ?", census.ColonyID, true).
+				Updates(map[string]interface{}{
+					"last_seen_date": census.CensusDate,
+					"missing_since":  nil,
+				}).Error; err != nil {
+				return fmt.Errorf("failed to update members: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(census.ID), nil
+}
+
+func (db *TarantulaDB) GetColonyCensuses(ctx context.Context, colonyID int32, userID int64, limit int32) ([]models.ColonyCensus, error) {
+	var censuses []models.ColonyCensus
+
+	result := db.db.WithContext(ctx).
+		Where("colony_id = ? AND user_id = ?", colonyID, userID).
+		Order("census_date DESC").
+		Limit(int(limit)).
+		Find(&censuses)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get colony censuses: %w", result.Error)
+	}
+
+	return censuses, nil
+}
+
+// RecordColonyMemberEvent saves an observation of a colony member. Sightings
+// and molts mark the member seen, a molt is also added to the tarantula's
+// molt history, a missing member is flagged until it's seen again, and
+// cannibalized or removed members leave the colony.
+func (db *TarantulaDB) RecordColonyMemberEvent(ctx context.Context, event models.ColonyMemberEvent) (int64, error) {
+	if !slices.Contains(models.MemberEventTypes, event.EventType) {
+		return 0, models.Invalid("unknown member event %q", event.EventType)
+	}
+
+	err := db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var member models.TarantulaColonyMember
+		if err := tx.Where("id = ? AND user_id = ? AND is_active = ?", event.MemberID, event.UserID, true).
+			First(&member).Error; err != nil {
+			return lookupError(err, "active colony member")
+		}
+		event.ColonyID = member.ColonyID
+		event.TarantulaID = member.TarantulaID
+
+		if err := tx.Create(&event).Error; err != nil {
+			return fmt.Errorf("failed to create member event: %w", err)
+		}
+
+		switch event.EventType {
+		case models.MemberEventSeen:
+			return markMemberSeen(tx, member, event.EventDate)
+
+		case models.MemberEventMolted:
+			if err := markMemberSeen(tx, member, event.EventDate); err != nil {
+				return err
+			}
+			return recordMolt(tx, &models.MoltRecord{
+				TarantulaID: member.TarantulaID,
+				MoltDate:    event.EventDate,
+				MoltStageID: int(models.MoltStagePostMolt),
+				Notes:       "Seen molting in colony",
+				UserID:      event.UserID,
+			})
+
+		case models.MemberEventMissing:
+			if err := tx.Model(&member).Update("missing_since", event.EventDate).Error; err != nil {
+				return fmt.Errorf("failed to update member: %w", err)
+			}
+
+		case models.MemberEventCannibalized, models.MemberEventRemoved:
+			if err := tx.Model(&member).Updates(map[string]interface{}{
+				"is_active": false,
+				"left_date": event.EventDate,
+				"notes":     event.EventType,
+			}).Error; err != nil {
+				return fmt.Errorf("failed to update membership: %w", err)
+			}
+			if err := tx.Model(&models.Tarantula{}).
+				Where("id = ?", member.TarantulaID).
+				Update("colony_id", nil).Error; err != nil {
+				return fmt.Errorf("failed to clear tarantula colony reference: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(event.ID), nil
+}
+
+func markMemberSeen(tx *gorm.DB, member models.TarantulaColonyMember, date time.Time) error {
+	if err := tx.Model(&member).Updates(map[string]interface{}{
+		"last_seen_date": date,
+		"missing_since":  nil,
+	}).Error; err != nil {
+		return fmt.Errorf("failed to update member: %w", err)
+	}
+	return nil
+}
+
+func (db *TarantulaDB) GetColonyMemberEvents(ctx context.Context, colonyID int32, userID int64, limit int32) ([]models.ColonyMemberEvent, error) {
+	var events []models.ColonyMemberEvent
+
+	result := db.db.WithContext(ctx).
+		Preload("Tarantula").
+		Where("colony_id = ? AND user_id = ?", colonyID, userID).
+		Order("event_date DESC").
+		Limit(int(limit)).
+		Find(&events)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get member events: %w", result.Error)
+	}
+
+	return events, nil
+}
+
+func (db *TarantulaDB) GetColonyCensusAlerts(ctx context.Context, userID int64) ([]models.ColonyCensusAlert, error) {
+	colonies, err := db.GetUserColonies(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(colonies) == 0 {
+		return nil, nil
+	}
+
+	// Only the latest two censuses of each colony are compared
+	var censuses []models.ColonyCensus
+	query := `
+    SELECT id, colony_id, census_date, visible_count, expected_count, notes, user_id, created_at
+    FROM (
+        SELECT c.*, ROW_NUMBER() OVER (PARTITION BY c.colony_id ORDER BY c.census_date DESC) as rn
+        FROM spider_bot.colony_census c
+        WHERE c.user_id = ?
+    ) recent
+    WHERE rn <= 2
+    ORDER BY census_date DESC`
+
+	if err := db.db.WithContext(ctx).Raw(query, userID).Scan(&censuses).Error; err != nil {
+		return nil, fmt.Errorf("failed to get colony censuses: %w", err)
+	}
+
+	return BuildColonyCensusAlerts(colonies, censuses, time.Now()), nil
+}
@@ -0,0 +1,53 @@
+package db
+
+import (
+	"tarantulago/models"
+	"testing"
+	"time"
+)
+
+func TestBuildColonyCensusAlerts(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	recently := now.AddDate(0, 0, -3)
+	joined := now.AddDate(0, -3, 0)
+
+	colonies := []models.TarantulaColony{
+		{ID: 1, ColonyName: "Balfouri Group", Members: []models.TarantulaColonyMember{
+			{IsActive: true, JoinedDate: joined, LastSeenDate: &recently, Tarantula: models.Tarantula{Name: "Ada"}},
+			{IsActive: true, JoinedDate: joined, Tarantula: models.Tarantula{Name: "Bo"}},
+		}},
+		{ID: 2, ColonyName: "Quiet Colony", Members: []models.TarantulaColonyMember{
+			{IsActive: true, JoinedDate: joined, LastSeenDate: &recently, Tarantula: models.Tarantula{Name: "Cy"}},
+		}},
+	}
+	censuses := []models.ColonyCensus{
+		{ColonyID: 1, CensusDate: now.AddDate(0, 0, -1), VisibleCount: 1, ExpectedCount: 2},
+		{ColonyID: 2, CensusDate: now.AddDate(0, 0, -2), VisibleCount: 1, ExpectedCount: 1},
+		{ColonyID: 1, CensusDate: now.AddDate(0, 0, -8), VisibleCount: 2, ExpectedCount: 2},
+		{ColonyID: 2, CensusDate: now.AddDate(0, 0, -9), VisibleCount: 1, ExpectedCount: 1},
+	}
+
+	alerts := BuildColonyCensusAlerts(colonies, censuses, now)
+	if len(alerts) != 1 {
+		t.Fatalf("alerts = %+v, want only Balfouri Group", alerts)
+	}
+	alert := alerts[0]
+	if !alert.CountDropped || alert.PreviousCount != 2 || alert.VisibleCount != 1 {
+		t.Errorf("alert = %+v, want a drop from 2 to 1", alert)
+	}
+	if len(alert.UnseenMembers) != 1 || alert.UnseenMembers[0] != "Bo" {
+		t.Errorf("unseen = %v, want [Bo]", alert.UnseenMembers)
+	}
+
+	// A member reported missing is flagged straight away, not as unseen
+	colonies[1].Members[0].MissingSince = &recently
+	alerts = BuildColonyCensusAlerts(colonies[1:], censuses, now)
+	if len(alerts) != 1 || len(alerts[0].MissingMembers) != 1 || alerts[0].MissingMembers[0] != "Cy" || len(alerts[0].UnseenMembers) != 0 {
+		t.Errorf("alerts = %+v, want Cy reported missing", alerts)
+	}
+
+	// An old drop is no longer reported
+	if alerts := BuildColonyCensusAlerts(colonies[:1], censuses, now.AddDate(0, 0, 10)); alerts[0].CountDropped {
+		t.Errorf("drop still reported after %d days", censusAlertDays)
+	}
+}
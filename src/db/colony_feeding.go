@@ -0,0 +1,255 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"tarantulago/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Communal colonies are fed as a group. How much to offer comes from each
+// member's own feeding schedule, and what was offered is credited back to the
+// members in proportion to their size, so per-tarantula analytics include it.
+
+// defaultMinFeedingDays matches the fallback interval used for tarantulas
+// whose species has no feeding schedule
+const defaultMinFeedingDays = 7
+
+// colonyMemberFeeding is what a colony feeding plan needs to know about one
+// active member. Schedule is nil when its species has none for its size.
+type colonyMemberFeeding struct {
+	TarantulaID int
+	Name        string
+	SpeciesID   int
+	CurrentSize float64
+	MoltStageID int
+	LastFedDate *time.Time
+	Schedule    *models.FeedingSchedule `gorm:"-"`
+}
+
+// isFasting reports whether a molt stage means the tarantula won't eat
+func isFasting(moltStageID int) bool {
+	stage := models.MoltStageEnum(moltStageID)
+	return stage == models.MoltStagePreMolt || stage == models.MoltStageMolting
+}
+
+// PlanColonyFeeding suggests a colony feeding: every member not fasting for a
+// molt gets its schedule's prey count, in the size the smallest of them needs
+// so nobody is offered prey too large. Members past their schedule's minimum
+// interval are due.
+func PlanColonyFeeding(colony models.TarantulaColony, members []colonyMemberFeeding, now time.Time) models.ColonyFeedingPlan {
+	plan := models.ColonyFeedingPlan{
+		ColonyID:      colony.ID,
+		ColonyName:    colony.ColonyName,
+		ActiveMembers: len(members),
+	}
+
+	smallest := models.CricketSizeUnknown
+	for _, member := range members {
+		if isFasting(member.MoltStageID) {
+			continue
+		}
+		plan.FeedingMembers++
+
+		count := 1.0
+		minDays := defaultMinFeedingDays
+		if member.Schedule != nil {
+			size, n := ParsePreySize(member.Schedule.PreySize)
+			count = n
+			if size < smallest {
+				smallest = size
+			}
+			if member.Schedule.Frequency.MinDays > 0 {
+				minDays = member.Schedule.Frequency.MinDays
+			}
+		}
+		plan.PreyCount += int(math.Ceil(count))
+
+		if member.LastFedDate == nil || now.Sub(*member.LastFedDate) >= time.Duration(minDays)*24*time.Hour {
+			plan.DueMembers = append(plan.DueMembers, member.Name)
+		}
+	}
+
+	if smallest != models.CricketSizeUnknown {
+		plan.PreySize = smallest.ToDBName()
+	}
+	return plan
+}
+
+// ShareColonyFeeding credits the members present at a colony feeding with its
+// prey in proportion to body length. Members of unknown size count as the
+// average, and members fasting for a molt get nothing unless all of them are.
+func ShareColonyFeeding(event models.FeedingEvent, members []models.TarantulaColonyMember) []models.ColonyFeedingShare {
+	var eating []models.TarantulaColonyMember
+	for _, member := range members {
+		if member.IsActive && !isFasting(member.Tarantula.CurrentMoltStageID) {
+			eating = append(eating, member)
+		}
+	}
+	if len(eating) == 0 {
+		for _, member := range members {
+			if member.IsActive {
+				eating = append(eating, member)
+			}
+		}
+	}
+	if len(eating) == 0 {
+		return nil
+	}
+
+	var known, knownTotal float64
+	for _, member := range eating {
+		if member.Tarantula.CurrentSize > 0 {
+			known++
+			knownTotal += member.Tarantula.CurrentSize
+		}
+	}
+	average := 1.0
+	if known > 0 {
+		average = knownTotal / known
+	}
+
+	weights := make([]float64, len(eating))
+	var total float64
+	for i, member := range eating {
+		weights[i] = member.Tarantula.CurrentSize
+		if weights[i] <= 0 {
+			weights[i] = average
+		}
+		total += weights[i]
+	}
+
+	shares := make([]models.ColonyFeedingShare, len(eating))
+	for i, member := range eating {
+		part := weights[i] / total
+		shares[i] = models.ColonyFeedingShare{
+			FeedingEventID: event.ID,
+			ColonyID:       member.ColonyID,
+			TarantulaID:    member.TarantulaID,
+			FeedingDate:    event.FeedingDate,
+			PreyCount:      float64(event.PreyCount) * part,
+			PreyMassGrams:  event.PreyMassGrams * part,
+			UserID:         event.UserID,
+		}
+	}
+	return shares
+}
+
+// creditColonyMembers records each member's share of a colony feeding
+func creditColonyMembers(tx *gorm.DB, event models.FeedingEvent) error {
+	if event.TarantulaColonyID == nil {
+		return nil
+	}
+
+	var members []models.TarantulaColonyMember
+	if err := tx.Preload("Tarantula").
+		Where("colony_id = ? AND is_active = ?", *event.TarantulaColonyID, true).
+		Find(&members).Error; err != nil {
+		return fmt.Errorf("failed to get colony members: %w", err)
+	}
+
+	shares := ShareColonyFeeding(event, members)
+	if len(shares) == 0 {
+		return nil
+	}
+	if err := tx.Create(&shares).Error; err != nil {
+		return fmt.Errorf("failed to credit colony members: %w", err)
+	}
+	return nil
+}
+
+// memberFeedingsSQL lists every feeding a tarantula took part in: its own,
+// and its share of colony feedings. It stands in for feeding_events in
+// per-tarantula analytics.
+const memberFeedingsSQL = `(
+        SELECT id, tarantula_id, feeding_date, feeder_species_id, prey_size,
+               prey_count::float8 as prey_count, prey_mass_grams, feeding_status_id
+        FROM spider_bot.feeding_events
+        WHERE tarantula_id IS NOT NULL
+        UNION ALL
+        SELECT cfe.id, cfs.tarantula_id, cfs.feeding_date, cfe.feeder_species_id, cfe.prey_size,
+               cfs.prey_count::float8, cfs.prey_mass_grams, cfe.feeding_status_id
+        FROM spider_bot.colony_feeding_shares cfs
+        JOIN spider_bot.feeding_events cfe ON cfe.id = cfs.feeding_event_id
+    )`
+
+// ========== Colony Feeding ==========
+
+// GetColonyFeedingPlan works out what a colony should be fed from its active
+// members' schedules and when each last ate, alone or with the colony
+func (db *TarantulaDB) GetColonyFeedingPlan(ctx context.Context, colonyID int32, userID int64) (*models.ColonyFeedingPlan, error) {
+	var colony models.TarantulaColony
+	if err := db.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", colonyID, userID).
+		First(&colony).Error; err != nil {
+		return nil, lookupError(err, "colony")
+	}
+
+	plan, err := db.planColonyFeeding(ctx, colony)
+	if err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+func (db *TarantulaDB) planColonyFeeding(ctx context.Context, colony models.TarantulaColony) (models.ColonyFeedingPlan, error) {
+	var members []colonyMemberFeeding
+	query := `
+    SELECT
+        t.id as tarantula_id,
+        t.name,
+        t.species_id,
+        COALESCE(t.current_size, 0) as current_size,
+        COALESCE(t.current_molt_stage_id, 0) as molt_stage_id,
+        GREATEST(
+            (SELECT MAX(fe.feeding_date) FROM spider_bot.feeding_events fe WHERE fe.tarantula_id = t.id),
+            (SELECT MAX(fe.feeding_date)
+             FROM spider_bot.feeding_events fe
+             JOIN spider_bot.tarantula_colony_members m ON fe.tarantula_colony_id = m.colony_id
+             WHERE m.tarantula_id = t.id
+               AND fe.feeding_date >= m.joined_date
+               AND (m.left_date IS NULL OR fe.feeding_date <= m.left_date))
+        ) as last_fed_date
+    FROM spider_bot.tarantula_colony_members tcm
+    JOIN spider_bot.tarantulas t ON t.id = tcm.tarantula_id
+    WHERE tcm.colony_id = ? AND tcm.is_active = true
+    ORDER BY t.name`
+
+	if err := db.db.WithContext(ctx).Raw(query, colony.ID).Scan(&members).Error; err != nil {
+		return models.ColonyFeedingPlan{}, fmt.Errorf("failed to get colony members: %w", err)
+	}
+
+	for i := range members {
+		schedule, err := db.GetFeedingSchedule(ctx, int64(members[i].SpeciesID), float32(members[i].CurrentSize))
+		if err != nil {
+			return models.ColonyFeedingPlan{}, err
+		}
+		members[i].Schedule = schedule
+	}
+
+	return PlanColonyFeeding(colony, members, time.Now()), nil
+}
+
+// GetColonyFeedingPlans plans a feeding for each of the user's colonies
+func (db *TarantulaDB) GetColonyFeedingPlans(ctx context.Context, userID int64) ([]models.ColonyFeedingPlan, error) {
+	var colonies []models.TarantulaColony
+	if err := db.db.WithContext(ctx).
+		Where("user_id = ? AND dissolved_date IS NULL", userID).
+		Order("colony_name").
+		Find(&colonies).Error; err != nil {
+		return nil, fmt.Errorf("failed to get colonies: %w", err)
+	}
+
+	plans := make([]models.ColonyFeedingPlan, 0, len(colonies))
+	for _, colony := range colonies {
+		plan, err := db.planColonyFeeding(ctx, colony)
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, plan)
+	}
+	return plans, nil
+}
@@ -0,0 +1,71 @@
+package db
+
+import (
+	"math"
+	"tarantulago/models"
+	"testing"
+	"time"
+)
+
+func TestPlanColonyFeeding(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	recently := now.AddDate(0, 0, -2)
+	weekAgo := now.AddDate(0, 0, -10)
+
+	schedule := func(prey string, minDays int) *models.FeedingSchedule {
+		return &models.FeedingSchedule{PreySize: prey, Frequency: models.FeedingFrequency{MinDays: minDays}}
+	}
+	members := []colonyMemberFeeding{
+		{Name: "Ada", LastFedDate: &weekAgo, Schedule: schedule("2-3 medium crickets", 7)},
+		{Name: "Bo", LastFedDate: &recently, Schedule: schedule("1 small cricket", 5)},
+		{Name: "Cy", MoltStageID: int(models.MoltStagePreMolt), Schedule: schedule("1 large cricket", 7)},
+		{Name: "Di"},
+	}
+
+	plan := PlanColonyFeeding(models.TarantulaColony{ID: 3, ColonyName: "Balfouri Group"}, members, now)
+	if plan.ActiveMembers != 4 || plan.FeedingMembers != 3 {
+		t.Errorf("members = %d active, %d feeding, want 4 and 3", plan.ActiveMembers, plan.FeedingMembers)
+	}
+	// Ada rounds 2.5 up to 3, Bo has 1 and Di falls back to 1; Cy is fasting
+	if plan.PreyCount != 5 {
+		t.Errorf("prey count = %d, want 5", plan.PreyCount)
+	}
+	if plan.PreySize != "Small" {
+		t.Errorf("prey size = %q, want the smallest member's Small", plan.PreySize)
+	}
+	if len(plan.DueMembers) != 2 || plan.DueMembers[0] != "Ada" || plan.DueMembers[1] != "Di" {
+		t.Errorf("due = %v, want [Ada Di]", plan.DueMembers)
+	}
+}
+
+func TestShareColonyFeeding(t *testing.T) {
+	event := models.FeedingEvent{ID: 9, PreyCount: 6, PreyMassGrams: 3, UserID: 1}
+	members := []models.TarantulaColonyMember{
+		{TarantulaID: 1, ColonyID: 3, IsActive: true, Tarantula: models.Tarantula{CurrentSize: 4}},
+		{TarantulaID: 2, ColonyID: 3, IsActive: true, Tarantula: models.Tarantula{CurrentSize: 2}},
+		{TarantulaID: 3, ColonyID: 3, IsActive: true},
+		{TarantulaID: 4, ColonyID: 3, IsActive: true, Tarantula: models.Tarantula{CurrentSize: 5, CurrentMoltStageID: int(models.MoltStageMolting)}},
+		{TarantulaID: 5, ColonyID: 3, IsActive: false, Tarantula: models.Tarantula{CurrentSize: 5}},
+	}
+
+	shares := ShareColonyFeeding(event, members)
+	if len(shares) != 3 {
+		t.Fatalf("shares = %+v, want the three eating members", shares)
+	}
+
+	// Weights are 4, 2 and the average 3
+	want := map[int]float64{1: 6 * 4.0 / 9, 2: 6 * 2.0 / 9, 3: 6 * 3.0 / 9}
+	var total float64
+	for _, share := range shares {
+		if math.Abs(share.PreyCount-want[share.TarantulaID]) > 1e-9 {
+			t.Errorf("tarantula %d prey = %.3f, want %.3f", share.TarantulaID, share.PreyCount, want[share.TarantulaID])
+		}
+		if share.FeedingEventID != 9 {
+			t.Errorf("share not linked to the feeding: %+v", share)
+		}
+		total += share.PreyMassGrams
+	}
+	if math.Abs(total-3) > 1e-9 {
+		t.Errorf("shared mass = %.3f, want all 3g", total)
+	}
+}
@@ -0,0 +1,273 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"tarantulago/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Colony membership changes never rewrite history: a tarantula that moves
+// between colonies leaves the old one (LeftDate) and joins the new one
+// (JoinedDate), so colony feedings stay attributed to whoever was a member
+// at the time.
+
+// splitMembers picks the active members being moved out in a split. At least
+// one must move and at least one must stay.
+func splitMembers(members []models.TarantulaColonyMember, tarantulaIDs []int32) ([]models.TarantulaColonyMember, error) {
+	if len(tarantulaIDs) == 0 {
+		return nil, models.Invalid("choose at least one member to move")
+	}
+
+	active := make(map[int]models.TarantulaColonyMember)
+	for _, member := range members {
+		if member.IsActive {
+			active[member.TarantulaID] = member
+		}
+	}
+
+	var moving []models.TarantulaColonyMember
+	seen := make(map[int]bool)
+	for _, id := range tarantulaIDs {
+		member, ok := active[int(id)]
+		if !ok {
+			return nil, models.Invalid("tarantula %d is not a member of this colony", id)
+		}
+		if !seen[int(id)] {
+			seen[int(id)] = true
+			moving = append(moving, member)
+		}
+	}
+
+	if len(moving) == len(active) {
+		return nil, models.Invalid("a split must leave at least one member in the original colony")
+	}
+	return moving, nil
+}
+
+// addColonyMember checks the tarantula can join the colony and records it
+func addColonyMember(tx *gorm.DB, colony models.TarantulaColony, member models.TarantulaColonyMember) error {
+	// Verify tarantula exists and belongs to user
+	var tarantula models.Tarantula
+	if err := tx.Where("id = ? AND user_id = ?", member.TarantulaID, member.UserID).First(&tarantula).Error; err != nil {
+		return lookupError(err, "tarantula")
+	}
+
+	// Verify species match
+	if tarantula.SpeciesID != colony.SpeciesID {
+		return models.Invalid("%s's species does not match colony species", tarantula.Name)
+	}
+
+	var active int64
+	if err := tx.Model(&models.TarantulaColonyMember{}).
+		Where("tarantula_id = ? AND is_active = ?", member.TarantulaID, true).
+		Count(&active).Error; err != nil {
+		return fmt.Errorf("failed to check memberships: %w", err)
+	}
+	if active > 0 {
+		return models.Conflict("%s is already in a colony", tarantula.Name)
+	}
+
+	// Create the membership record
+	member.ColonyID = colony.ID
+	member.IsActive = true
+	if err := tx.Create(&member).Error; err != nil {
+		return fmt.Errorf("failed to add member to colony: %w", err)
+	}
+
+	// Update tarantula's colony_id
+	if err := tx.Model(&tarantula).Update("colony_id", colony.ID).Error; err != nil {
+		return fmt.Errorf("failed to update tarantula colony reference: %w", err)
+	}
+
+	return nil
+}
+
+// endColonyMembership marks a member as having left the colony on the given date
+func endColonyMembership(tx *gorm.DB, member models.TarantulaColonyMember, date time.Time, notes string) error {
+	updates := map[string]interface{}{
+		"is_active": false,
+		"left_date": date,
+	}
+	if notes != "" {
+		updates["notes"] = notes
+	}
+	if err := tx.Model(&member).Updates(updates).Error; err != nil {
+		return fmt.Errorf("failed to update membership: %w", err)
+	}
+
+	// Clear tarantula's colony_id
+	if err := tx.Model(&models.Tarantula{}).
+		Where("id = ? AND user_id = ?", member.TarantulaID, member.UserID).
+		Update("colony_id", nil).Error; err != nil {
+		return fmt.Errorf("failed to clear tarantula colony reference: %w", err)
+	}
+
+	return nil
+}
+
+// moveColonyMember ends a membership and continues it in another colony,
+// keeping when the tarantula was last seen
+func moveColonyMember(tx *gorm.DB, member models.TarantulaColonyMember, from, to models.TarantulaColony, date time.Time, verb string) error {
+	if err := endColonyMembership(tx, member, date, fmt.Sprintf("%s to %s", verb, to.ColonyName)); err != nil {
+		return err
+	}
+	return addColonyMember(tx, to, models.TarantulaColonyMember{
+		TarantulaID:  member.TarantulaID,
+		JoinedDate:   date,
+		LastSeenDate: member.LastSeenDate,
+		Notes:        fmt.Sprintf("%s from %s", verb, from.ColonyName),
+		UserID:       member.UserID,
+	})
+}
+
+func findActiveColony(tx *gorm.DB, colonyID int32, userID int64) (models.TarantulaColony, error) {
+	var colony models.TarantulaColony
+	if err := tx.Preload("Members", "is_active = ?", true).
+		Where("id = ? AND user_id = ? AND dissolved_date IS NULL", colonyID, userID).
+		First(&colony).Error; err != nil {
+		return colony, lookupError(err, "colony")
+	}
+	return colony, nil
+}
+
+// ========== Colony Structure ==========
+
+// SplitColony moves the chosen members into a new colony of the same species.
+// newColony supplies the name, enclosure and owner.
+func (db *TarantulaDB) SplitColony(ctx context.Context, colonyID int32, tarantulaIDs []int32, newColony models.TarantulaColony) (int64, error) {
+	err := db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		source, err := findActiveColony(tx, colonyID, newColony.UserID)
+		if err != nil {
+			return err
+		}
+		// The new colony may only go into one of the keeper's own enclosures
+		if newColony.EnclosureID != nil {
+			var enclosure models.Enclosure
+			if err := tx.Where("id = ? AND user_id = ?", *newColony.EnclosureID, newColony.UserID).First(&enclosure).Error; err != nil {
+				return lookupError(err, "enclosure")
+			}
+		}
+
+		moving, err := splitMembers(source.Members, tarantulaIDs)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		newColony.SpeciesID = source.SpeciesID
+		newColony.FormationDate = now
+		if newColony.Notes == "" {
+			newColony.Notes = "Split from " + source.ColonyName
+		}
+		if err := tx.Create(&newColony).Error; err != nil {
+			return fmt.Errorf("failed to create colony: %w", err)
+		}
+
+		for _, member := range moving {
+			if err := moveColonyMember(tx, member, source, newColony, now, "Split"); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(newColony.ID), nil
+}
+
+// MergeColonies moves every member of the source colony into the target and
+// dissolves the source. Both must be the same species.
+func (db *TarantulaDB) MergeColonies(ctx context.Context, targetID, sourceID int32, userID int64) error {
+	if targetID == sourceID {
+		return models.Invalid("cannot merge a colony into itself")
+	}
+
+	return db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		target, err := findActiveColony(tx, targetID, userID)
+		if err != nil {
+			return err
+		}
+		source, err := findActiveColony(tx, sourceID, userID)
+		if err != nil {
+			return err
+		}
+		if source.SpeciesID != target.SpeciesID {
+			return models.Invalid("only colonies of the same species can be merged")
+		}
+
+		now := time.Now()
+		for _, member := range source.Members {
+			if err := moveColonyMember(tx, member, source, target, now, "Merged"); err != nil {
+				return err
+			}
+		}
+
+		notes := "Merged into " + target.ColonyName
+		if source.Notes != "" {
+			notes = source.Notes + "\n" + notes
+		}
+		if err := tx.Model(&source).Updates(map[string]interface{}{
+			"dissolved_date": now,
+			"notes":          notes,
+		}).Error; err != nil {
+			return fmt.Errorf("failed to dissolve colony: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// AddMembersToColony adds several tarantulas at once; none are added if any can't join
+func (db *TarantulaDB) AddMembersToColony(ctx context.Context, colonyID int32, tarantulaIDs []int32, userID int64) error {
+	return db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		colony, err := findActiveColony(tx, colonyID, userID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for _, id := range tarantulaIDs {
+			if err := addColonyMember(tx, colony, models.TarantulaColonyMember{
+				TarantulaID: int(id),
+				JoinedDate:  now,
+				UserID:      userID,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// RemoveMembersFromColony ends several memberships at once
+func (db *TarantulaDB) RemoveMembersFromColony(ctx context.Context, colonyID int32, tarantulaIDs []int32, userID int64) error {
+	return db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// A tarantula picked twice is still one membership
+		ids := slices.Clone(tarantulaIDs)
+		slices.Sort(ids)
+		ids = slices.Compact(ids)
+
+		var members []models.TarantulaColonyMember
+		if err := tx.Where("colony_id = ? AND tarantula_id IN ? AND is_active = ? AND user_id = ?",
+			colonyID, ids, true, userID).Find(&members).Error; err != nil {
+			return fmt.Errorf("failed to get memberships: %w", err)
+		}
+		if len(members) != len(ids) {
+			return models.Invalid("not all tarantulas are members of this colony")
+		}
+
+		now := time.Now()
+		for _, member := range members {
+			if err := endColonyMembership(tx, member, now, ""); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
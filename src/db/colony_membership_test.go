@@ -0,0 +1,76 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"tarantulago/models"
+	"testing"
+	"time"
+)
+
+func TestSplitMembers(t *testing.T) {
+	members := []models.TarantulaColonyMember{
+		{ID: 1, TarantulaID: 10, IsActive: true},
+		{ID: 2, TarantulaID: 11, IsActive: true},
+		{ID: 3, TarantulaID: 12, IsActive: true},
+		{ID: 4, TarantulaID: 13, IsActive: false},
+	}
+
+	moving, err := splitMembers(members, []int32{11, 12, 11})
+	if err != nil {
+		t.Fatalf("splitMembers: %v", err)
+	}
+	if len(moving) != 2 || moving[0].ID != 2 || moving[1].ID != 3 {
+		t.Errorf("moving = %+v, want members 2 and 3", moving)
+	}
+
+	for name, ids := range map[string][]int32{
+		"none chosen":   nil,
+		"not a member":  {99},
+		"former member": {13},
+		"everyone":      {10, 11, 12},
+	} {
+		if _, err := splitMembers(members, ids); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestSplitColonyIntoForeignEnclosure(t *testing.T) {
+	database, err := NewTarantulaDB(context.Background(), testConnectionString, Options{})
+	if err != nil {
+		t.Skipf("Database not available: %v", err)
+	}
+	ctx := context.Background()
+
+	keeper := &models.TelegramUser{TelegramID: 9121, Username: "split_keeper"}
+	other := &models.TelegramUser{TelegramID: 9122, Username: "split_other"}
+	for _, user := range []*models.TelegramUser{keeper, other} {
+		if err := database.EnsureUserExists(ctx, user); err != nil {
+			t.Fatalf("Failed to ensure user exists: %v", err)
+		}
+	}
+
+	colonyID, err := database.CreateColony(ctx, models.TarantulaColony{
+		ColonyName: "Split test", SpeciesID: 1, FormationDate: time.Now(), UserID: keeper.TelegramID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create colony: %v", err)
+	}
+	enclosureID, err := database.CreateEnclosure(ctx, models.Enclosure{Name: "Not yours", UserID: other.TelegramID})
+	if err != nil {
+		t.Fatalf("Failed to create enclosure: %v", err)
+	}
+	t.Cleanup(func() {
+		database.db.Delete(&models.TarantulaColony{}, colonyID)
+		database.db.Delete(&models.Enclosure{}, enclosureID)
+	})
+
+	enclosure := int(enclosureID)
+	_, err = database.SplitColony(ctx, int32(colonyID), []int32{1}, models.TarantulaColony{
+		ColonyName: "Moved", EnclosureID: &enclosure, UserID: keeper.TelegramID,
+	})
+	if !errors.Is(err, models.ErrNotFound) {
+		t.Errorf("split into another keeper's enclosure: err = %v, want not found", err)
+	}
+}
@@ -0,0 +1,299 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"tarantulago/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Costs come from the keeper's own records: feeder purchases give an average
+// price per insect, which prices each tarantula's feedings, and expenses are
+// added as they were paid. The feeder species' typical unit cost is only used
+// for feeders that were never bought.
+
+type feederCostKey struct {
+	speciesID int
+	size      string
+}
+
+type costTotal struct {
+	paid     float64
+	quantity int
+}
+
+func (c costTotal) unitCost() float64 {
+	return c.paid / float64(c.quantity)
+}
+
+// FeederCosts holds the average price paid per insect by species and size
+type FeederCosts struct {
+	bySize    map[feederCostKey]costTotal
+	bySpecies map[int]costTotal
+}
+
+func NewFeederCosts(purchases []models.FeederPurchase) FeederCosts {
+	costs := FeederCosts{
+		bySize:    make(map[feederCostKey]costTotal),
+		bySpecies: make(map[int]costTotal),
+	}
+	for _, p := range purchases {
+		if p.Quantity <= 0 {
+			continue
+		}
+		paid := p.Price + p.Shipping
+
+		species := costs.bySpecies[p.FeederSpeciesID]
+		costs.bySpecies[p.FeederSpeciesID] = costTotal{species.paid + paid, species.quantity + p.Quantity}
+
+		if p.PreySize != "" {
+			key := feederCostKey{p.FeederSpeciesID, p.PreySize}
+			sized := costs.bySize[key]
+			costs.bySize[key] = costTotal{sized.paid + paid, sized.quantity + p.Quantity}
+		}
+	}
+	return costs
+}
+
+// UnitCost prices one insect from purchases of that size, then of any size of
+// the species, then the fallback
+func (c FeederCosts) UnitCost(speciesID int, size string, fallback float64) float64 {
+	if total, ok := c.bySize[feederCostKey{speciesID, size}]; ok {
+		return total.unitCost()
+	}
+	if total, ok := c.bySpecies[speciesID]; ok {
+		return total.unitCost()
+	}
+	return fallback
+}
+
+// preyUsage is the prey one tarantula was offered of a feeder species and
+// size with one feeding status
+type preyUsage struct {
+	TarantulaID     int32
+	TarantulaName   string
+	FeederSpeciesID int
+	PreySize        string
+	FeedingStatusID int
+	PreyCount       float64
+	DefaultUnitCost float64
+}
+
+// refused reports whether the tarantula turned the prey down, which leaves
+// the feeders out of its spend
+func (u preyUsage) refused() bool {
+	status := models.FeedingStatusEnum(u.FeedingStatusID)
+	return status == models.FeedingStatusRejected || status == models.FeedingStatusPreMolt
+}
+
+// BuildSpendReport totals a year's purchases and expenses, and prices each
+// tarantula's prey from all purchases on record. Prey it refused is not
+// counted against it.
+func BuildSpendReport(year int, purchases []models.FeederPurchase, expenses []models.Expense, usage []preyUsage) models.SpendReport {
+	report := models.SpendReport{Year: year, ExpenseSpend: make(map[string]float64)}
+	costs := NewFeederCosts(purchases)
+
+	for _, p := range purchases {
+		if p.PurchaseDate.Year() != year {
+			continue
+		}
+		report.FeederSpend += p.Price + p.Shipping
+		report.FeederItems += p.Quantity
+	}
+
+	tarantulas := make(map[int32]*models.TarantulaSpend)
+	spendFor := func(id int32, name string) *models.TarantulaSpend {
+		if _, ok := tarantulas[id]; !ok {
+			tarantulas[id] = &models.TarantulaSpend{TarantulaID: id, TarantulaName: name}
+		}
+		return tarantulas[id]
+	}
+
+	for _, e := range expenses {
+		if e.ExpenseDate.Year() != year {
+			continue
+		}
+		report.ExpenseSpend[e.Category] += e.Amount
+		if e.TarantulaID != nil {
+			name := ""
+			if e.Tarantula != nil {
+				name = e.Tarantula.Name
+			}
+			spendFor(int32(*e.TarantulaID), name).ExpenseCost += e.Amount
+		}
+	}
+
+	for _, u := range usage {
+		if u.refused() {
+			continue
+		}
+		unit := costs.UnitCost(u.FeederSpeciesID, u.PreySize, u.DefaultUnitCost)
+		spendFor(u.TarantulaID, u.TarantulaName).PreyCost += unit * u.PreyCount
+	}
+
+	report.Total = report.FeederSpend
+	for _, amount := range report.ExpenseSpend {
+		report.Total += amount
+	}
+
+	for key, total := range costs.bySize {
+		report.FeederUnitCosts = append(report.FeederUnitCosts, models.FeederUnitCost{
+			FeederSpeciesID: key.speciesID,
+			PreySize:        key.size,
+			Quantity:        total.quantity,
+			UnitCost:        total.unitCost(),
+		})
+	}
+	names := make(map[int]string)
+	for _, p := range purchases {
+		names[p.FeederSpeciesID] = p.FeederSpecies.Name
+	}
+	for i := range report.FeederUnitCosts {
+		report.FeederUnitCosts[i].FeederName = names[report.FeederUnitCosts[i].FeederSpeciesID]
+	}
+	sort.Slice(report.FeederUnitCosts, func(i, j int) bool {
+		a, b := report.FeederUnitCosts[i], report.FeederUnitCosts[j]
+		if a.FeederName != b.FeederName {
+			return a.FeederName < b.FeederName
+		}
+		return a.PreySize < b.PreySize
+	})
+
+	for _, spend := range tarantulas {
+		report.Tarantulas = append(report.Tarantulas, *spend)
+	}
+	sort.Slice(report.Tarantulas, func(i, j int) bool {
+		return report.Tarantulas[i].TarantulaName < report.Tarantulas[j].TarantulaName
+	})
+
+	return report
+}
+
+// ========== Purchases and Expenses ==========
+
+// RecordFeederPurchase saves a purchase and adds the insects to the chosen
+// feeder colony, if any
+func (db *TarantulaDB) RecordFeederPurchase(ctx context.Context, purchase models.FeederPurchase) (int64, error) {
+	if purchase.Quantity <= 0 {
+		return 0, models.Invalid("quantity must be positive")
+	}
+
+	err := db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if purchase.ColonyID != nil {
+			var colony models.CricketColony
+			if err := tx.Where("id = ? AND user_id = ?", *purchase.ColonyID, purchase.UserID).First(&colony).Error; err != nil {
+				return lookupError(err, "feeder colony")
+			}
+			if colony.FeederSpeciesID != purchase.FeederSpeciesID {
+				return models.Invalid("colony %s holds a different feeder species", colony.ColonyName)
+			}
+
+			if err := tx.Model(&colony).Updates(map[string]interface{}{
+				"current_count":   gorm.Expr("current_count + ?", purchase.Quantity),
+				"last_count_date": time.Now(),
+			}).Error; err != nil {
+				return fmt.Errorf("failed to update colony count: %w", err)
+			}
+		}
+
+		if err := tx.Create(&purchase).Error; err != nil {
+			return fmt.Errorf("failed to create feeder purchase: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(purchase.ID), nil
+}
+
+func (db *TarantulaDB) RecordExpense(ctx context.Context, expense models.Expense) (int64, error) {
+	if expense.TarantulaID != nil {
+		var count int64
+		if err := db.db.WithContext(ctx).Model(&models.Tarantula{}).
+			Where("id = ? AND user_id = ?", *expense.TarantulaID, expense.UserID).
+			Count(&count).Error; err != nil {
+			return 0, fmt.Errorf("failed to check tarantula: %w", err)
+		}
+		if count == 0 {
+			return 0, models.NotFound("tarantula not found or access denied")
+		}
+	}
+
+	if err := db.db.WithContext(ctx).Create(&expense).Error; err != nil {
+		return 0, fmt.Errorf("failed to create expense: %w", err)
+	}
+
+	return int64(expense.ID), nil
+}
+
+func (db *TarantulaDB) GetFeederPurchases(ctx context.Context, userID int64) ([]models.FeederPurchase, error) {
+	var purchases []models.FeederPurchase
+
+	result := db.db.WithContext(ctx).
+		Preload("FeederSpecies").
+		Where("user_id = ?", userID).
+		Order("purchase_date DESC").
+		Find(&purchases)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get feeder purchases: %w", result.Error)
+	}
+
+	return purchases, nil
+}
+
+func (db *TarantulaDB) GetExpenses(ctx context.Context, userID int64, year int) ([]models.Expense, error) {
+	var expenses []models.Expense
+
+	result := db.db.WithContext(ctx).
+		Preload("Tarantula").
+		Where("user_id = ? AND EXTRACT(YEAR FROM expense_date) = ?", userID, year).
+		Order("expense_date DESC").
+		Find(&expenses)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get expenses: %w", result.Error)
+	}
+
+	return expenses, nil
+}
+
+func (db *TarantulaDB) GetSpendReport(ctx context.Context, userID int64, year int) (*models.SpendReport, error) {
+	purchases, err := db.GetFeederPurchases(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	expenses, err := db.GetExpenses(ctx, userID, year)
+	if err != nil {
+		return nil, err
+	}
+
+	var usage []preyUsage
+	query := `
+    SELECT
+        t.id as tarantula_id,
+        t.name as tarantula_name,
+        fe.feeder_species_id,
+        COALESCE(fe.prey_size, '') as prey_size,
+        COALESCE(fe.feeding_status_id, 0) as feeding_status_id,
+        SUM(fe.prey_count) as prey_count,
+        fsp.unit_cost as default_unit_cost
+    FROM spider_bot.tarantulas t
+    JOIN ` + memberFeedingsSQL + ` fe ON fe.tarantula_id = t.id
+        AND EXTRACT(YEAR FROM fe.feeding_date) = ?
+    JOIN spider_bot.feeder_species fsp ON fe.feeder_species_id = fsp.id
+    WHERE t.user_id = ?
+    GROUP BY t.id, t.name, fe.feeder_species_id, fe.prey_size, fe.feeding_status_id, fsp.unit_cost`
+
+	if err := db.db.WithContext(ctx).Raw(query, year, userID).Scan(&usage).Error; err != nil {
+		return nil, fmt.Errorf("failed to get prey usage: %w", err)
+	}
+
+	report := BuildSpendReport(year, purchases, expenses, usage)
+	return &report, nil
+}
@@ -0,0 +1,66 @@
+package db
+
+import (
+	"math"
+	"tarantulago/models"
+	"testing"
+	"time"
+)
+
+func TestFeederUnitCost(t *testing.T) {
+	costs := NewFeederCosts([]models.FeederPurchase{
+		{FeederSpeciesID: 1, PreySize: "Small", Quantity: 100, Price: 8, Shipping: 2},
+		{FeederSpeciesID: 1, PreySize: "Small", Quantity: 100, Price: 6},
+		{FeederSpeciesID: 1, Quantity: 50, Price: 9},
+	})
+
+	cases := []struct {
+		species int
+		size    string
+		want    float64
+	}{
+		{1, "Small", 0.08}, // both small orders
+		{1, "Large", 0.1},  // no large bought, so every cricket order
+		{2, "Small", 0.25}, // never bought, falls back
+	}
+	for _, c := range cases {
+		if got := costs.UnitCost(c.species, c.size, 0.25); math.Abs(got-c.want) > 1e-9 {
+			t.Errorf("UnitCost(%d, %s) = %v, want %v", c.species, c.size, got, c.want)
+		}
+	}
+}
+
+func TestBuildSpendReport(t *testing.T) {
+	bought := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	lastYear := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	spiderID := 7
+
+	purchases := []models.FeederPurchase{
+		{FeederSpeciesID: 1, PreySize: "Small", Quantity: 100, Price: 10, PurchaseDate: bought},
+		{FeederSpeciesID: 1, PreySize: "Small", Quantity: 100, Price: 10, PurchaseDate: lastYear},
+	}
+	expenses := []models.Expense{
+		{Category: models.ExpenseVet, Amount: 40, ExpenseDate: bought, TarantulaID: &spiderID},
+		{Category: models.ExpenseSubstrate, Amount: 15, ExpenseDate: bought},
+	}
+	usage := []preyUsage{
+		{TarantulaID: 7, TarantulaName: "Rosie", FeederSpeciesID: 1, PreySize: "Small", FeedingStatusID: int(models.FeedingStatusAccepted), PreyCount: 30, DefaultUnitCost: 0.5},
+		// Refused prey isn't spend on Rosie
+		{TarantulaID: 7, TarantulaName: "Rosie", FeederSpeciesID: 1, PreySize: "Small", FeedingStatusID: int(models.FeedingStatusRejected), PreyCount: 20, DefaultUnitCost: 0.5},
+	}
+
+	report := BuildSpendReport(2025, purchases, expenses, usage)
+	if report.FeederSpend != 10 || report.FeederItems != 100 {
+		t.Errorf("feeder spend = %v for %d, want 10 for 100", report.FeederSpend, report.FeederItems)
+	}
+	if report.Total != 65 {
+		t.Errorf("total = %v, want 65", report.Total)
+	}
+	if len(report.Tarantulas) != 1 {
+		t.Fatalf("tarantulas = %v, want only Rosie", report.Tarantulas)
+	}
+	rosie := report.Tarantulas[0]
+	if math.Abs(rosie.PreyCost-3) > 1e-9 || rosie.ExpenseCost != 40 {
+		t.Errorf("Rosie = %+v, want $3 prey and $40 other", rosie)
+	}
+}
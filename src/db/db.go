@@ -3,7 +3,9 @@ package db
 import (
 	"context"
 	"fmt"
+	"math"
 	"strings"
+	"tarantulago/logging"
 	"tarantulago/models"
 	"time"
 
@@ -13,26 +15,62 @@ import (
 )
 
 type TarantulaDB struct {
-	db *gorm.DB
+	db              *gorm.DB
+	defaultSettings models.UserSettings
 }
 
-func NewTarantulaDB(connectionString string) (*TarantulaDB, error) {
+// Options size the connection pool and set what new users start with. Zero
+// pool values keep the driver's defaults; nil settings use
+// models.UserSettings.Defaults.
+type Options struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	// SlowQueryThreshold is when a statement is logged as slow
+	SlowQueryThreshold time.Duration
+	DefaultSettings    *models.UserSettings
+}
+
+func NewTarantulaDB(ctx context.Context, connectionString string, opts Options) (*TarantulaDB, error) {
 	db, err := gorm.Open(postgres.Open(connectionString), &gorm.Config{
 		NamingStrategy: schema.NamingStrategy{
 			TablePrefix: "spider_bot.",
 		},
+		Logger: logging.GormLogger{SlowThreshold: opts.SlowQueryThreshold},
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 	db = db.Set("gorm:table_options", "")
-	err = db.AutoMigrate(
+	if err := instrument(db); err != nil {
+		return nil, fmt.Errorf("failed to instrument database: %w", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database pool: %w", err)
+	}
+	if opts.MaxOpenConns > 0 {
+		sqlDB.SetMaxOpenConns(opts.MaxOpenConns)
+	}
+	if opts.MaxIdleConns > 0 {
+		sqlDB.SetMaxIdleConns(opts.MaxIdleConns)
+	}
+	if opts.ConnMaxLifetime > 0 {
+		sqlDB.SetConnMaxLifetime(opts.ConnMaxLifetime)
+	}
+
+	err = db.WithContext(ctx).AutoMigrate(
 		&models.Tarantula{},
 		&models.TarantulaSpecies{},
 		&models.MoltStage{},
 		&models.HealthStatus{},
+		&models.FeederSpecies{},
 		&models.FeedingEvent{},
 		&models.CricketColony{},
+		&models.FeederClutch{},
+		&models.FeederPurchase{},
+		&models.Expense{},
 		&models.Enclosure{},
 		&models.FeedingFrequency{},
 		&models.FeedingSchedule{},
@@ -44,12 +82,35 @@ func NewTarantulaDB(connectionString string) (*TarantulaDB, error) {
 		&models.TarantulaPhoto{},
 		&models.TarantulaColony{},
 		&models.TarantulaColonyMember{},
+		&models.ColonyCensus{},
+		&models.ColonyMemberEvent{},
+		&models.ColonyFeedingShare{},
+		&models.CollectionMember{},
+		&models.CollectionInvite{},
+		&models.GroupChat{},
+		&models.CareLog{},
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to migrate database: %w", err)
 	}
 
-	return &TarantulaDB{db: db}, nil
+	database := &TarantulaDB{db: db, defaultSettings: models.UserSettings{}.Defaults()}
+	if opts.DefaultSettings != nil {
+		database.defaultSettings = *opts.DefaultSettings
+	}
+	return database, nil
+}
+
+// Close closes the connection pool once nothing is using the database anymore
+func (db *TarantulaDB) Close() error {
+	sqlDB, err := db.db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get database pool: %w", err)
+	}
+	if err := sqlDB.Close(); err != nil {
+		return fmt.Errorf("failed to close database: %w", err)
+	}
+	return nil
 }
 
 func (db *TarantulaDB) GetRecentHealthRecords(ctx context.Context, userID int64, limit int32) ([]models.HealthCheckRecord, error) {
@@ -107,52 +168,69 @@ func (db *TarantulaDB) RecordFeeding(ctx context.Context, event models.FeedingEv
 			// Colony feeding - validate colony exists
 			var tarantulaColony models.TarantulaColony
 			if err := tx.Where("id = ? AND user_id = ?", *event.TarantulaColonyID, event.UserID).First(&tarantulaColony).Error; err != nil {
-				return fmt.Errorf("tarantula colony not found or access denied: %w", err)
+				return lookupError(err, "tarantula colony")
 			}
 		} else if event.TarantulaID != nil && *event.TarantulaID > 0 {
 			// Individual feeding - validate tarantula exists
 			var tarantula models.Tarantula
 			if err := tx.Where("id = ? AND user_id = ?", *event.TarantulaID, event.UserID).First(&tarantula).Error; err != nil {
-				return fmt.Errorf("tarantula not found or access denied: %w", err)
+				return lookupError(err, "tarantula")
 			}
 		} else {
-			return fmt.Errorf("feeding event must specify either a tarantula or a colony")
+			return models.Invalid("feeding event must specify either a tarantula or a colony")
 		}
 
-		var colony models.CricketColony
-		if err := tx.Where("user_id = ?", event.UserID).First(&colony).Error; err != nil {
-			return fmt.Errorf("no cricket colony found for user: %w", err)
+		if event.FeederSpeciesID == 0 {
+			event.FeederSpeciesID = models.DefaultFeederSpeciesID
 		}
 
-		if colony.CurrentCount < event.NumberOfCrickets {
-			colony.CurrentCount = 100
-			if err := tx.Model(&colony).Update("current_count", colony.CurrentCount).Error; err != nil {
-				return fmt.Errorf("failed to auto-refill colony: %w", err)
-			}
+		if event.PreyCount < 0 {
+			return models.Invalid("prey count can't be negative")
+		}
+
+		// Prey from a feeder colony is taken from its count; bought prey is not tracked
+		if err := takeFromFeederColony(tx, event); err != nil {
+			return err
 		}
 
-		result := tx.Model(&colony).
-			UpdateColumn("current_count", gorm.Expr("current_count - ?", event.NumberOfCrickets))
+		mass, err := preyMass(tx, event.FeederSpeciesID, event.PreySize, event.PreyCount)
+		if err != nil {
+			return err
+		}
 
-		if result.Error != nil {
-			return fmt.Errorf("failed to update colony count: %w", result.Error)
+		// Feedings are recorded as happening now and accepted unless the
+		// event says otherwise
+		if event.FeedingDate.IsZero() {
+			event.FeedingDate = time.Now()
+		}
+		if event.FeedingStatusID == 0 {
+			event.FeedingStatusID = int(models.FeedingStatusAccepted)
 		}
 
 		feedingEvent := models.FeedingEvent{
 			TarantulaID:       event.TarantulaID,
 			TarantulaColonyID: event.TarantulaColonyID,
-			FeedingDate:       time.Now(),
-			CricketColonyID:   colony.ID,
-			NumberOfCrickets:  event.NumberOfCrickets,
-			FeedingStatusID:   int(models.FeedingStatusAccepted),
+			FeedingDate:       event.FeedingDate,
+			CricketColonyID:   event.CricketColonyID,
+			FeederSpeciesID:   event.FeederSpeciesID,
+			PreySize:          event.PreySize,
+			PreyCount:         event.PreyCount,
+			PreKilled:         event.PreKilled,
+			PreyMassGrams:     mass,
+			FeedingStatusID:   event.FeedingStatusID,
 			Notes:             event.Notes,
 			UserID:            event.UserID,
+			RecordedBy:        event.RecordedBy,
 		}
 
 		if err := tx.Create(&feedingEvent).Error; err != nil {
 			return fmt.Errorf("failed to create feeding event: %w", err)
 		}
 
+		if err := creditColonyMembers(tx, feedingEvent); err != nil {
+			return err
+		}
+
 		id = int64(feedingEvent.ID)
 		return nil
 	})
@@ -164,6 +242,33 @@ func (db *TarantulaDB) RecordFeeding(ctx context.Context, event models.FeedingEv
 	return id, nil
 }
 
+// DeleteFeeding removes a feeding, returning its prey to the feeder colony
+// it was taken from
+func (db *TarantulaDB) DeleteFeeding(ctx context.Context, feedingID int64, userID int64) error {
+	return db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var event models.FeedingEvent
+		if err := tx.Where("id = ? AND user_id = ?", feedingID, userID).First(&event).Error; err != nil {
+			return lookupError(err, "feeding")
+		}
+
+		if event.CricketColonyID != nil {
+			if err := tx.Model(&models.CricketColony{}).
+				Where("id = ? AND user_id = ?", *event.CricketColonyID, userID).
+				UpdateColumn("current_count", gorm.Expr("current_count + ?", event.PreyCount)).Error; err != nil {
+				return fmt.Errorf("failed to return prey to colony: %w", err)
+			}
+		}
+
+		if err := tx.Where("feeding_event_id = ?", event.ID).Delete(&models.ColonyFeedingShare{}).Error; err != nil {
+			return fmt.Errorf("failed to delete colony feeding shares: %w", err)
+		}
+		if err := tx.Delete(&event).Error; err != nil {
+			return fmt.Errorf("failed to delete feeding: %w", err)
+		}
+		return nil
+	})
+}
+
 func (db *TarantulaDB) GetTarantulasDueFeeding(ctx context.Context, userID int64) ([]models.TarantulaListItem, error) {
 	var items []models.TarantulaListItem
 
@@ -180,7 +285,8 @@ func (db *TarantulaDB) GetTarantulasDueFeeding(ctx context.Context, userID int64
 
     UNION ALL
 
-    -- Get colony feedings for tarantulas that are members
+    -- Get colony feedings from while each tarantula was a member, including
+    -- colonies it has since left through a split, merge or removal
     SELECT
         tcm.tarantula_id,
         MAX(fe.feeding_date) as last_feeding_date,
@@ -189,7 +295,6 @@ func (db *TarantulaDB) GetTarantulasDueFeeding(ctx context.Context, userID int64
     INNER JOIN spider_bot.tarantula_colony_members tcm
         ON fe.tarantula_colony_id = tcm.colony_id
     WHERE fe.tarantula_colony_id IS NOT NULL
-      AND tcm.is_active = true
       AND (tcm.left_date IS NULL OR fe.feeding_date <= tcm.left_date)
       AND fe.feeding_date >= tcm.joined_date
     GROUP BY tcm.tarantula_id
@@ -258,6 +363,7 @@ SELECT
     ms.frequency_id,
     ms.min_days,
     ms.max_days,
+    COALESCE(e.name, '') as enclosure_name,
     CASE
         WHEN molt.stage_name = 'Pre-molt' THEN 'In pre-molt'
         WHEN lf.days_since_feeding IS NULL THEN 'Never fed'
@@ -268,6 +374,7 @@ SELECT
 FROM spider_bot.tarantulas t
          JOIN spider_bot.tarantula_species ts ON t.species_id = ts.id
          LEFT JOIN spider_bot.molt_stages molt ON t.current_molt_stage_id = molt.id
+         LEFT JOIN spider_bot.enclosures e ON t.enclosure_id = e.id
          LEFT JOIN CombinedFeeding lf ON t.id = lf.tarantula_id
          LEFT JOIN MatchingSchedule ms ON t.id = ms.tarantula_id
 WHERE t.user_id = ?
@@ -300,7 +407,7 @@ func (db *TarantulaDB) GetTarantulaByID(ctx context.Context, userID int64, id in
 
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("tarantula not found")
+			return nil, models.NotFound("tarantula not found")
 		}
 		return nil, fmt.Errorf("failed to get tarantula: %w", result.Error)
 	}
@@ -315,8 +422,10 @@ func (db *TarantulaDB) GetRecentFeedingRecords(ctx context.Context, userID int64
 		Preload("Tarantula").
 		Preload("TarantulaColony").
 		Preload("CricketColony").
+		Preload("FeederSpecies").
 		Preload("FeedingStatus").
 		Preload("User").
+		Preload("Recorder").
 		Where("user_id = ?", userID).
 		Order("feeding_date DESC").
 		Limit(int(limit)).
@@ -329,6 +438,29 @@ func (db *TarantulaDB) GetRecentFeedingRecords(ctx context.Context, userID int64
 	return records, nil
 }
 
+// GetLastFeeding returns the tarantula's latest feeding, including its share of
+// colony feedings, with who recorded it; nil if it has never been fed
+func (db *TarantulaDB) GetLastFeeding(ctx context.Context, tarantulaID int32, userID int64) (*models.FeedingEvent, error) {
+	var feeding models.FeedingEvent
+
+	err := db.db.WithContext(ctx).
+		Preload("Recorder").
+		Where("user_id = ?", userID).
+		Where("tarantula_id = ? OR id IN (?)", tarantulaID,
+			db.db.Model(&models.ColonyFeedingShare{}).Select("feeding_event_id").Where("tarantula_id = ?", tarantulaID)).
+		Order("feeding_date DESC").
+		First(&feeding).Error
+
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last feeding: %w", err)
+	}
+
+	return &feeding, nil
+}
+
 func (db *TarantulaDB) GetAllTarantulas(ctx context.Context, userID int64) ([]models.TarantulaListItem, error) {
 	var items []models.TarantulaListItem
 
@@ -395,7 +527,7 @@ func (db *TarantulaDB) UpdateTarantulaEnclosure(ctx context.Context, tarantulaID
 	}
 
 	if result.RowsAffected == 0 {
-		return fmt.Errorf("tarantula not found or access denied")
+		return models.NotFound("tarantula not found or access denied")
 	}
 
 	return nil
@@ -449,7 +581,7 @@ func (db *TarantulaDB) RecordHealthCheck(ctx context.Context, healthCheck models
 		}
 
 		if result.RowsAffected == 0 {
-			return fmt.Errorf("tarantula not found or access denied")
+			return models.NotFound("tarantula not found or access denied")
 		}
 
 		if err := tx.Create(&healthCheck).Error; err != nil {
@@ -508,48 +640,113 @@ func (db *TarantulaDB) GetHealthAlerts(ctx context.Context, userID int64) ([]mod
 	return alerts, nil
 }
 
-func (db *TarantulaDB) RecordMolt(ctx context.Context, molt models.MoltRecord) error {
-	return db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// Get user settings to determine post-molt mute duration
-		var settings models.UserSettings
-		if err := tx.Where("user_id = ?", molt.UserID).First(&settings).Error; err != nil {
-			// If settings not found, use default
-			settings = models.UserSettings{PostMoltMuteDays: 7}
-		}
+func (db *TarantulaDB) RecordMolt(ctx context.Context, molt models.MoltRecord) (int64, error) {
+	err := db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return recordMolt(tx, &molt)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int64(molt.ID), nil
+}
 
-		// Calculate post-molt mute period
-		muteUntil := time.Now().AddDate(0, 0, settings.PostMoltMuteDays)
+// recordMolt saves a molt and moves the tarantula to post-molt, muting
+// feeding reminders for the user's post-molt period
+func recordMolt(tx *gorm.DB, molt *models.MoltRecord) error {
+	// Get user settings to determine post-molt mute duration
+	var settings models.UserSettings
+	if err := tx.Where("user_id = ?", molt.UserID).First(&settings).Error; err != nil {
+		// If settings not found, use default
+		settings = models.UserSettings{PostMoltMuteDays: 7}
+	}
 
-		result := tx.Model(&models.Tarantula{}).
-			Where("id = ? AND user_id = ?", molt.TarantulaID, molt.UserID).
-			Updates(map[string]interface{}{
-				"last_molt_date":        time.Now(),
-				"current_molt_stage_id": models.MoltStagePostMolt,
-				"post_molt_mute_until":  muteUntil,
-			})
+	if molt.MoltDate.IsZero() {
+		molt.MoltDate = time.Now()
+	}
 
-		if result.Error != nil {
-			return fmt.Errorf("failed to update tarantula molt status: %w", result.Error)
-		}
+	// Calculate post-molt mute period
+	muteUntil := molt.MoltDate.AddDate(0, 0, settings.PostMoltMuteDays)
 
-		if result.RowsAffected == 0 {
-			return fmt.Errorf("tarantula not found or access denied")
+	result := tx.Model(&models.Tarantula{}).
+		Where("id = ? AND user_id = ?", molt.TarantulaID, molt.UserID).
+		Updates(map[string]interface{}{
+			"last_molt_date":        molt.MoltDate,
+			"current_molt_stage_id": models.MoltStagePostMolt,
+			"post_molt_mute_until":  muteUntil,
+		})
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to update tarantula molt status: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return models.NotFound("tarantula not found or access denied")
+	}
+
+	if err := tx.Create(molt).Error; err != nil {
+		return fmt.Errorf("failed to create molt record: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteMolt removes a molt, taking the tarantula back to its previous molt
+// date and out of post-molt
+func (db *TarantulaDB) DeleteMolt(ctx context.Context, moltID int64, userID int64) error {
+	return db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var molt models.MoltRecord
+		if err := tx.Where("id = ? AND user_id = ?", moltID, userID).First(&molt).Error; err != nil {
+			return lookupError(err, "molt")
+		}
+		if err := tx.Delete(&molt).Error; err != nil {
+			return fmt.Errorf("failed to delete molt: %w", err)
 		}
 
-		if err := tx.Create(&molt).Error; err != nil {
-			return fmt.Errorf("failed to create molt record: %w", err)
+		var lastMoltDate *time.Time
+		var previous models.MoltRecord
+		err := tx.Where("tarantula_id = ? AND user_id = ?", molt.TarantulaID, userID).Order("molt_date DESC").First(&previous).Error
+		switch {
+		case err == nil:
+			lastMoltDate = &previous.MoltDate
+		case err != gorm.ErrRecordNotFound:
+			return fmt.Errorf("failed to get previous molt: %w", err)
 		}
 
+		if err := tx.Model(&models.Tarantula{}).
+			Where("id = ? AND user_id = ?", molt.TarantulaID, userID).
+			Updates(map[string]interface{}{
+				"last_molt_date":        lastMoltDate,
+				"current_molt_stage_id": models.MoltStageNormal,
+				"post_molt_mute_until":  nil,
+			}).Error; err != nil {
+			return fmt.Errorf("failed to update tarantula molt status: %w", err)
+		}
 		return nil
 	})
 }
 
+// SetMoltStage moves a tarantula to a molt stage, such as premolt when it
+// starts refusing food
+func (db *TarantulaDB) SetMoltStage(ctx context.Context, tarantulaID int32, userID int64, stage models.MoltStageEnum) error {
+	result := db.db.WithContext(ctx).Model(&models.Tarantula{}).
+		Where("id = ? AND user_id = ?", tarantulaID, userID).
+		Update("current_molt_stage_id", stage)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update molt stage: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return models.NotFound("tarantula not found")
+	}
+	return nil
+}
+
 func (db *TarantulaDB) GetRecentMoltRecords(ctx context.Context, userID int64, limit int32) ([]models.MoltRecord, error) {
 	var records []models.MoltRecord
 
 	result := db.db.WithContext(ctx).
 		Preload("Tarantula").
 		Preload("MoltStage").
+		Preload("Recorder").
 		Model(&models.MoltRecord{}).
 		Joins("JOIN spider_bot.tarantulas ON spider_bot.molt_records.tarantula_id = spider_bot.tarantulas.id").
 		Where("spider_bot.tarantulas.user_id = ?", userID).
@@ -580,18 +777,22 @@ func (db *TarantulaDB) GetColonyStatus(ctx context.Context, userID int64) ([]mod
         SELECT
             cc.id,
             cc.colony_name,
+            cc.feeder_species_id,
+            fsp.name as feeder_name,
             cc.current_count,
-            COALESCE(SUM(fe.number_of_crickets), 0) as crickets_used_7_days,
+            COALESCE(cc.prey_size, '') as size_name,
+            COALESCE(SUM(fe.prey_count), 0) as used_last_7_days,
             CASE
-                WHEN SUM(fe.number_of_crickets) > 0
-                THEN cc.current_count::FLOAT / (SUM(fe.number_of_crickets)::FLOAT / 7.0)
+                WHEN SUM(fe.prey_count) > 0
+                THEN cc.current_count::FLOAT / (SUM(fe.prey_count)::FLOAT / 7.0)
                 ELSE NULL
             END as weeks_remaining
         FROM spider_bot.cricket_colonies cc
+        JOIN spider_bot.feeder_species fsp ON cc.feeder_species_id = fsp.id
         LEFT JOIN spider_bot.feeding_events fe ON cc.id = fe.cricket_colony_id
             AND fe.feeding_date >= CURRENT_DATE - INTERVAL '7 days'
         WHERE cc.user_id = ?
-        GROUP BY cc.id, cc.colony_name, cc.current_count
+        GROUP BY cc.id, cc.colony_name, cc.feeder_species_id, fsp.name, cc.current_count, cc.prey_size
         ORDER BY weeks_remaining ASC NULLS LAST`, userID).
 		Scan(&colonies)
 
@@ -613,7 +814,7 @@ func (db *TarantulaDB) UpdateColonyCount(ctx context.Context, colonyID int32, ad
 	}
 
 	if result.RowsAffected == 0 {
-		return fmt.Errorf("colony not found or access denied")
+		return models.NotFound("colony not found or access denied")
 	}
 
 	return nil
@@ -700,7 +901,7 @@ func (db *TarantulaDB) GetEnclosure(ctx context.Context, id, userID int64) (*mod
 
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("enclosure not found")
+			return nil, models.NotFound("enclosure not found")
 		}
 		return nil, fmt.Errorf("failed to get enclosure: %w", result.Error)
 	}
@@ -708,6 +909,21 @@ func (db *TarantulaDB) GetEnclosure(ctx context.Context, id, userID int64) (*mod
 	return &enclosure, nil
 }
 
+func (db *TarantulaDB) GetEnclosures(ctx context.Context, userID int64) ([]models.Enclosure, error) {
+	var enclosures []models.Enclosure
+
+	result := db.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("name").
+		Find(&enclosures)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get enclosures: %w", result.Error)
+	}
+
+	return enclosures, nil
+}
+
 func (db *TarantulaDB) GetCurrentSize(ctx context.Context, tarantulaID int32) (float32, error) {
 	var size float32
 
@@ -795,7 +1011,7 @@ func (db *TarantulaDB) UpdateUserSettings(ctx context.Context, settings *models.
 }
 
 func (db *TarantulaDB) createDefaultSettings(ctx context.Context, userID int64) (*models.UserSettings, error) {
-	settings := models.UserSettings{}.Defaults()
+	settings := db.defaultSettings
 	settings.UserID = userID
 	result := db.db.WithContext(ctx).Create(&settings)
 	if result.Error != nil {
@@ -952,7 +1168,9 @@ func (db *TarantulaDB) GetMaintenanceTypes(ctx context.Context) ([]models.Colony
 }
 
 func (db *TarantulaDB) RecordWeight(ctx context.Context, weight models.WeightRecord) (int64, error) {
-	weight.WeighDate = time.Now()
+	if weight.WeighDate.IsZero() {
+		weight.WeighDate = time.Now()
+	}
 	weight.CreatedAt = time.Now()
 
 	err := db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
@@ -961,12 +1179,11 @@ func (db *TarantulaDB) RecordWeight(ctx context.Context, weight models.WeightRec
 			return fmt.Errorf("failed to create weight record: %w", err)
 		}
 
-		updateTime := time.Now()
 		if err := tx.Model(&models.Tarantula{}).
 			Where("id = ? AND user_id = ?", weight.TarantulaID, weight.UserID).
 			Updates(map[string]interface{}{
 				"current_weight_grams": weight.WeightGrams,
-				"last_weigh_date":      &updateTime,
+				"last_weigh_date":      &weight.WeighDate,
 			}).Error; err != nil {
 			return fmt.Errorf("failed to update tarantula weight: %w", err)
 		}
@@ -981,6 +1198,37 @@ func (db *TarantulaDB) RecordWeight(ctx context.Context, weight models.WeightRec
 	return int64(weight.ID), nil
 }
 
+// DeleteWeight removes a weighing, taking the tarantula back to its
+// previous weight
+func (db *TarantulaDB) DeleteWeight(ctx context.Context, weightID int64, userID int64) error {
+	return db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var weight models.WeightRecord
+		if err := tx.Where("id = ? AND user_id = ?", weightID, userID).First(&weight).Error; err != nil {
+			return lookupError(err, "weight")
+		}
+		if err := tx.Delete(&weight).Error; err != nil {
+			return fmt.Errorf("failed to delete weight: %w", err)
+		}
+
+		updates := map[string]interface{}{"current_weight_grams": nil, "last_weigh_date": nil}
+		var previous models.WeightRecord
+		err := tx.Where("tarantula_id = ? AND user_id = ?", weight.TarantulaID, userID).Order("weigh_date DESC").First(&previous).Error
+		switch {
+		case err == nil:
+			updates = map[string]interface{}{"current_weight_grams": previous.WeightGrams, "last_weigh_date": previous.WeighDate}
+		case err != gorm.ErrRecordNotFound:
+			return fmt.Errorf("failed to get previous weight: %w", err)
+		}
+
+		if err := tx.Model(&models.Tarantula{}).
+			Where("id = ? AND user_id = ?", weight.TarantulaID, userID).
+			Updates(updates).Error; err != nil {
+			return fmt.Errorf("failed to update tarantula weight: %w", err)
+		}
+		return nil
+	})
+}
+
 func (db *TarantulaDB) GetWeightHistory(ctx context.Context, tarantulaID int32, userID int64, limit int32) ([]models.WeightRecord, error) {
 	var weights []models.WeightRecord
 
@@ -1054,88 +1302,170 @@ func (db *TarantulaDB) UpdateTarantulaProfilePhoto(ctx context.Context, tarantul
 	}
 
 	if result.RowsAffected == 0 {
-		return fmt.Errorf("tarantula not found or access denied")
+		return models.NotFound("tarantula not found or access denied")
 	}
 
 	return nil
 }
 
-func (db *TarantulaDB) QuickFeed(ctx context.Context, tarantulaID int32, userID int64) error {
+func (db *TarantulaDB) QuickFeed(ctx context.Context, tarantulaID int32, userID, recordedBy int64) error {
 	return db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-
-		var colony models.CricketColony
-		if err := tx.Where("user_id = ?", userID).First(&colony).Error; err != nil {
-			return fmt.Errorf("no cricket colony found: %w", err)
-		}
-
-		if colony.CurrentCount < 1 {
-			return fmt.Errorf("no crickets available in colony")
-		}
-
 		tid := int(tarantulaID)
-		feedingEvent := models.FeedingEvent{
-			TarantulaID:      &tid,
-			FeedingDate:      time.Now(),
-			CricketColonyID:  colony.ID,
-			NumberOfCrickets: 1,
-			FeedingStatusID:  int(models.FeedingStatusAccepted),
-			Notes:            "Quick feed",
-			UserID:           userID,
+		feedingEvent, err := quickFeedPrey(tx, userID, "tarantula_id", tid)
+		if err != nil {
+			return err
 		}
+		feedingEvent.TarantulaID = &tid
+		feedingEvent.Notes = "Quick feed"
+		feedingEvent.RecordedBy = &recordedBy
 
 		if err := tx.Create(&feedingEvent).Error; err != nil {
 			return fmt.Errorf("failed to create feeding event: %w", err)
 		}
 
-		if err := tx.Model(&colony).
-			UpdateColumn("current_count", gorm.Expr("current_count - 1")).Error; err != nil {
-			return fmt.Errorf("failed to update colony count: %w", err)
-		}
-
-		return nil
+		return takeFromFeederColony(tx, feedingEvent)
 	})
 }
 
-func (db *TarantulaDB) QuickFeedColony(ctx context.Context, tarantulaColonyID int32, userID int64) error {
-	return db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-
-		// Verify colony exists and belongs to user
-		var tarantulaColony models.TarantulaColony
-		if err := tx.Where("id = ? AND user_id = ?", tarantulaColonyID, userID).First(&tarantulaColony).Error; err != nil {
-			return fmt.Errorf("tarantula colony not found: %w", err)
-		}
+// QuickFeedColony repeats the colony's usual prey, offering as many as its
+// feeding plan suggests in the size its smallest feeding member needs
+func (db *TarantulaDB) QuickFeedColony(ctx context.Context, tarantulaColonyID int32, userID, recordedBy int64) (*models.FeedingEvent, error) {
+	plan, err := db.GetColonyFeedingPlan(ctx, tarantulaColonyID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if plan.FeedingMembers == 0 {
+		return nil, models.Invalid("no members are eating right now")
+	}
 
-		var cricketColony models.CricketColony
-		if err := tx.Where("user_id = ?", userID).First(&cricketColony).Error; err != nil {
-			return fmt.Errorf("no cricket colony found: %w", err)
+	var feedingEvent models.FeedingEvent
+	err = db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		cid := int(tarantulaColonyID)
+		var err error
+		feedingEvent, err = quickFeedPrey(tx, userID, "tarantula_colony_id", cid)
+		if err != nil {
+			return err
 		}
-
-		if cricketColony.CurrentCount < 1 {
-			return fmt.Errorf("no crickets available in colony")
+		feedingEvent.TarantulaColonyID = &cid
+		feedingEvent.Notes = "Quick feed - colony"
+		feedingEvent.RecordedBy = &recordedBy
+		feedingEvent.PreyCount = plan.PreyCount
+		if plan.PreySize != "" {
+			feedingEvent.PreySize = plan.PreySize
 		}
 
-		cid := int(tarantulaColonyID)
-		feedingEvent := models.FeedingEvent{
-			TarantulaColonyID: &cid,
-			FeedingDate:       time.Now(),
-			CricketColonyID:   cricketColony.ID,
-			NumberOfCrickets:  1,
-			FeedingStatusID:   int(models.FeedingStatusAccepted),
-			Notes:             "Quick feed - colony",
-			UserID:            userID,
+		feedingEvent.PreyMassGrams, err = preyMass(tx, feedingEvent.FeederSpeciesID, feedingEvent.PreySize, feedingEvent.PreyCount)
+		if err != nil {
+			return err
 		}
 
 		if err := tx.Create(&feedingEvent).Error; err != nil {
 			return fmt.Errorf("failed to create feeding event: %w", err)
 		}
 
-		if err := tx.Model(&cricketColony).
-			UpdateColumn("current_count", gorm.Expr("current_count - 1")).Error; err != nil {
-			return fmt.Errorf("failed to update cricket colony count: %w", err)
+		if err := creditColonyMembers(tx, feedingEvent); err != nil {
+			return err
+		}
+		return takeFromFeederColony(tx, feedingEvent)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &feedingEvent, nil
+}
+
+// quickFeedPrey builds a single-prey feeding that repeats the last prey offered
+// to the same animal, then the user's last prey of any kind, and otherwise
+// takes a medium insect from the user's first feeder colony.
+func quickFeedPrey(tx *gorm.DB, userID int64, targetColumn string, targetID int) (models.FeedingEvent, error) {
+	event := models.FeedingEvent{
+		FeedingDate:     time.Now(),
+		FeederSpeciesID: models.DefaultFeederSpeciesID,
+		PreySize:        models.PreySizeMedium,
+		PreyCount:       1,
+		FeedingStatusID: int(models.FeedingStatusAccepted),
+		UserID:          userID,
+	}
+
+	var last models.FeedingEvent
+	err := tx.Where("user_id = ? AND "+targetColumn+" = ?", userID, targetID).Order("feeding_date DESC").First(&last).Error
+	if err == gorm.ErrRecordNotFound {
+		err = tx.Where("user_id = ?", userID).Order("feeding_date DESC").First(&last).Error
+	}
+
+	switch {
+	case err == nil:
+		event.FeederSpeciesID = last.FeederSpeciesID
+		if last.PreySize != "" {
+			event.PreySize = last.PreySize
+		}
+		event.PreKilled = last.PreKilled
+		event.CricketColonyID = last.CricketColonyID
+	case err == gorm.ErrRecordNotFound:
+		var colony models.CricketColony
+		if err := tx.Where("user_id = ?", userID).First(&colony).Error; err != nil {
+			return event, lookupError(err, "feeder colony")
 		}
+		event.FeederSpeciesID = colony.FeederSpeciesID
+		event.CricketColonyID = &colony.ID
+	default:
+		return event, fmt.Errorf("failed to get last feeding: %w", err)
+	}
 
+	mass, err := preyMass(tx, event.FeederSpeciesID, event.PreySize, event.PreyCount)
+	if err != nil {
+		return event, err
+	}
+	event.PreyMassGrams = mass
+
+	return event, nil
+}
+
+// takeFromFeederColony decrements the colony a feeding's prey came from,
+// which must hold the feeding's feeder species.
+func takeFromFeederColony(tx *gorm.DB, event models.FeedingEvent) error {
+	if event.CricketColonyID == nil {
 		return nil
-	})
+	}
+	if event.PreyCount <= 0 {
+		return models.Invalid("prey taken from a feeder colony must be at least one")
+	}
+
+	var colony models.CricketColony
+	if err := tx.Where("id = ? AND user_id = ?", *event.CricketColonyID, event.UserID).First(&colony).Error; err != nil {
+		return lookupError(err, "feeder colony")
+	}
+	if colony.FeederSpeciesID != event.FeederSpeciesID {
+		return models.Invalid("feeder colony %q holds a different feeder species", colony.ColonyName)
+	}
+
+	if colony.CurrentCount < event.PreyCount {
+		return models.InsufficientStock("no feeders available in colony %q", colony.ColonyName)
+	}
+
+	if err := tx.Model(&colony).
+		UpdateColumn("current_count", gorm.Expr("current_count - ?", event.PreyCount)).Error; err != nil {
+		return fmt.Errorf("failed to update colony count: %w", err)
+	}
+
+	return nil
+}
+
+func preyMass(tx *gorm.DB, feederSpeciesID int, size string, count int) (float64, error) {
+	var feeder models.FeederSpecies
+	if err := tx.First(&feeder, feederSpeciesID).Error; err != nil {
+		return 0, lookupError(err, "feeder species")
+	}
+	return feeder.MassGrams(size) * float64(count), nil
+}
+
+func (db *TarantulaDB) GetFeederSpecies(ctx context.Context) ([]models.FeederSpecies, error) {
+	var feeders []models.FeederSpecies
+	if err := db.db.WithContext(ctx).Order("id").Find(&feeders).Error; err != nil {
+		return nil, fmt.Errorf("failed to get feeder species: %w", err)
+	}
+	return feeders, nil
 }
 
 func (db *TarantulaDB) GetFeedingPatterns(ctx context.Context, userID int64) ([]models.FeedingPattern, error) {
@@ -1157,8 +1487,11 @@ func (db *TarantulaDB) GetFeedingPatterns(ctx context.Context, userID int64) ([]
         MAX(fe.feeding_date) as last_feeding_date,
         COALESCE(EXTRACT(DAY FROM (NOW() - MAX(fe.feeding_date))), 999) as days_since_last_feeding,
         COALESCE(
-            COUNT(fe.id) * 7.0 / NULLIF(EXTRACT(DAY FROM (MAX(fe.feeding_date) - MIN(fe.feeding_date))), 0), 0
-        ) as crickets_per_week,
+            SUM(fe.prey_count) * 7.0 / NULLIF(EXTRACT(DAY FROM (MAX(fe.feeding_date) - MIN(fe.feeding_date))), 0), 0
+        ) as prey_items_per_week,
+        COALESCE(
+            SUM(fe.prey_mass_grams) * 7.0 / NULLIF(EXTRACT(DAY FROM (MAX(fe.feeding_date) - MIN(fe.feeding_date))), 0), 0
+        ) as prey_mass_per_week,
         CASE 
             WHEN COUNT(fe.id) < 3 THEN 'Insufficient Data'
             WHEN EXTRACT(DAY FROM (MAX(fe.feeding_date) - MIN(fe.feeding_date))) / NULLIF(COUNT(fe.id) - 1, 0) BETWEEN 7 AND 14 THEN 'Regular'
@@ -1166,7 +1499,7 @@ func (db *TarantulaDB) GetFeedingPatterns(ctx context.Context, userID int64) ([]
             ELSE 'Inconsistent'
         END as feeding_regularity
     FROM spider_bot.tarantulas t
-    LEFT JOIN spider_bot.feeding_events fe ON t.id = fe.tarantula_id
+    LEFT JOIN ` + memberFeedingsSQL + ` fe ON t.id = fe.tarantula_id
     LEFT JOIN spider_bot.feeding_statuses fs ON fe.feeding_status_id = fs.id
     WHERE t.user_id = ?
     GROUP BY t.id, t.name
@@ -1280,19 +1613,19 @@ func (db *TarantulaDB) GetGrowthData(ctx context.Context, userID int64) ([]model
 func (db *TarantulaDB) GenerateAnnualReport(ctx context.Context, userID int64, year int) ([]models.AnnualReport, error) {
 
 	type AnnualReportTemp struct {
-		Year            int      `json:"year"`
-		TarantulaID     int32    `json:"tarantula_id"`
-		TarantulaName   string   `json:"tarantula_name"`
-		TotalFeedings   int32    `json:"total_feedings"`
-		TotalCrickets   int32    `json:"total_crickets"`
-		AcceptanceRate  float64  `json:"acceptance_rate"`
-		AverageInterval float64  `json:"average_interval"`
-		EndWeight       *float64 `json:"end_weight"`
-		EndSize         float64  `json:"end_size"`
-		MoltCount       int32    `json:"molt_count"`
-		PhotosAdded     int32    `json:"photos_added"`
-		HealthIssues    int32    `json:"health_issues"`
-		EstimatedCost   float64  `json:"estimated_cost"`
+		Year               int      `json:"year"`
+		TarantulaID        int32    `json:"tarantula_id"`
+		TarantulaName      string   `json:"tarantula_name"`
+		TotalFeedings      int32    `json:"total_feedings"`
+		TotalPreyItems     int32    `json:"total_prey_items"`
+		TotalPreyMassGrams float64  `json:"total_prey_mass_grams"`
+		AcceptanceRate     float64  `json:"acceptance_rate"`
+		AverageInterval    float64  `json:"average_interval"`
+		EndWeight          *float64 `json:"end_weight"`
+		EndSize            float64  `json:"end_size"`
+		MoltCount          int32    `json:"molt_count"`
+		PhotosAdded        int32    `json:"photos_added"`
+		HealthIssues       int32    `json:"health_issues"`
 	}
 
 	var tempReports []AnnualReportTemp
@@ -1304,7 +1637,8 @@ func (db *TarantulaDB) GenerateAnnualReport(ctx context.Context, userID int64, y
         t.name as tarantula_name,
         -- Feeding statistics
         COALESCE(COUNT(DISTINCT fe.id), 0) as total_feedings,
-        COALESCE(SUM(fe.number_of_crickets), 0) as total_crickets,
+        COALESCE(prey.total_items, 0) as total_prey_items,
+        COALESCE(prey.total_mass, 0) as total_prey_mass_grams,
         COALESCE(
             COUNT(CASE WHEN fs.status_name = 'Accepted' THEN 1 END) * 100.0 / NULLIF(COUNT(fe.id), 0), 0
         ) as acceptance_rate,
@@ -1321,11 +1655,10 @@ func (db *TarantulaDB) GenerateAnnualReport(ctx context.Context, userID int64, y
         -- Events count
         COALESCE(COUNT(DISTINCT mr.id), 0) as molt_count,
         COALESCE(COUNT(DISTINCT tp.id), 0) as photos_added,
-        COALESCE(COUNT(CASE WHEN hs.status_name != 'Healthy' THEN 1 END), 0) as health_issues,
-        COALESCE(SUM(fe.number_of_crickets), 0) * 0.10 as estimated_cost
+        COALESCE(COUNT(CASE WHEN hs.status_name != 'Healthy' THEN 1 END), 0) as health_issues
         
     FROM spider_bot.tarantulas t
-    LEFT JOIN spider_bot.feeding_events fe ON t.id = fe.tarantula_id 
+    LEFT JOIN ` + memberFeedingsSQL + ` fe ON t.id = fe.tarantula_id
         AND EXTRACT(YEAR FROM fe.feeding_date) = $1
     LEFT JOIN spider_bot.feeding_statuses fs ON fe.feeding_status_id = fs.id
     LEFT JOIN spider_bot.molt_records mr ON t.id = mr.tarantula_id 
@@ -1335,31 +1668,49 @@ func (db *TarantulaDB) GenerateAnnualReport(ctx context.Context, userID int64, y
     LEFT JOIN spider_bot.health_check_records hcr ON t.id = hcr.tarantula_id 
         AND EXTRACT(YEAR FROM hcr.check_date) = $1
     LEFT JOIN spider_bot.health_statuses hs ON hcr.health_status_id = hs.id
+    -- Prey totals are summed separately so the joins above don't multiply them
+    LEFT JOIN LATERAL (
+        SELECT ROUND(SUM(pf.prey_count))::int as total_items,
+               SUM(pf.prey_mass_grams) as total_mass
+        FROM ` + memberFeedingsSQL + ` pf
+        WHERE pf.tarantula_id = t.id AND EXTRACT(YEAR FROM pf.feeding_date) = $1
+    ) prey ON TRUE
     WHERE t.user_id = $2
-    GROUP BY t.id, t.name, t.current_weight_grams, t.current_size
+    GROUP BY t.id, t.name, t.current_weight_grams, t.current_size, prey.total_items, prey.total_mass
     ORDER BY t.name`
 
 	if err := db.db.WithContext(ctx).Raw(query, year, userID).Scan(&tempReports).Error; err != nil {
 		return nil, fmt.Errorf("failed to generate annual report: %w", err)
 	}
 
+	spend, err := db.GetSpendReport(ctx, userID, year)
+	if err != nil {
+		return nil, err
+	}
+	costs := make(map[int32]models.TarantulaSpend, len(spend.Tarantulas))
+	for _, ts := range spend.Tarantulas {
+		costs[ts.TarantulaID] = ts
+	}
+
 	reports := make([]models.AnnualReport, len(tempReports))
 	for i, temp := range tempReports {
 		reports[i] = models.AnnualReport{
-			Year:            temp.Year,
-			TarantulaID:     temp.TarantulaID,
-			TarantulaName:   temp.TarantulaName,
-			TotalFeedings:   temp.TotalFeedings,
-			TotalCrickets:   temp.TotalCrickets,
-			AcceptanceRate:  temp.AcceptanceRate,
-			AverageInterval: temp.AverageInterval,
-			EndWeight:       temp.EndWeight,
-			EndSize:         temp.EndSize,
-			MoltCount:       temp.MoltCount,
-			PhotosAdded:     temp.PhotosAdded,
-			HealthIssues:    temp.HealthIssues,
-			EstimatedCost:   temp.EstimatedCost,
-			Milestones:      []string{},
+			Year:               temp.Year,
+			TarantulaID:        temp.TarantulaID,
+			TarantulaName:      temp.TarantulaName,
+			TotalFeedings:      temp.TotalFeedings,
+			TotalPreyItems:     temp.TotalPreyItems,
+			TotalPreyMassGrams: temp.TotalPreyMassGrams,
+			AcceptanceRate:     temp.AcceptanceRate,
+			AverageInterval:    temp.AverageInterval,
+			EndWeight:          temp.EndWeight,
+			EndSize:            temp.EndSize,
+			MoltCount:          temp.MoltCount,
+			PhotosAdded:        temp.PhotosAdded,
+			HealthIssues:       temp.HealthIssues,
+			PreyCost:           costs[temp.TarantulaID].PreyCost,
+			ExpenseCost:        costs[temp.TarantulaID].ExpenseCost,
+			Milestones:         []string{},
 		}
 	}
 
@@ -1416,32 +1767,37 @@ func (db *TarantulaDB) GenerateAnnualReport(ctx context.Context, userID int64, y
 	return reports, nil
 }
 
+// moltPredictionRow is the per-tarantula input to the molt prediction helpers
+type moltPredictionRow struct {
+	TarantulaID        int32      `json:"tarantula_id"`
+	TarantulaName      string     `json:"tarantula_name"`
+	SpeciesID          int32      `json:"species_id"`
+	LastMoltDate       *time.Time `json:"last_molt_date"`
+	DaysSinceLastMolt  int32      `json:"days_since_last_molt"`
+	MoltCount          int32      `json:"molt_count"`
+	CurrentSize        float64    `json:"current_size"`
+	AdultSizeCM        *float64   `json:"adult_size_cm"`
+	EstimatedAgeMonths int32      `json:"estimated_age_months"`
+	SpeciesTemperament string     `json:"species_temperament"`
+	SpeciesName        string     `json:"species_name"`
+	RecentFeedings     int32      `json:"recent_feedings"`
+	RecentRejections   int32      `json:"recent_rejections"`
+	AverageCycle       *float64   `json:"average_cycle"`
+	LastFeedingDate    *time.Time `json:"last_feeding_date"`
+	FeedingFreqMinDays *int32     `json:"feeding_freq_min_days"`
+	FeedingFreqMaxDays *int32     `json:"feeding_freq_max_days"`
+}
+
 func (db *TarantulaDB) GetMoltPredictions(ctx context.Context, userID int64) ([]models.MoltPrediction, error) {
 
-	var queryResults []struct {
-		TarantulaID        int32      `json:"tarantula_id"`
-		TarantulaName      string     `json:"tarantula_name"`
-		LastMoltDate       *time.Time `json:"last_molt_date"`
-		DaysSinceLastMolt  int32      `json:"days_since_last_molt"`
-		MoltCount          int32      `json:"molt_count"`
-		CurrentSize        float64    `json:"current_size"`
-		AdultSizeCM        *float64   `json:"adult_size_cm"`
-		EstimatedAgeMonths int32      `json:"estimated_age_months"`
-		SpeciesTemperament string     `json:"species_temperament"`
-		SpeciesName        string     `json:"species_name"`
-		RecentFeedings     int32      `json:"recent_feedings"`
-		RecentRejections   int32      `json:"recent_rejections"`
-		AverageCycle       *float64   `json:"average_cycle"`
-		LastFeedingDate    *time.Time `json:"last_feeding_date"`
-		FeedingFreqMinDays *int32     `json:"feeding_freq_min_days"`
-		FeedingFreqMaxDays *int32     `json:"feeding_freq_max_days"`
-	}
+	var queryResults []moltPredictionRow
 
 	query := `
     WITH molt_stats AS (
         SELECT 
             t.id as tarantula_id,
             t.name as tarantula_name,
+            t.species_id,
             t.current_size,
             t.estimated_age_months,
             ts.adult_size_cm,
@@ -1451,18 +1807,13 @@ func (db *TarantulaDB) GetMoltPredictions(ctx context.Context, userID int64) ([]
             -- Use the actual most recent molt date from molt_records
             MAX(mr.molt_date) as last_molt_date,
             COALESCE(EXTRACT(DAY FROM (NOW() - MAX(mr.molt_date))), 999) as days_since_last_molt,
-            COUNT(mr.id) as molt_count,
-            
-            -- Calculate proper average cycle from consecutive molt records
-            CASE WHEN COUNT(mr.id) > 1 THEN
-                EXTRACT(DAY FROM (MAX(mr.molt_date) - MIN(mr.molt_date))) / NULLIF(COUNT(mr.id) - 1, 0)
-            ELSE NULL END as average_cycle
+            COUNT(mr.id) as molt_count
             
         FROM spider_bot.tarantulas t
         LEFT JOIN spider_bot.molt_records mr ON t.id = mr.tarantula_id
         LEFT JOIN spider_bot.tarantula_species ts ON t.species_id = ts.id
         WHERE t.user_id = $1
-        GROUP BY t.id, t.name, t.current_size, t.estimated_age_months, ts.adult_size_cm, ts.temperament, ts.scientific_name
+        GROUP BY t.id, t.name, t.species_id, t.current_size, t.estimated_age_months, ts.adult_size_cm, ts.temperament, ts.scientific_name
     ),
     feeding_behavior AS (
         SELECT 
@@ -1505,9 +1856,32 @@ func (db *TarantulaDB) GetMoltPredictions(ctx context.Context, userID int64) ([]
 		return nil, fmt.Errorf("failed to get molt predictions: %w", err)
 	}
 
+	// Pool molt history across every keeper's animals of the same species
+	observations, err := db.GetMoltObservations(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	model := BuildMoltModel(observations)
+
+	histories := make(map[int32][]models.MoltObservation)
+	for _, o := range observations {
+		histories[o.TarantulaID] = append(histories[o.TarantulaID], o)
+	}
+
 	predictions := make([]models.MoltPrediction, len(queryResults))
 
 	for i, result := range queryResults {
+		history := histories[result.TarantulaID]
+
+		if intervals := MoltIntervals(history); len(intervals) > 0 {
+			var total float64
+			for _, d := range intervals {
+				total += d
+			}
+			avg := total / float64(len(intervals))
+			result.AverageCycle = &avg
+		}
+
 		prediction := models.MoltPrediction{
 			TarantulaID:       result.TarantulaID,
 			TarantulaName:     result.TarantulaName,
@@ -1517,33 +1891,29 @@ func (db *TarantulaDB) GetMoltPredictions(ctx context.Context, userID int64) ([]
 			MoltCount:         result.MoltCount,
 		}
 
-		var estimatedCycle int
-		var confidenceAdjustment int
-
-		if result.AverageCycle != nil && result.LastMoltDate != nil && result.MoltCount > 1 {
-			estimatedCycle = int(*result.AverageCycle)
-			confidenceAdjustment = 2 // High confidence boost
-		} else if result.LastMoltDate != nil {
-			// Step 2: Use species-specific intelligent estimates
-			estimatedCycle = db.calculateSpeciesBasedMoltCycle(result)
-			confidenceAdjustment = 0
-		} else {
-			// Step 3: Pure species-based estimate for no molt history
-			estimatedCycle = db.calculateSpeciesBasedMoltCycle(result)
-			confidenceAdjustment = -1 // Lower confidence
+		size := result.CurrentSize
+		if size <= 0 && len(history) > 0 {
+			size = history[len(history)-1].SizeCM
+		}
+		var adultSize float64
+		if result.AdultSizeCM != nil {
+			adultSize = *result.AdultSizeCM
 		}
 
+		estimate := model.Predict(history, result.SpeciesID, size, adultSize, db.calculateSpeciesBasedMoltCycle(result))
+		prediction.ConfidenceInterval = estimate.ConfidenceLevel
+		prediction.SampleSize = int32(estimate.PooledIntervals + estimate.IndividualIntervals)
+
 		// Calculate prediction if we have a last molt date
-		if result.LastMoltDate != nil && estimatedCycle > 0 {
-			predictedDate := result.LastMoltDate.AddDate(0, 0, estimatedCycle)
-			prediction.PredictedMoltDate = &predictedDate
-			daysUntil := int32(time.Until(predictedDate).Hours() / 24)
+		if result.LastMoltDate != nil {
+			median, earliest, latest := estimate.Window(*result.LastMoltDate)
+			prediction.PredictedMoltDate = &median
+			prediction.EarliestMoltDate = &earliest
+			prediction.LatestMoltDate = &latest
+			daysUntil := int32(time.Until(median).Hours() / 24)
 			prediction.DaysUntilMolt = &daysUntil
 		}
 
-		// Enhanced confidence calculation
-		prediction.ConfidenceLevel = db.calculateConfidenceLevel(result, confidenceAdjustment)
-
 		// Set size indicator
 		if result.AdultSizeCM != nil {
 			ratio := result.CurrentSize / *result.AdultSizeCM
@@ -1581,11 +1951,9 @@ func (db *TarantulaDB) GetMoltPredictions(ctx context.Context, userID int64) ([]
 
 		// Build reasoning
 		var reasoning strings.Builder
-		if result.AverageCycle != nil && result.MoltCount > 1 {
-			reasoning.WriteString(fmt.Sprintf("Based on %d previous molts with %.0f day average cycle. ",
-				result.MoltCount, *result.AverageCycle))
-		} else if result.LastMoltDate != nil {
-			reasoning.WriteString("Based on species size estimates and last molt date. ")
+		if result.LastMoltDate != nil {
+			reasoning.WriteString(fmt.Sprintf("%.0f%% window based on %s. ",
+				estimate.ConfidenceLevel*100, estimate.Basis))
 		} else {
 			reasoning.WriteString("No molt history available. ")
 		}
@@ -1604,8 +1972,42 @@ func (db *TarantulaDB) GetMoltPredictions(ctx context.Context, userID int64) ([]
 	return predictions, nil
 }
 
+// GetMoltObservations returns every recorded molt for the species kept by
+// the user, across all users, ordered by tarantula and date
+func (db *TarantulaDB) GetMoltObservations(ctx context.Context, userID int64) ([]models.MoltObservation, error) {
+	var observations []models.MoltObservation
+
+	result := db.db.WithContext(ctx).Raw(`
+        SELECT
+            mr.tarantula_id,
+            t.species_id,
+            mr.molt_date,
+            COALESCE(NULLIF(mr.post_molt_length_cm, 0), NULLIF(mr.pre_molt_length_cm, 0), 0) as size_cm,
+            COALESCE(ts.adult_size_cm, 0) as adult_size_cm
+        FROM spider_bot.molt_records mr
+        JOIN spider_bot.tarantulas t ON mr.tarantula_id = t.id
+        JOIN spider_bot.tarantula_species ts ON t.species_id = ts.id
+        WHERE t.species_id IN (
+            SELECT DISTINCT species_id FROM spider_bot.tarantulas WHERE user_id = ?
+        )
+        ORDER BY mr.tarantula_id, mr.molt_date`, userID).
+		Scan(&observations)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get molt observations: %w", result.Error)
+	}
+
+	return observations, nil
+}
+
+// maxNotifyWindowRatio limits molt notifications to predictions whose window
+// is reasonably tight (latest date no more than twice the earliest interval).
+// Estimates from the species heuristic alone are always wider than that, but
+// they're all a keeper without molt history has, so they still notify.
+const maxNotifyWindowRatio = 2.0
+
 // GetUpcomingMoltPredictions returns molt predictions that are predicted within the specified number of days
-// Only returns predictions with High or Medium confidence
+// Only returns predictions whose confidence window is narrow enough to act on
 func (db *TarantulaDB) GetUpcomingMoltPredictions(ctx context.Context, userID int64, withinDays int) ([]models.MoltPrediction, error) {
 	allPredictions, err := db.GetMoltPredictions(ctx, userID)
 	if err != nil {
@@ -1614,13 +2016,15 @@ func (db *TarantulaDB) GetUpcomingMoltPredictions(ctx context.Context, userID in
 
 	var upcomingPredictions []models.MoltPrediction
 	for _, pred := range allPredictions {
-		// Only include predictions with sufficient confidence
-		if pred.ConfidenceLevel != "High" && pred.ConfidenceLevel != "Medium" {
+		// Only include if we have a prediction date and days until molt
+		if pred.DaysUntilMolt == nil || pred.PredictedMoltDate == nil || pred.LastMoltDate == nil {
 			continue
 		}
 
-		// Only include if we have a prediction date and days until molt
-		if pred.DaysUntilMolt == nil || pred.PredictedMoltDate == nil {
+		// Only include predictions with a usable window
+		earliest := pred.EarliestMoltDate.Sub(*pred.LastMoltDate)
+		latest := pred.LatestMoltDate.Sub(*pred.LastMoltDate)
+		if earliest <= 0 || (pred.SampleSize > 0 && float64(latest)/float64(earliest) > maxNotifyWindowRatio) {
 			continue
 		}
 
@@ -1671,7 +2075,7 @@ func (db *TarantulaDB) GetTarantulaWithSpeciesData(ctx context.Context, tarantul
 
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("tarantula not found")
+			return nil, models.NotFound("tarantula not found")
 		}
 		return nil, fmt.Errorf("failed to get tarantula with species data: %w", result.Error)
 	}
@@ -1679,25 +2083,9 @@ func (db *TarantulaDB) GetTarantulaWithSpeciesData(ctx context.Context, tarantul
 	return &tarantula, nil
 }
 
-// Enhanced species-based molt cycle calculation
-func (db *TarantulaDB) calculateSpeciesBasedMoltCycle(result struct {
-	TarantulaID        int32      `json:"tarantula_id"`
-	TarantulaName      string     `json:"tarantula_name"`
-	LastMoltDate       *time.Time `json:"last_molt_date"`
-	DaysSinceLastMolt  int32      `json:"days_since_last_molt"`
-	MoltCount          int32      `json:"molt_count"`
-	CurrentSize        float64    `json:"current_size"`
-	AdultSizeCM        *float64   `json:"adult_size_cm"`
-	EstimatedAgeMonths int32      `json:"estimated_age_months"`
-	SpeciesTemperament string     `json:"species_temperament"`
-	SpeciesName        string     `json:"species_name"`
-	RecentFeedings     int32      `json:"recent_feedings"`
-	RecentRejections   int32      `json:"recent_rejections"`
-	AverageCycle       *float64   `json:"average_cycle"`
-	LastFeedingDate    *time.Time `json:"last_feeding_date"`
-	FeedingFreqMinDays *int32     `json:"feeding_freq_min_days"`
-	FeedingFreqMaxDays *int32     `json:"feeding_freq_max_days"`
-}) int {
+// Heuristic molt cycle by size and temperament, used by the molt model as its
+// prior when no pooled interval data exists for the species and size bracket
+func (db *TarantulaDB) calculateSpeciesBasedMoltCycle(result moltPredictionRow) int {
 	// REALISTIC molt cycle estimates by size ratio to adult
 	var baseCycle int
 	if result.AdultSizeCM != nil && *result.AdultSizeCM > 0 {
@@ -1766,84 +2154,8 @@ func (db *TarantulaDB) calculateSpeciesBasedMoltCycle(result struct {
 	return baseCycle
 }
 
-// Enhanced confidence level calculation
-func (db *TarantulaDB) calculateConfidenceLevel(result struct {
-	TarantulaID        int32      `json:"tarantula_id"`
-	TarantulaName      string     `json:"tarantula_name"`
-	LastMoltDate       *time.Time `json:"last_molt_date"`
-	DaysSinceLastMolt  int32      `json:"days_since_last_molt"`
-	MoltCount          int32      `json:"molt_count"`
-	CurrentSize        float64    `json:"current_size"`
-	AdultSizeCM        *float64   `json:"adult_size_cm"`
-	EstimatedAgeMonths int32      `json:"estimated_age_months"`
-	SpeciesTemperament string     `json:"species_temperament"`
-	SpeciesName        string     `json:"species_name"`
-	RecentFeedings     int32      `json:"recent_feedings"`
-	RecentRejections   int32      `json:"recent_rejections"`
-	AverageCycle       *float64   `json:"average_cycle"`
-	LastFeedingDate    *time.Time `json:"last_feeding_date"`
-	FeedingFreqMinDays *int32     `json:"feeding_freq_min_days"`
-	FeedingFreqMaxDays *int32     `json:"feeding_freq_max_days"`
-}, confidenceAdjustment int) string {
-
-	baseScore := 0
-
-	// Historical data quality
-	if result.MoltCount >= 3 && result.AverageCycle != nil {
-		baseScore += 3 // Excellent historical data
-	} else if result.MoltCount >= 2 {
-		baseScore += 2 // Good historical data
-	} else if result.MoltCount >= 1 {
-		baseScore += 1 // Some historical data
-	}
-
-	// Species data availability
-	if result.AdultSizeCM != nil && *result.AdultSizeCM > 0 {
-		baseScore += 1 // Have adult size reference
-	}
-	if result.FeedingFreqMinDays != nil {
-		baseScore += 1 // Have feeding schedule data
-	}
-
-	// Recent behavioral indicators
-	if result.RecentRejections > 2 {
-		baseScore += 1 // Pre-molt signs
-	}
-
-	// Apply adjustment
-	finalScore := baseScore + confidenceAdjustment
-
-	switch {
-	case finalScore >= 5:
-		return "High"
-	case finalScore >= 3:
-		return "Medium"
-	case finalScore >= 1:
-		return "Low"
-	default:
-		return "None"
-	}
-}
-
 // Enhanced molt recommendations
-func (db *TarantulaDB) generateMoltRecommendation(result struct {
-	TarantulaID        int32      `json:"tarantula_id"`
-	TarantulaName      string     `json:"tarantula_name"`
-	LastMoltDate       *time.Time `json:"last_molt_date"`
-	DaysSinceLastMolt  int32      `json:"days_since_last_molt"`
-	MoltCount          int32      `json:"molt_count"`
-	CurrentSize        float64    `json:"current_size"`
-	AdultSizeCM        *float64   `json:"adult_size_cm"`
-	EstimatedAgeMonths int32      `json:"estimated_age_months"`
-	SpeciesTemperament string     `json:"species_temperament"`
-	SpeciesName        string     `json:"species_name"`
-	RecentFeedings     int32      `json:"recent_feedings"`
-	RecentRejections   int32      `json:"recent_rejections"`
-	AverageCycle       *float64   `json:"average_cycle"`
-	LastFeedingDate    *time.Time `json:"last_feeding_date"`
-	FeedingFreqMinDays *int32     `json:"feeding_freq_min_days"`
-	FeedingFreqMaxDays *int32     `json:"feeding_freq_max_days"`
-}, prediction *models.MoltPrediction) string {
+func (db *TarantulaDB) generateMoltRecommendation(result moltPredictionRow, prediction *models.MoltPrediction) string {
 
 	if result.LastMoltDate == nil {
 		return "Record first molt to enable predictions."
@@ -1924,7 +2236,7 @@ func (db *TarantulaDB) GetUserColonies(ctx context.Context, userID int64) ([]mod
 		Preload("Enclosure").
 		Preload("Members", "is_active = ?", true).
 		Preload("Members.Tarantula").
-		Where("user_id = ?", userID).
+		Where("user_id = ? AND dissolved_date IS NULL", userID).
 		Order("formation_date DESC").
 		Find(&colonies)
 
@@ -1939,31 +2251,10 @@ func (db *TarantulaDB) AddMemberToColony(ctx context.Context, member models.Tara
 		// Verify colony exists and belongs to user
 		var colony models.TarantulaColony
 		if err := tx.Where("id = ? AND user_id = ?", member.ColonyID, member.UserID).First(&colony).Error; err != nil {
-			return fmt.Errorf("colony not found or access denied: %w", err)
-		}
-
-		// Verify tarantula exists and belongs to user
-		var tarantula models.Tarantula
-		if err := tx.Where("id = ? AND user_id = ?", member.TarantulaID, member.UserID).First(&tarantula).Error; err != nil {
-			return fmt.Errorf("tarantula not found or access denied: %w", err)
+			return lookupError(err, "colony")
 		}
 
-		// Verify species match
-		if tarantula.SpeciesID != colony.SpeciesID {
-			return fmt.Errorf("tarantula species does not match colony species")
-		}
-
-		// Create the membership record
-		if err := tx.Create(&member).Error; err != nil {
-			return fmt.Errorf("failed to add member to colony: %w", err)
-		}
-
-		// Update tarantula's colony_id
-		if err := tx.Model(&tarantula).Update("colony_id", member.ColonyID).Error; err != nil {
-			return fmt.Errorf("failed to update tarantula colony reference: %w", err)
-		}
-
-		return nil
+		return addColonyMember(tx, colony, member)
 	})
 }
 
@@ -1973,27 +2264,10 @@ func (db *TarantulaDB) RemoveMemberFromColony(ctx context.Context, colonyID, tar
 		var member models.TarantulaColonyMember
 		if err := tx.Where("colony_id = ? AND tarantula_id = ? AND is_active = ? AND user_id = ?",
 			colonyID, tarantulaID, true, userID).First(&member).Error; err != nil {
-			return fmt.Errorf("active membership not found: %w", err)
+			return lookupError(err, "active membership")
 		}
 
-		// Mark as inactive and set left date
-		now := time.Now()
-		member.IsActive = false
-		member.LeftDate = &now
-		if err := tx.Save(&member).Error; err != nil {
-			return fmt.Errorf("failed to update membership: %w", err)
-		}
-
-		// Clear tarantula's colony_id
-		var tarantula models.Tarantula
-		if err := tx.Where("id = ? AND user_id = ?", tarantulaID, userID).First(&tarantula).Error; err != nil {
-			return fmt.Errorf("tarantula not found: %w", err)
-		}
-		if err := tx.Model(&tarantula).Update("colony_id", nil).Error; err != nil {
-			return fmt.Errorf("failed to clear tarantula colony reference: %w", err)
-		}
-
-		return nil
+		return endColonyMembership(tx, member, time.Now(), "")
 	})
 }
 
@@ -2026,75 +2300,299 @@ func (db *TarantulaDB) UpdateColony(ctx context.Context, colony models.Tarantula
 		return fmt.Errorf("failed to update colony: %w", result.Error)
 	}
 	if result.RowsAffected == 0 {
-		return fmt.Errorf("colony not found or access denied")
+		return models.NotFound("colony not found or access denied")
 	}
 	return nil
 }
 
-// GetColoniesDueFeeding returns colonies that need feeding based on their species schedule
-func (db *TarantulaDB) GetColoniesDueFeeding(ctx context.Context, userID int64) ([]models.TarantulaColony, error) {
-	var colonies []models.TarantulaColony
+// GetColoniesDueFeeding returns the feeding plans of colonies with a member
+// due to eat by its own schedule
+func (db *TarantulaDB) GetColoniesDueFeeding(ctx context.Context, userID int64) ([]models.ColonyFeedingPlan, error) {
+	plans, err := db.GetColonyFeedingPlans(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var due []models.ColonyFeedingPlan
+	for _, plan := range plans {
+		if plan.IsDue() {
+			due = append(due, plan)
+		}
+	}
+
+	return due, nil
+}
+
+// ========== Species Catalogue ==========
 
-	// Get all user's colonies with species and member info
+// GetAvailableSpecies returns the shared catalogue plus the user's own species
+func (db *TarantulaDB) GetAvailableSpecies(ctx context.Context, userID int64) ([]models.TarantulaSpecies, error) {
+	var species []models.TarantulaSpecies
 	result := db.db.WithContext(ctx).
-		Preload("Species").
-		Preload("Members", "is_active = ?", true).
-		Preload("Members.Tarantula").
+		Where("status = ? OR owner_user_id = ?", models.SpeciesStatusApproved, userID).
+		Order("common_name ASC").
+		Find(&species)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get available species: %w", result.Error)
+	}
+	return species, nil
+}
+
+func (db *TarantulaDB) GetUserSpecies(ctx context.Context, userID int64) ([]models.TarantulaSpecies, error) {
+	var species []models.TarantulaSpecies
+	result := db.db.WithContext(ctx).
+		Where("owner_user_id = ?", userID).
+		Order("scientific_name ASC").
+		Find(&species)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get user species: %w", result.Error)
+	}
+	return species, nil
+}
+
+// GetRecentSpeciesIDs returns the species of the user's tarantulas, most recently added first
+func (db *TarantulaDB) GetRecentSpeciesIDs(ctx context.Context, userID int64, limit int) ([]int32, error) {
+	var ids []int32
+	result := db.db.WithContext(ctx).
+		Model(&models.Tarantula{}).
+		Select("species_id").
 		Where("user_id = ?", userID).
-		Find(&colonies)
+		Group("species_id").
+		Order("MAX(created_at) DESC").
+		Limit(limit).
+		Scan(&ids)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get recent species: %w", result.Error)
+	}
+	return ids, nil
+}
 
+func (db *TarantulaDB) GetSpeciesByID(ctx context.Context, speciesID int32) (*models.TarantulaSpecies, error) {
+	var species models.TarantulaSpecies
+	result := db.db.WithContext(ctx).First(&species, speciesID)
 	if result.Error != nil {
-		return nil, fmt.Errorf("failed to get colonies: %w", result.Error)
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, models.NotFound("species not found")
+		}
+		return nil, fmt.Errorf("failed to get species: %w", result.Error)
 	}
+	return &species, nil
+}
+
+// AddUserSpecies creates a user-defined species together with feeding
+// schedules derived from its adult size
+func (db *TarantulaDB) AddUserSpecies(ctx context.Context, species models.TarantulaSpecies) (int64, error) {
+	err := db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Only names the keeper can see count, so other keepers' private
+		// species neither block nor show through
+		visible := tx.Where("status = ?", models.SpeciesStatusApproved)
+		if species.OwnerUserID != nil {
+			visible = visible.Or("owner_user_id = ?", *species.OwnerUserID)
+		}
+		var existing int64
+		if err := tx.Model(&models.TarantulaSpecies{}).
+			Where("LOWER(scientific_name) = LOWER(?)", species.ScientificName).
+			Where(visible).
+			Count(&existing).Error; err != nil {
+			return fmt.Errorf("failed to check species: %w", err)
+		}
+		if existing > 0 {
+			return models.Conflict("species %s already exists", species.ScientificName)
+		}
 
-	var dueColonies []models.TarantulaColony
+		if err := tx.Create(&species).Error; err != nil {
+			return fmt.Errorf("failed to create species: %w", err)
+		}
 
-	for _, colony := range colonies {
-		// Skip colonies with no active members
-		if len(colony.Members) == 0 {
-			continue
+		return replaceGeneratedSchedules(tx, species)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int64(species.ID), nil
+}
+
+// UpdateSpecies saves edited care parameters. Feeding schedules of
+// user-defined species are regenerated so they follow the new adult size.
+func (db *TarantulaDB) UpdateSpecies(ctx context.Context, species models.TarantulaSpecies) error {
+	return db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.TarantulaSpecies{}).
+			Where("id = ?", species.ID).
+			Updates(map[string]interface{}{
+				"common_name":                     species.CommonName,
+				"adult_size_cm":                   species.AdultSizeCM,
+				"temperament":                     species.Temperament,
+				"humidity_requirement_percent":    species.HumidityRequirementPercent,
+				"temperature_requirement_celsius": species.TemperatureRequirementCelsius,
+				"is_communal":                     species.IsCommunal,
+				"synonyms":                        species.Synonyms,
+				"habitat_type":                    species.HabitatType,
+				"urticating_hairs":                species.UrticatingHairs,
+				"venom_potency":                   species.VenomPotency,
+				"growth_rate":                     species.GrowthRate,
+				"origin":                          species.Origin,
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to update species: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return models.NotFound("species not found")
+		}
+
+		if species.OwnerUserID == nil {
+			return nil
 		}
+		return replaceGeneratedSchedules(tx, species)
+	})
+}
+
+// GetFeedingSchedules returns every size stage of a species' feeding schedule, smallest first
+func (db *TarantulaDB) GetFeedingSchedules(ctx context.Context, speciesID int32) ([]models.FeedingSchedule, error) {
+	var schedules []models.FeedingSchedule
+	result := db.db.WithContext(ctx).
+		Preload("Frequency").
+		Where("species_id = ?", speciesID).
+		Order("body_length_cm ASC").
+		Find(&schedules)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get feeding schedules: %w", result.Error)
+	}
+	return schedules, nil
+}
+
+// ProposeSpecies submits a user's private species for the shared catalogue
+func (db *TarantulaDB) ProposeSpecies(ctx context.Context, speciesID int32, userID int64) error {
+	result := db.db.WithContext(ctx).
+		Model(&models.TarantulaSpecies{}).
+		Where("id = ? AND owner_user_id = ? AND status = ?", speciesID, userID, models.SpeciesStatusPrivate).
+		Update("status", models.SpeciesStatusPending)
+	if result.Error != nil {
+		return fmt.Errorf("failed to propose species: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return models.NotFound("species not found or already shared")
+	}
+	return nil
+}
+
+func (db *TarantulaDB) GetPendingSpecies(ctx context.Context) ([]models.TarantulaSpecies, error) {
+	var species []models.TarantulaSpecies
+	result := db.db.WithContext(ctx).
+		Where("status = ?", models.SpeciesStatusPending).
+		Order("id ASC").
+		Find(&species)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get pending species: %w", result.Error)
+	}
+	return species, nil
+}
 
-		// Get last colony feeding
-		var lastFeeding models.FeedingEvent
-		err := db.db.WithContext(ctx).
-			Where("tarantula_colony_id = ?", colony.ID).
-			Order("feeding_date DESC").
-			First(&lastFeeding).Error
+// ReviewSpecies approves a proposed species for everyone, or returns it to
+// its owner's private list
+func (db *TarantulaDB) ReviewSpecies(ctx context.Context, speciesID int32, approve bool) (*models.TarantulaSpecies, error) {
+	status := models.SpeciesStatusPrivate
+	if approve {
+		status = models.SpeciesStatusApproved
+	}
 
-		daysSinceFeeding := 999.0
-		if err == nil {
-			daysSinceFeeding = time.Since(lastFeeding.FeedingDate).Hours() / 24
+	err := db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var species models.TarantulaSpecies
+		if err := tx.Where("id = ? AND status = ?", speciesID, models.SpeciesStatusPending).First(&species).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return models.NotFound("species not found or already reviewed")
+			}
+			return fmt.Errorf("failed to get species: %w", err)
 		}
 
-		// Get feeding schedule for this species
-		// Use average size for colony members
-		var avgSize float64
-		for _, member := range colony.Members {
-			if member.Tarantula.CurrentSize > 0 {
-				avgSize += member.Tarantula.CurrentSize
+		// Keepers may each have their own copy, but only one can be shared
+		if approve {
+			var existing int64
+			if err := tx.Model(&models.TarantulaSpecies{}).
+				Where("LOWER(scientific_name) = LOWER(?) AND status = ?", species.ScientificName, models.SpeciesStatusApproved).
+				Count(&existing).Error; err != nil {
+				return fmt.Errorf("failed to check species: %w", err)
+			}
+			if existing > 0 {
+				return models.Conflict("species %s is already in the shared catalogue", species.ScientificName)
 			}
 		}
-		if len(colony.Members) > 0 {
-			avgSize /= float64(len(colony.Members))
+
+		if err := tx.Model(&species).Update("status", status).Error; err != nil {
+			return fmt.Errorf("failed to review species: %w", err)
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		var schedule models.FeedingSchedule
-		err = db.db.WithContext(ctx).
-			Where("species_id = ? AND body_length_cm >= ?", colony.SpeciesID, avgSize).
-			Order("body_length_cm ASC").
-			First(&schedule).Error
+	return db.GetSpeciesByID(ctx, speciesID)
+}
 
-		if err == nil && schedule.FrequencyID > 0 {
-			var frequency models.FeedingFrequency
-			if err = db.db.First(&frequency, schedule.FrequencyID).Error; err == nil {
-				// Check if colony is due
-				if daysSinceFeeding >= float64(frequency.MinDays) {
-					dueColonies = append(dueColonies, colony)
-				}
-			}
+// scheduleTemplate describes one generated feeding_schedules row. Body length
+// is the upper bound of the size category as a fraction of adult size, in
+// line with the seeded schedules.
+type scheduleTemplate struct {
+	category  string
+	sizeRatio float64
+	preySize  string
+	frequency string
+	preyType  string
+}
+
+var generatedScheduleTemplates = []scheduleTemplate{
+	{"Spiderling", 0.05, "Pre-killed pinhead cricket", "2-3 times per week", "Pinhead crickets"},
+	{"Juvenile", 0.25, "2-3 small crickets", "Every 5-7 days", "Small crickets, small roaches"},
+	{"Sub-Adult", 0.6, "2-3 medium crickets", "Every 10-14 days", "Medium crickets, medium roaches"},
+	{"Adult", 1.0, "2-3 large crickets", "Every 14-21 days", "Large crickets, adult roaches"},
+}
+
+// GenerateFeedingSchedules builds default schedules for a species from its adult size
+func GenerateFeedingSchedules(species models.TarantulaSpecies, frequencyIDs map[string]int) []models.FeedingSchedule {
+	if species.AdultSizeCM <= 0 {
+		return nil
+	}
+
+	schedules := make([]models.FeedingSchedule, 0, len(generatedScheduleTemplates))
+	for _, tmpl := range generatedScheduleTemplates {
+		bodyLength := math.Round(species.AdultSizeCM*tmpl.sizeRatio*10) / 10
+		if bodyLength < 0.3 {
+			bodyLength = 0.3
 		}
+		schedules = append(schedules, models.FeedingSchedule{
+			SpeciesID:        species.ID,
+			SizeCategory:     tmpl.category,
+			BodyLengthCM:     bodyLength,
+			PreySize:         tmpl.preySize,
+			FeedingFrequency: tmpl.frequency,
+			PreyType:         tmpl.preyType,
+			Notes:            "Generated from adult size; adjust to your animal",
+			FrequencyID:      frequencyIDs[tmpl.frequency],
+		})
+	}
+	return schedules
+}
+
+func replaceGeneratedSchedules(tx *gorm.DB, species models.TarantulaSpecies) error {
+	var frequencies []models.FeedingFrequency
+	if err := tx.Find(&frequencies).Error; err != nil {
+		return fmt.Errorf("failed to get feeding frequencies: %w", err)
+	}
+	frequencyIDs := make(map[string]int, len(frequencies))
+	for _, f := range frequencies {
+		frequencyIDs[f.FrequencyName] = f.ID
 	}
 
-	return dueColonies, nil
+	if err := tx.Where("species_id = ?", species.ID).Delete(&models.FeedingSchedule{}).Error; err != nil {
+		return fmt.Errorf("failed to clear feeding schedules: %w", err)
+	}
+
+	schedules := GenerateFeedingSchedules(species, frequencyIDs)
+	if len(schedules) == 0 {
+		return nil
+	}
+	if err := tx.Omit("Species", "Frequency").Create(&schedules).Error; err != nil {
+		return fmt.Errorf("failed to create feeding schedules: %w", err)
+	}
+	return nil
 }
@@ -0,0 +1,17 @@
+package db
+
+import (
+	"fmt"
+	"tarantulago/models"
+
+	"gorm.io/gorm"
+)
+
+// lookupError reports a record the user asked for that isn't there (or isn't
+// theirs) as not found, and anything else as the database failure it is
+func lookupError(err error, what string) error {
+	if err == gorm.ErrRecordNotFound {
+		return &models.DomainError{Kind: models.ErrNotFound, Message: what + " not found", Err: err}
+	}
+	return fmt.Errorf("failed to get %s: %w", what, err)
+}
@@ -0,0 +1,301 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"tarantulago/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Feeder breeding projections. Eggs hatch IncubationDays after they are laid
+// and the cohort grows through the size classes on its feeder species'
+// development schedule.
+
+// Assumed laying period while the substrate is still in the colony
+const defaultLayingDays = 7
+
+var feederSizes = []models.CricketSizeEnum{
+	models.CricketSizePinhead, models.CricketSizeSmall, models.CricketSizeMedium,
+	models.CricketSizeLarge, models.CricketSizeAdult,
+}
+
+var preyCountPattern = regexp.MustCompile(`(\d+(?:\.\d+)?)(?:\s*-\s*(\d+(?:\.\d+)?))?`)
+
+// ExpectedHatchDate returns the recorded hatch date, or an estimate from the
+// middle of the laying period when the clutch hasn't hatched yet.
+func ExpectedHatchDate(clutch models.FeederClutch, feeder models.FeederSpecies) time.Time {
+	if clutch.HatchDate != nil {
+		return *clutch.HatchDate
+	}
+
+	out := clutch.SubstrateInDate.AddDate(0, 0, defaultLayingDays)
+	if clutch.SubstrateOutDate != nil {
+		out = *clutch.SubstrateOutDate
+	}
+	laid := clutch.SubstrateInDate.Add(out.Sub(clutch.SubstrateInDate) / 2)
+	return laid.AddDate(0, 0, feeder.IncubationDays)
+}
+
+// ClutchSize is the counted hatchlings, or the species' typical clutch until counted
+func ClutchSize(clutch models.FeederClutch, feeder models.FeederSpecies) int {
+	if clutch.HatchlingCount > 0 {
+		return clutch.HatchlingCount
+	}
+	return feeder.TypicalHatchCount
+}
+
+// ProjectClutchSupply estimates how many feeders of each size class the
+// clutches will provide on the given date. Clutches need Colony.FeederSpecies
+// loaded; merged clutches are already part of their colony's count.
+func ProjectClutchSupply(clutches []models.FeederClutch, at time.Time) map[models.CricketSizeEnum]int {
+	supply := make(map[models.CricketSizeEnum]int)
+	for _, clutch := range clutches {
+		if clutch.MergedDate != nil {
+			continue
+		}
+		feeder := clutch.Colony.FeederSpecies
+		age := int(at.Sub(ExpectedHatchDate(clutch, feeder)).Hours() / 24)
+		size := feeder.SizeAtAge(age)
+		if size == models.CricketSizeUnknown {
+			continue
+		}
+		supply[size] += ClutchSize(clutch, feeder)
+	}
+	return supply
+}
+
+// ParsePreySize reads the size class and number of prey from a feeding
+// schedule description such as "2-3 small crickets" or "Pre-killed pinhead
+// cricket". Ranges count as their midpoint and a missing number as one.
+func ParsePreySize(text string) (models.CricketSizeEnum, float64) {
+	lower := strings.ToLower(text)
+
+	size := models.CricketSizeUnknown
+	for _, s := range feederSizes {
+		if strings.Contains(lower, strings.ToLower(s.ToDBName())) {
+			size = s
+			break
+		}
+	}
+
+	count := 1.0
+	if m := preyCountPattern.FindStringSubmatch(lower); m != nil {
+		count, _ = strconv.ParseFloat(m[1], 64)
+		if m[2] != "" {
+			upper, _ := strconv.ParseFloat(m[2], 64)
+			count = (count + upper) / 2
+		}
+	}
+
+	return size, count
+}
+
+// WeeklyDemand sums the feeders of each size class the schedules call for per week
+func WeeklyDemand(schedules []models.ScheduledFeeding) map[models.CricketSizeEnum]float64 {
+	demand := make(map[models.CricketSizeEnum]float64)
+	for _, s := range schedules {
+		interval := float64(s.MinDays+s.MaxDays) / 2
+		if interval <= 0 {
+			continue
+		}
+		size, count := ParsePreySize(s.PreySize)
+		if size == models.CricketSizeUnknown {
+			continue
+		}
+		demand[size] += count * 7 / interval
+	}
+	return demand
+}
+
+// BuildFeederProjection projects active clutches and scheduled demand
+// the given number of weeks ahead of now
+func BuildFeederProjection(clutches []models.FeederClutch, schedules []models.ScheduledFeeding, weeks int, now time.Time) models.FeederProjection {
+	at := now.AddDate(0, 0, weeks*7)
+	projection := models.FeederProjection{Weeks: weeks, Date: at}
+
+	for _, clutch := range clutches {
+		if clutch.MergedDate != nil {
+			continue
+		}
+		feeder := clutch.Colony.FeederSpecies
+		hatch := ExpectedHatchDate(clutch, feeder)
+		projection.Clutches = append(projection.Clutches, models.ClutchProjection{
+			Clutch:        clutch,
+			ExpectedHatch: hatch,
+			Count:         ClutchSize(clutch, feeder),
+			CurrentSize:   feeder.SizeAtAge(int(now.Sub(hatch).Hours() / 24)),
+			ProjectedSize: feeder.SizeAtAge(int(at.Sub(hatch).Hours() / 24)),
+		})
+	}
+
+	supply := ProjectClutchSupply(clutches, at)
+	demand := WeeklyDemand(schedules)
+	for _, size := range feederSizes {
+		if supply[size] == 0 && demand[size] == 0 {
+			continue
+		}
+		projection.Sizes = append(projection.Sizes, models.FeederSizeProjection{
+			Size:         size,
+			Supply:       supply[size],
+			WeeklyDemand: demand[size],
+			PeriodDemand: demand[size] * float64(weeks),
+		})
+	}
+
+	return projection
+}
+
+// ========== Feeder Breeding ==========
+
+func (db *TarantulaDB) StartClutch(ctx context.Context, colonyID int32, userID int64) (int64, error) {
+	var colony models.CricketColony
+	if err := db.db.WithContext(ctx).Where("id = ? AND user_id = ?", colonyID, userID).First(&colony).Error; err != nil {
+		return 0, lookupError(err, "feeder colony")
+	}
+
+	clutch := models.FeederClutch{
+		ColonyID:        colony.ID,
+		SubstrateInDate: time.Now(),
+		UserID:          userID,
+	}
+	if err := db.db.WithContext(ctx).Create(&clutch).Error; err != nil {
+		return 0, fmt.Errorf("failed to create clutch: %w", err)
+	}
+
+	return int64(clutch.ID), nil
+}
+
+// RemoveClutchSubstrate records taking the egg-laying substrate out to incubate
+func (db *TarantulaDB) RemoveClutchSubstrate(ctx context.Context, clutchID int32, userID int64) error {
+	result := db.db.WithContext(ctx).
+		Model(&models.FeederClutch{}).
+		Where("id = ? AND user_id = ? AND substrate_out_date IS NULL", clutchID, userID).
+		Update("substrate_out_date", time.Now())
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to update clutch: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return models.NotFound("clutch not found or substrate already removed")
+	}
+
+	return nil
+}
+
+// RecordClutchHatch marks a clutch hatched today. A count of zero means it
+// wasn't counted and the species' typical clutch size is assumed.
+func (db *TarantulaDB) RecordClutchHatch(ctx context.Context, clutchID int32, userID int64, count int) error {
+	now := time.Now()
+	result := db.db.WithContext(ctx).
+		Model(&models.FeederClutch{}).
+		Where("id = ? AND user_id = ? AND hatch_date IS NULL", clutchID, userID).
+		Updates(map[string]interface{}{
+			"hatch_date":         now,
+			"hatchling_count":    count,
+			"substrate_out_date": gorm.Expr("COALESCE(substrate_out_date, ?)", now),
+		})
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to record hatch: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return models.NotFound("clutch not found or already hatched")
+	}
+
+	return nil
+}
+
+// MergeClutch adds a grown cohort to its colony's count
+func (db *TarantulaDB) MergeClutch(ctx context.Context, clutchID int32, userID int64) error {
+	return db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var clutch models.FeederClutch
+		if err := tx.Preload("Colony.FeederSpecies").
+			Where("id = ? AND user_id = ? AND merged_date IS NULL", clutchID, userID).
+			First(&clutch).Error; err != nil {
+			return lookupError(err, "unmerged clutch")
+		}
+		if clutch.HatchDate == nil {
+			return models.Invalid("clutch hasn't hatched yet")
+		}
+
+		count := ClutchSize(clutch, clutch.Colony.FeederSpecies)
+		if err := tx.Model(&models.CricketColony{}).
+			Where("id = ?", clutch.ColonyID).
+			UpdateColumn("current_count", gorm.Expr("current_count + ?", count)).Error; err != nil {
+			return fmt.Errorf("failed to update colony count: %w", err)
+		}
+
+		if err := tx.Model(&clutch).Update("merged_date", time.Now()).Error; err != nil {
+			return fmt.Errorf("failed to update clutch: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (db *TarantulaDB) GetActiveClutches(ctx context.Context, userID int64) ([]models.FeederClutch, error) {
+	var clutches []models.FeederClutch
+
+	result := db.db.WithContext(ctx).
+		Preload("Colony.FeederSpecies").
+		Where("user_id = ? AND merged_date IS NULL", userID).
+		Order("substrate_in_date").
+		Find(&clutches)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get clutches: %w", result.Error)
+	}
+
+	return clutches, nil
+}
+
+func (db *TarantulaDB) GetFeederProjection(ctx context.Context, userID int64, weeks int) (*models.FeederProjection, error) {
+	clutches, err := db.GetActiveClutches(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	schedules, err := db.GetScheduledFeedings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	projection := BuildFeederProjection(clutches, schedules, weeks, time.Now())
+	return &projection, nil
+}
+
+// GetScheduledFeedings returns the feeding schedule matching each tarantula's current size
+func (db *TarantulaDB) GetScheduledFeedings(ctx context.Context, userID int64) ([]models.ScheduledFeeding, error) {
+	var schedules []models.ScheduledFeeding
+
+	query := `
+    SELECT
+        t.id as tarantula_id,
+        t.name as tarantula_name,
+        fs.prey_size,
+        ff.min_days,
+        ff.max_days,
+        t.current_molt_stage_id as molt_stage_id
+    FROM spider_bot.tarantulas t
+    JOIN LATERAL (
+        SELECT s.prey_size, s.frequency_id
+        FROM spider_bot.feeding_schedules s
+        WHERE s.species_id = t.species_id
+          AND s.body_length_cm >= COALESCE(t.current_size, 0)
+        ORDER BY s.body_length_cm ASC
+        LIMIT 1
+    ) fs ON TRUE
+    JOIN spider_bot.feeding_frequencies ff ON fs.frequency_id = ff.id
+    WHERE t.user_id = ?
+    ORDER BY t.name`
+
+	if err := db.db.WithContext(ctx).Raw(query, userID).Scan(&schedules).Error; err != nil {
+		return nil, fmt.Errorf("failed to get scheduled feedings: %w", err)
+	}
+
+	return schedules, nil
+}
@@ -0,0 +1,82 @@
+package db
+
+import (
+	"math"
+	"tarantulago/models"
+	"testing"
+	"time"
+)
+
+var testCricket = models.FeederSpecies{
+	Name:              "Cricket",
+	IncubationDays:    12,
+	DaysToSmall:       14,
+	DaysToMedium:      28,
+	DaysToLarge:       42,
+	DaysToAdult:       56,
+	TypicalHatchCount: 300,
+}
+
+func TestParsePreySize(t *testing.T) {
+	cases := []struct {
+		text  string
+		size  models.CricketSizeEnum
+		count float64
+	}{
+		{"2-3 small crickets", models.CricketSizeSmall, 2.5},
+		{"Pre-killed pinhead cricket", models.CricketSizePinhead, 1},
+		{"4-5 large crickets", models.CricketSizeLarge, 4.5},
+		{"2 medium crickets", models.CricketSizeMedium, 2},
+		{"Fruit flies", models.CricketSizeUnknown, 1},
+	}
+	for _, c := range cases {
+		size, count := ParsePreySize(c.text)
+		if size != c.size || count != c.count {
+			t.Errorf("ParsePreySize(%q) = %v, %v; want %v, %v", c.text, size.ToDBName(), count, c.size.ToDBName(), c.count)
+		}
+	}
+}
+
+func TestWeeklyDemand(t *testing.T) {
+	demand := WeeklyDemand([]models.ScheduledFeeding{
+		{PreySize: "2 small crickets", MinDays: 7, MaxDays: 7},
+		{PreySize: "2-3 small crickets", MinDays: 5, MaxDays: 9},
+		{PreySize: "3-4 large crickets", MinDays: 14, MaxDays: 21},
+	})
+
+	if got := demand[models.CricketSizeSmall]; math.Abs(got-4.5) > 1e-9 {
+		t.Errorf("small demand = %v, want 4.5", got)
+	}
+	if got := demand[models.CricketSizeLarge]; math.Abs(got-1.4) > 1e-9 {
+		t.Errorf("large demand = %v, want 1.4", got)
+	}
+}
+
+func TestProjectClutchSupply(t *testing.T) {
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	out := start.AddDate(0, 0, 6)
+	hatched := start.AddDate(0, 0, 10)
+	merged := start.AddDate(0, 0, 60)
+	colony := models.CricketColony{FeederSpecies: testCricket}
+
+	clutches := []models.FeederClutch{
+		// Laid around day 3, expected to hatch on day 15
+		{SubstrateInDate: start, SubstrateOutDate: &out, Colony: colony},
+		{SubstrateInDate: start, HatchDate: &hatched, HatchlingCount: 120, Colony: colony},
+		{SubstrateInDate: start, HatchDate: &hatched, HatchlingCount: 50, MergedDate: &merged, Colony: colony},
+	}
+
+	if hatch := ExpectedHatchDate(clutches[0], testCricket); !hatch.Equal(start.AddDate(0, 0, 15)) {
+		t.Errorf("expected hatch = %v, want day 15", hatch)
+	}
+
+	supply := ProjectClutchSupply(clutches, start.AddDate(0, 0, 14))
+	if supply[models.CricketSizePinhead] != 120 || len(supply) != 1 {
+		t.Errorf("supply on day 14 = %v, want only 120 pinheads", supply)
+	}
+
+	supply = ProjectClutchSupply(clutches, start.AddDate(0, 0, 40))
+	if supply[models.CricketSizeSmall] != 300 || supply[models.CricketSizeMedium] != 120 {
+		t.Errorf("supply on day 40 = %v, want 300 small and 120 medium", supply)
+	}
+}
@@ -0,0 +1,205 @@
+package db
+
+import (
+	"context"
+	"math"
+	"tarantulago/models"
+	"time"
+)
+
+// Feeder demand forecast. Each tarantula eats what its feeding schedule calls
+// for, except while it fasts before a molt and recovers afterwards. Demand is
+// drawn from colonies holding that size first, then from mixed-size colonies.
+
+const (
+	ForecastWeeks = 8
+
+	premoltFastDays  = 14
+	postmoltFastDays = 10
+	orderLeadDays    = 7
+)
+
+type fastWindow struct {
+	from, to time.Time
+}
+
+// moltFast returns when a tarantula is expected not to eat, based on its
+// current molt stage and predicted next molt.
+func moltFast(stage int, prediction *models.MoltPrediction, now time.Time) (fastWindow, bool) {
+	switch models.MoltStageEnum(stage) {
+	case models.MoltStagePreMolt:
+		molt := now.AddDate(0, 0, premoltFastDays)
+		if prediction != nil && prediction.PredictedMoltDate != nil && prediction.PredictedMoltDate.After(now) {
+			molt = *prediction.PredictedMoltDate
+		}
+		return fastWindow{now, molt.AddDate(0, 0, postmoltFastDays)}, true
+	case models.MoltStageMolting:
+		return fastWindow{now, now.AddDate(0, 0, postmoltFastDays)}, true
+	case models.MoltStagePostMolt:
+		to := now.AddDate(0, 0, postmoltFastDays/2)
+		if prediction != nil && prediction.LastMoltDate != nil {
+			to = prediction.LastMoltDate.AddDate(0, 0, postmoltFastDays)
+		}
+		return fastWindow{now, to}, to.After(now)
+	}
+
+	if prediction != nil && prediction.PredictedMoltDate != nil {
+		molt := *prediction.PredictedMoltDate
+		return fastWindow{molt.AddDate(0, 0, -premoltFastDays), molt.AddDate(0, 0, postmoltFastDays)}, true
+	}
+	return fastWindow{}, false
+}
+
+// overlapDays is how many days of [from, to) fall inside the window
+func (f fastWindow) overlapDays(from, to time.Time) float64 {
+	start, end := from, to
+	if f.from.After(start) {
+		start = f.from
+	}
+	if f.to.Before(end) {
+		end = f.to
+	}
+	if !end.After(start) {
+		return 0
+	}
+	return end.Sub(start).Hours() / 24
+}
+
+// ForecastDemand returns each size class's expected demand for each of the next weeks
+func ForecastDemand(schedules []models.ScheduledFeeding, predictions []models.MoltPrediction, weeks int, now time.Time) map[models.CricketSizeEnum][]float64 {
+	byTarantula := make(map[int32]*models.MoltPrediction, len(predictions))
+	for i := range predictions {
+		byTarantula[predictions[i].TarantulaID] = &predictions[i]
+	}
+
+	demand := make(map[models.CricketSizeEnum][]float64)
+	for _, s := range schedules {
+		interval := float64(s.MinDays+s.MaxDays) / 2
+		size, count := ParsePreySize(s.PreySize)
+		if interval <= 0 || size == models.CricketSizeUnknown {
+			continue
+		}
+		perDay := count / interval
+		fast, fasting := moltFast(s.MoltStageID, byTarantula[s.TarantulaID], now)
+
+		if demand[size] == nil {
+			demand[size] = make([]float64, weeks)
+		}
+		for w := 0; w < weeks; w++ {
+			from := now.AddDate(0, 0, w*7)
+			to := from.AddDate(0, 0, 7)
+			days := 7.0
+			if fasting {
+				days -= fast.overlapDays(from, to)
+			}
+			demand[size][w] += perDay * days
+		}
+	}
+	return demand
+}
+
+// BuildFeederForecast compares forecast demand with colony stock and works out
+// when each size runs out and how many to order, allowing for delivery time.
+func BuildFeederForecast(schedules []models.ScheduledFeeding, predictions []models.MoltPrediction, colonies []models.ColonyStatus, weeks int, now time.Time) models.FeederForecast {
+	forecast := models.FeederForecast{Start: now, Weeks: weeks}
+
+	stock := make(map[models.CricketSizeEnum]float64)
+	for _, c := range colonies {
+		if c.CurrentCount <= 0 {
+			continue
+		}
+		if size := sizeFromName(c.SizeName); size != models.CricketSizeUnknown {
+			stock[size] += float64(c.CurrentCount)
+		} else {
+			forecast.MixedStock += int(c.CurrentCount)
+		}
+	}
+
+	demand := ForecastDemand(schedules, predictions, weeks, now)
+	remaining := make(map[models.CricketSizeEnum]float64, len(stock))
+	for size, n := range stock {
+		remaining[size] = n
+	}
+	mixed := float64(forecast.MixedStock)
+	shortfall := make(map[models.CricketSizeEnum]float64)
+	runOut := make(map[models.CricketSizeEnum]time.Time)
+
+	for w := 0; w < weeks; w++ {
+		for _, size := range feederSizes {
+			if demand[size] == nil {
+				continue
+			}
+			weekly := demand[size][w]
+			need := weekly
+
+			take := math.Min(need, remaining[size])
+			remaining[size] -= take
+			need -= take
+
+			take = math.Min(need, mixed)
+			mixed -= take
+			need -= take
+
+			if need > 1e-9 {
+				if _, ok := runOut[size]; !ok {
+					covered := (weekly - need) / weekly * 7
+					runOut[size] = now.AddDate(0, 0, w*7).Add(time.Duration(covered * 24 * float64(time.Hour)))
+				}
+				shortfall[size] += need
+			}
+		}
+	}
+
+	for _, size := range feederSizes {
+		if demand[size] == nil && stock[size] == 0 {
+			continue
+		}
+		sf := models.FeederSizeForecast{
+			Size:         size,
+			WeeklyDemand: demand[size],
+			Stock:        int(stock[size]),
+		}
+		if sf.WeeklyDemand == nil {
+			sf.WeeklyDemand = make([]float64, weeks)
+		}
+		if date, ok := runOut[size]; ok {
+			orderBy := date.AddDate(0, 0, -orderLeadDays)
+			if orderBy.Before(now) {
+				orderBy = now
+			}
+			sf.RunOutDate = &date
+			sf.OrderByDate = &orderBy
+			sf.OrderQuantity = int(math.Ceil(shortfall[size] - 1e-9))
+		}
+		forecast.Sizes = append(forecast.Sizes, sf)
+	}
+
+	return forecast
+}
+
+func sizeFromName(name string) models.CricketSizeEnum {
+	for _, size := range feederSizes {
+		if size.ToDBName() == name {
+			return size
+		}
+	}
+	return models.CricketSizeUnknown
+}
+
+func (db *TarantulaDB) GetFeederForecast(ctx context.Context, userID int64) (*models.FeederForecast, error) {
+	schedules, err := db.GetScheduledFeedings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	predictions, err := db.GetMoltPredictions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	colonies, err := db.GetColonyStatus(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	forecast := BuildFeederForecast(schedules, predictions, colonies, ForecastWeeks, time.Now())
+	return &forecast, nil
+}
@@ -0,0 +1,55 @@
+package db
+
+import (
+	"math"
+	"tarantulago/models"
+	"testing"
+	"time"
+)
+
+func TestBuildFeederForecastRunOut(t *testing.T) {
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	schedules := []models.ScheduledFeeding{
+		{TarantulaID: 1, PreySize: "2 small crickets", MinDays: 7, MaxDays: 7, MoltStageID: int(models.MoltStageNormal)},
+	}
+	colonies := []models.ColonyStatus{{CurrentCount: 10, SizeName: "Small"}}
+
+	forecast := BuildFeederForecast(schedules, nil, colonies, ForecastWeeks, now)
+	if len(forecast.Sizes) != 1 {
+		t.Fatalf("sizes = %v, want only small", forecast.Sizes)
+	}
+	small := forecast.Sizes[0]
+	if small.RunOutDate == nil || !small.RunOutDate.Equal(now.AddDate(0, 0, 35)) {
+		t.Errorf("run out = %v, want day 35", small.RunOutDate)
+	}
+	if small.OrderByDate == nil || !small.OrderByDate.Equal(now.AddDate(0, 0, 28)) {
+		t.Errorf("order by = %v, want day 28", small.OrderByDate)
+	}
+	if small.OrderQuantity != 6 {
+		t.Errorf("order quantity = %d, want 6", small.OrderQuantity)
+	}
+}
+
+func TestForecastDemandPremolt(t *testing.T) {
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	molt := now.AddDate(0, 0, 7)
+	schedules := []models.ScheduledFeeding{
+		{TarantulaID: 1, PreySize: "2 small crickets", MinDays: 7, MaxDays: 7, MoltStageID: int(models.MoltStagePreMolt)},
+	}
+	predictions := []models.MoltPrediction{{TarantulaID: 1, PredictedMoltDate: &molt}}
+
+	// Fasting until the predicted molt and for ten days after, i.e. day 17
+	weekly := ForecastDemand(schedules, predictions, 4, now)[models.CricketSizeSmall]
+	want := []float64{0, 0, 2.0 / 7 * 4, 2}
+	for i := range want {
+		if math.Abs(weekly[i]-want[i]) > 1e-9 {
+			t.Errorf("week %d demand = %v, want %v", i+1, weekly[i], want[i])
+		}
+	}
+
+	// Mixed stock covers the reduced demand
+	forecast := BuildFeederForecast(schedules, predictions, []models.ColonyStatus{{CurrentCount: 5}}, 4, now)
+	if forecast.MixedStock != 5 || forecast.Sizes[0].RunOutDate != nil {
+		t.Errorf("forecast = %+v, want mixed stock of 5 lasting the period", forecast)
+	}
+}
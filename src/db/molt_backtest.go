@@ -0,0 +1,258 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"tarantulago/models"
+)
+
+// MoltBacktestStats summarises how well predictions matched the molts that
+// actually followed. Errors are predicted minus actual, in days.
+type MoltBacktestStats struct {
+	SpeciesID   int32
+	SpeciesName string
+	Bracket     string
+	Predictions int
+	Hits        int
+	sumAbsError float64
+	sumError    float64
+}
+
+// MAE is the mean absolute error of the predicted molt date in days.
+func (s MoltBacktestStats) MAE() float64 {
+	if s.Predictions == 0 {
+		return 0
+	}
+	return s.sumAbsError / float64(s.Predictions)
+}
+
+// Bias is the mean signed error in days; positive means molts came earlier than predicted.
+func (s MoltBacktestStats) Bias() float64 {
+	if s.Predictions == 0 {
+		return 0
+	}
+	return s.sumError / float64(s.Predictions)
+}
+
+// HitRate is the share of molts that fell inside the predicted window.
+func (s MoltBacktestStats) HitRate() float64 {
+	if s.Predictions == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Predictions)
+}
+
+func (s *MoltBacktestStats) add(predictedDays, lowerDays, upperDays, actualDays float64) {
+	diff := predictedDays - actualDays
+	s.Predictions++
+	s.sumError += diff
+	s.sumAbsError += math.Abs(diff)
+	if actualDays >= lowerDays && actualDays <= upperDays {
+		s.Hits++
+	}
+}
+
+// MoltBacktestReport holds overall accuracy and a breakdown by species and size bracket.
+type MoltBacktestReport struct {
+	Overall MoltBacktestStats
+	Groups  []MoltBacktestStats
+}
+
+// BacktestMolts replays molt history in date order. For every molt that has a
+// successor, it predicts the next molt using only the data that was recorded
+// up to that molt and compares it with the molt that actually followed. The
+// model grows as the replay goes, so each interval is pooled once.
+// fallback supplies the heuristic cycle for an observation, as in
+// GetMoltPredictions; its SizeCM is the latest size known at that molt.
+func BacktestMolts(observations []models.MoltObservation, speciesNames map[int32]string, fallback func(models.MoltObservation) int) *MoltBacktestReport {
+	type molt struct {
+		history []models.MoltObservation
+		i       int
+	}
+	var molts []molt
+	for _, history := range groupByTarantula(observations) {
+		for i := range history {
+			molts = append(molts, molt{history, i})
+		}
+	}
+	sort.SliceStable(molts, func(a, b int) bool {
+		return molts[a].history[molts[a].i].MoltDate.Before(molts[b].history[molts[b].i].MoltDate)
+	})
+
+	report := &MoltBacktestReport{}
+	groups := make(map[moltGroupKey]*MoltBacktestStats)
+	model := newMoltModel()
+
+	for start := 0; start < len(molts); {
+		// Everything recorded on a day is known to predictions made that day
+		end := start
+		for end < len(molts) && molts[end].history[molts[end].i].MoltDate.Equal(molts[start].history[molts[start].i].MoltDate) {
+			if m := molts[end]; m.i > 0 {
+				model.addInterval(m.history[m.i-1], m.history[m.i])
+			}
+			end++
+		}
+
+		for _, m := range molts[start:end] {
+			history, i := m.history, m.i
+			if i+1 >= len(history) {
+				continue
+			}
+			current, next := history[i], history[i+1]
+			actualDays := next.MoltDate.Sub(current.MoltDate).Hours() / 24
+			if actualDays < minIntervalDays {
+				continue
+			}
+
+			known := current
+			known.SizeCM = knownSize(history[:i+1])
+			estimate := model.Predict(history[:i+1], current.SpeciesID, known.SizeCM, current.AdultSizeCM, fallback(known))
+
+			bracket := SizeBracket(known.SizeCM, current.AdultSizeCM)
+			if bracket == "" {
+				bracket = "Unknown"
+			}
+			key := moltGroupKey{current.SpeciesID, bracket}
+			if groups[key] == nil {
+				groups[key] = &MoltBacktestStats{
+					SpeciesID:   current.SpeciesID,
+					SpeciesName: speciesNames[current.SpeciesID],
+					Bracket:     bracket,
+				}
+			}
+
+			groups[key].add(estimate.MedianDays, estimate.LowerDays, estimate.UpperDays, actualDays)
+			report.Overall.add(estimate.MedianDays, estimate.LowerDays, estimate.UpperDays, actualDays)
+		}
+		start = end
+	}
+
+	for _, g := range groups {
+		report.Groups = append(report.Groups, *g)
+	}
+	sort.Slice(report.Groups, func(i, j int) bool {
+		a, b := report.Groups[i], report.Groups[j]
+		if a.SpeciesName != b.SpeciesName {
+			return a.SpeciesName < b.SpeciesName
+		}
+		return bracketOrder(a.Bracket) < bracketOrder(b.Bracket)
+	})
+
+	return report
+}
+
+// knownSize is the latest size recorded in a tarantula's history so far
+func knownSize(history []models.MoltObservation) float64 {
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].SizeCM > 0 {
+			return history[i].SizeCM
+		}
+	}
+	return 0
+}
+
+// scheduleForSize picks the schedule GetMoltPredictions uses: the largest
+// body length not above the size, from schedules sorted by body length
+func scheduleForSize(schedules []models.FeedingSchedule, sizeCM float64) *models.FeedingSchedule {
+	var match *models.FeedingSchedule
+	for i := range schedules {
+		if schedules[i].BodyLengthCM <= sizeCM {
+			match = &schedules[i]
+		}
+	}
+	return match
+}
+
+func bracketOrder(bracket string) int {
+	switch bracket {
+	case SizeBracketSpiderling:
+		return 0
+	case SizeBracketJuvenile:
+		return 1
+	case SizeBracketSubAdult:
+		return 2
+	case SizeBracketAdult:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// GetAllMoltObservations returns every recorded molt across all users
+func (db *TarantulaDB) GetAllMoltObservations(ctx context.Context) ([]models.MoltObservation, error) {
+	var observations []models.MoltObservation
+
+	result := db.db.WithContext(ctx).Raw(`
+        SELECT
+            mr.tarantula_id,
+            t.species_id,
+            mr.molt_date,
+            COALESCE(NULLIF(mr.post_molt_length_cm, 0), NULLIF(mr.pre_molt_length_cm, 0), 0) as size_cm,
+            COALESCE(ts.adult_size_cm, 0) as adult_size_cm,
+            COALESCE(t.estimated_age_months, 0) as estimated_age_months
+        FROM spider_bot.molt_records mr
+        JOIN spider_bot.tarantulas t ON mr.tarantula_id = t.id
+        JOIN spider_bot.tarantula_species ts ON t.species_id = ts.id
+        ORDER BY mr.tarantula_id, mr.molt_date`).
+		Scan(&observations)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get all molt observations: %w", result.Error)
+	}
+
+	return observations, nil
+}
+
+// BacktestMoltPredictions replays all recorded molts through the molt model
+func (db *TarantulaDB) BacktestMoltPredictions(ctx context.Context) (*MoltBacktestReport, error) {
+	observations, err := db.GetAllMoltObservations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Species come through the tarantulas so private ones are named too
+	var species []models.TarantulaSpecies
+	if err := db.db.WithContext(ctx).
+		Where("id IN (SELECT species_id FROM spider_bot.tarantulas)").
+		Find(&species).Error; err != nil {
+		return nil, fmt.Errorf("failed to get species: %w", err)
+	}
+
+	names := make(map[int32]string, len(species))
+	temperaments := make(map[int32]string, len(species))
+	for _, s := range species {
+		names[int32(s.ID)] = s.ScientificName
+		temperaments[int32(s.ID)] = s.Temperament
+	}
+
+	var schedules []models.FeedingSchedule
+	if err := db.db.WithContext(ctx).Preload("Frequency").Order("species_id, body_length_cm").Find(&schedules).Error; err != nil {
+		return nil, fmt.Errorf("failed to get feeding schedules: %w", err)
+	}
+	bySpecies := make(map[int32][]models.FeedingSchedule)
+	for _, s := range schedules {
+		bySpecies[int32(s.SpeciesID)] = append(bySpecies[int32(s.SpeciesID)], s)
+	}
+
+	// The heuristic gets what GetMoltPredictions would have given it at the
+	// time: the size and age known then and the feeding schedule for that size
+	fallback := func(o models.MoltObservation) int {
+		adultSize := o.AdultSizeCM
+		row := moltPredictionRow{
+			SpeciesID:          o.SpeciesID,
+			CurrentSize:        o.SizeCM,
+			AdultSizeCM:        &adultSize,
+			EstimatedAgeMonths: o.EstimatedAgeMonths,
+			SpeciesTemperament: temperaments[o.SpeciesID],
+		}
+		if schedule := scheduleForSize(bySpecies[o.SpeciesID], o.SizeCM); schedule != nil {
+			minDays, maxDays := int32(schedule.Frequency.MinDays), int32(schedule.Frequency.MaxDays)
+			row.FeedingFreqMinDays, row.FeedingFreqMaxDays = &minDays, &maxDays
+		}
+		return db.calculateSpeciesBasedMoltCycle(row)
+	}
+
+	return BacktestMolts(observations, names, fallback), nil
+}
@@ -0,0 +1,361 @@
+package db
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"tarantulago/models"
+	"time"
+)
+
+// Molt intervals are modelled as log-normal: the log of the number of days
+// between consecutive molts is treated as normally distributed within a
+// species and size bracket, and each interval is expected to be a roughly
+// constant factor longer than the previous one as the animal grows.
+
+const (
+	// MoltConfidenceLevel is the coverage of the predicted molt window.
+	MoltConfidenceLevel = 0.8
+
+	minPooledIntervals = 3
+	minIntervalDays    = 14
+
+	// Used when there is not enough pooled data to estimate a spread.
+	defaultLogSpread  = 0.35
+	defaultLogGrowth  = 0.15 // each interval ~16% longer than the last
+	defaultGrowthVar  = 0.30 * 0.30
+	minPredictiveSpan = 0.12
+)
+
+const (
+	SizeBracketSpiderling = "Spiderling"
+	SizeBracketJuvenile   = "Juvenile"
+	SizeBracketSubAdult   = "Sub-Adult"
+	SizeBracketAdult      = "Adult"
+)
+
+// SizeBracket maps a body length to the size categories used by feeding_schedules.
+func SizeBracket(sizeCM, adultSizeCM float64) string {
+	if sizeCM <= 0 || adultSizeCM <= 0 {
+		return ""
+	}
+	ratio := sizeCM / adultSizeCM
+	switch {
+	case ratio < 0.25:
+		return SizeBracketSpiderling
+	case ratio < 0.5:
+		return SizeBracketJuvenile
+	case ratio < 0.85:
+		return SizeBracketSubAdult
+	default:
+		return SizeBracketAdult
+	}
+}
+
+type logStats struct {
+	n    int
+	mean float64
+	m2   float64
+}
+
+func (s *logStats) add(x float64) {
+	s.n++
+	delta := x - s.mean
+	s.mean += delta / float64(s.n)
+	s.m2 += delta * (x - s.mean)
+}
+
+// remove takes back a value that was added
+func (s *logStats) remove(x float64) {
+	if s.n <= 1 {
+		*s = logStats{}
+		return
+	}
+	mean := (s.mean*float64(s.n) - x) / float64(s.n-1)
+	s.m2 -= (x - mean) * (x - s.mean)
+	s.mean = mean
+	s.n--
+	// Rounding leaves a trace of spread where there was none
+	if s.m2 < 1e-9 {
+		s.m2 = 0
+	}
+}
+
+// excluding returns a copy of s without values, or nil if s is nil
+func excluding(s *logStats, values []float64) *logStats {
+	if s == nil {
+		return nil
+	}
+	c := *s
+	for _, v := range values {
+		c.remove(v)
+	}
+	return &c
+}
+
+func (s *logStats) variance() float64 {
+	if s == nil || s.n < 2 {
+		return math.NaN()
+	}
+	return s.m2 / float64(s.n-1)
+}
+
+type moltGroupKey struct {
+	speciesID int32
+	bracket   string
+}
+
+// MoltModel holds interval statistics pooled across every keeper's animals.
+// What each tarantula contributed is kept, so its own intervals can be left
+// out of the pooled prior when predicting for it.
+type MoltModel struct {
+	groups       map[moltGroupKey]*logStats
+	brackets     map[string]*logStats
+	growth       map[int32]*logStats
+	globalGrowth logStats
+
+	contributions map[int32][]moltContribution
+	lastLog       map[int32]float64
+}
+
+// moltContribution is one interval a tarantula added to the pooled statistics
+type moltContribution struct {
+	key       moltGroupKey // bracket is empty when the size wasn't known
+	logDays   float64
+	growth    float64
+	hasGrowth bool
+}
+
+// MoltEstimate is a predicted interval, in days from the last molt.
+type MoltEstimate struct {
+	MedianDays          float64
+	LowerDays           float64
+	UpperDays           float64
+	ConfidenceLevel     float64
+	PooledIntervals     int
+	IndividualIntervals int
+	Basis               string
+}
+
+// MoltIntervals returns consecutive intervals (in days) for one tarantula's
+// molts, which must be sorted by date. Implausibly short gaps are dropped.
+func MoltIntervals(history []models.MoltObservation) []float64 {
+	var intervals []float64
+	for i := 1; i < len(history); i++ {
+		days := history[i].MoltDate.Sub(history[i-1].MoltDate).Hours() / 24
+		if days < minIntervalDays {
+			continue
+		}
+		intervals = append(intervals, days)
+	}
+	return intervals
+}
+
+// BuildMoltModel pools molt intervals from all observations. Observations may
+// be in any order; they are grouped per tarantula and sorted by date.
+func BuildMoltModel(observations []models.MoltObservation) *MoltModel {
+	m := newMoltModel()
+	for _, history := range groupByTarantula(observations) {
+		for i := 1; i < len(history); i++ {
+			m.addInterval(history[i-1], history[i])
+		}
+	}
+	return m
+}
+
+func newMoltModel() *MoltModel {
+	return &MoltModel{
+		groups:        make(map[moltGroupKey]*logStats),
+		brackets:      make(map[string]*logStats),
+		growth:        make(map[int32]*logStats),
+		contributions: make(map[int32][]moltContribution),
+		lastLog:       make(map[int32]float64),
+	}
+}
+
+// addInterval pools the interval between two consecutive molts of one
+// tarantula. A tarantula's intervals must be added in date order.
+func (m *MoltModel) addInterval(start, end models.MoltObservation) {
+	days := end.MoltDate.Sub(start.MoltDate).Hours() / 24
+	if days < minIntervalDays {
+		return
+	}
+	logDays := math.Log(days)
+	c := moltContribution{key: moltGroupKey{speciesID: start.SpeciesID}, logDays: logDays}
+
+	if bracket := SizeBracket(start.SizeCM, start.AdultSizeCM); bracket != "" {
+		c.key.bracket = bracket
+		m.group(c.key).add(logDays)
+		m.bracket(bracket).add(logDays)
+	}
+
+	if prevLog, ok := m.lastLog[start.TarantulaID]; ok {
+		c.growth, c.hasGrowth = logDays-prevLog, true
+		m.speciesGrowth(start.SpeciesID).add(c.growth)
+		m.globalGrowth.add(c.growth)
+	}
+	m.lastLog[start.TarantulaID] = logDays
+	m.contributions[start.TarantulaID] = append(m.contributions[start.TarantulaID], c)
+}
+
+// pooledWithout returns the pooled statistics Predict uses, leaving out what
+// tarantulaID contributed
+func (m *MoltModel) pooledWithout(tarantulaID int32, key moltGroupKey) (group, bracket, speciesGrowth, globalGrowth *logStats) {
+	var ownGroup, ownBracket, ownGrowth []float64
+	for _, c := range m.contributions[tarantulaID] {
+		if c.key.bracket != "" && c.key.bracket == key.bracket {
+			ownBracket = append(ownBracket, c.logDays)
+			if c.key == key {
+				ownGroup = append(ownGroup, c.logDays)
+			}
+		}
+		if c.hasGrowth {
+			ownGrowth = append(ownGrowth, c.growth)
+		}
+	}
+
+	// A tarantula only ever has one species, so all its growth is in that
+	// species' statistics
+	var ownSpeciesGrowth []float64
+	if len(m.contributions[tarantulaID]) > 0 && m.contributions[tarantulaID][0].key.speciesID == key.speciesID {
+		ownSpeciesGrowth = ownGrowth
+	}
+
+	return excluding(m.groups[key], ownGroup),
+		excluding(m.brackets[key.bracket], ownBracket),
+		excluding(m.growth[key.speciesID], ownSpeciesGrowth),
+		excluding(&m.globalGrowth, ownGrowth)
+}
+
+func groupByTarantula(observations []models.MoltObservation) [][]models.MoltObservation {
+	byID := make(map[int32][]models.MoltObservation)
+	var order []int32
+	for _, o := range observations {
+		if _, ok := byID[o.TarantulaID]; !ok {
+			order = append(order, o.TarantulaID)
+		}
+		byID[o.TarantulaID] = append(byID[o.TarantulaID], o)
+	}
+
+	result := make([][]models.MoltObservation, 0, len(order))
+	for _, id := range order {
+		history := byID[id]
+		sort.Slice(history, func(i, j int) bool { return history[i].MoltDate.Before(history[j].MoltDate) })
+		result = append(result, history)
+	}
+	return result
+}
+
+func (m *MoltModel) group(k moltGroupKey) *logStats {
+	if m.groups[k] == nil {
+		m.groups[k] = &logStats{}
+	}
+	return m.groups[k]
+}
+
+func (m *MoltModel) bracket(b string) *logStats {
+	if m.brackets[b] == nil {
+		m.brackets[b] = &logStats{}
+	}
+	return m.brackets[b]
+}
+
+func (m *MoltModel) speciesGrowth(speciesID int32) *logStats {
+	if m.growth[speciesID] == nil {
+		m.growth[speciesID] = &logStats{}
+	}
+	return m.growth[speciesID]
+}
+
+// Predict estimates the next molt interval for a tarantula. history is that
+// tarantula's own molts sorted by date; fallbackDays is the heuristic cycle
+// used when no pooled data exists for its size bracket.
+func (m *MoltModel) Predict(history []models.MoltObservation, speciesID int32, sizeCM, adultSizeCM float64, fallbackDays int) MoltEstimate {
+	bracket := SizeBracket(sizeCM, adultSizeCM)
+
+	// The tarantula's own intervals are weighed below, so they're left out
+	// of the pooled data rather than counted twice
+	tarantulaID := int32(-1)
+	if len(history) > 0 {
+		tarantulaID = history[0].TarantulaID
+	}
+	g, b, speciesGrowth, globalGrowth := m.pooledWithout(tarantulaID, moltGroupKey{speciesID, bracket})
+
+	// Prior: distribution of intervals for the species at this size,
+	// falling back to all species at this size, then to the heuristic.
+	var priorMean, priorVar float64
+	var pooled int
+	var basis string
+	if bracket != "" && g != nil && g.n >= minPooledIntervals {
+		priorMean, priorVar, pooled = g.mean, spreadOrDefault(g), g.n
+		basis = fmt.Sprintf("%d %s intervals of this species", g.n, bracket)
+	} else if bracket != "" && b != nil && b.n >= minPooledIntervals {
+		priorMean, priorVar, pooled = b.mean, spreadOrDefault(b), b.n
+		basis = fmt.Sprintf("%d %s intervals across species", b.n, bracket)
+	} else {
+		priorMean, priorVar = math.Log(float64(max(fallbackDays, minIntervalDays))), defaultLogSpread*defaultLogSpread
+		basis = "species size estimate"
+	}
+
+	mean, variance := priorMean, priorVar
+
+	// Individual evidence: each of its own intervals, lengthened by the
+	// typical growth factor once for every molt since. Growth compounds the
+	// uncertainty, so older intervals count for less.
+	intervals := MoltIntervals(history)
+	if n := len(intervals); n > 0 {
+		growthMean, growthVar := defaultLogGrowth, defaultGrowthVar
+		if speciesGrowth != nil && speciesGrowth.n >= minPooledIntervals {
+			growthMean, growthVar = speciesGrowth.mean, spreadOrDefaultVar(speciesGrowth, defaultGrowthVar)
+		} else if globalGrowth.n >= minPooledIntervals {
+			growthMean, growthVar = globalGrowth.mean, spreadOrDefaultVar(globalGrowth, defaultGrowthVar)
+		}
+
+		weighted, precision := priorMean/priorVar, 1/priorVar
+		for i, days := range intervals {
+			steps := float64(n - i)
+			v := steps * growthVar
+			weighted += (math.Log(days) + steps*growthMean) / v
+			precision += 1 / v
+		}
+		mean = weighted / precision
+		variance = 1 / precision
+		basis += fmt.Sprintf(" and %d of its own", n)
+	}
+
+	spread := math.Max(math.Sqrt(variance), minPredictiveSpan)
+	z := normalQuantile(0.5 + MoltConfidenceLevel/2)
+
+	return MoltEstimate{
+		MedianDays:          math.Exp(mean),
+		LowerDays:           math.Exp(mean - z*spread),
+		UpperDays:           math.Exp(mean + z*spread),
+		ConfidenceLevel:     MoltConfidenceLevel,
+		PooledIntervals:     pooled,
+		IndividualIntervals: len(intervals),
+		Basis:               basis,
+	}
+}
+
+// Window converts the estimate into calendar dates after the given molt date.
+func (e MoltEstimate) Window(lastMolt time.Time) (median, lower, upper time.Time) {
+	return lastMolt.AddDate(0, 0, int(math.Round(e.MedianDays))),
+		lastMolt.AddDate(0, 0, int(math.Round(e.LowerDays))),
+		lastMolt.AddDate(0, 0, int(math.Round(e.UpperDays)))
+}
+
+func spreadOrDefault(s *logStats) float64 {
+	return spreadOrDefaultVar(s, defaultLogSpread*defaultLogSpread)
+}
+
+func spreadOrDefaultVar(s *logStats, fallback float64) float64 {
+	v := s.variance()
+	if math.IsNaN(v) || v <= 0 {
+		return fallback
+	}
+	return v
+}
+
+func normalQuantile(p float64) float64 {
+	return math.Sqrt2 * math.Erfinv(2*p-1)
+}
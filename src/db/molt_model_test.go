@@ -0,0 +1,188 @@
+package db
+
+import (
+	"math"
+	"tarantulago/models"
+	"testing"
+	"time"
+)
+
+func moltHistory(tarantulaID, speciesID int32, start time.Time, intervals []int, sizes []float64) []models.MoltObservation {
+	history := []models.MoltObservation{{
+		TarantulaID: tarantulaID, SpeciesID: speciesID, MoltDate: start, SizeCM: sizes[0], AdultSizeCM: 14,
+	}}
+	date := start
+	for i, days := range intervals {
+		date = date.AddDate(0, 0, days)
+		history = append(history, models.MoltObservation{
+			TarantulaID: tarantulaID, SpeciesID: speciesID, MoltDate: date, SizeCM: sizes[i+1], AdultSizeCM: 14,
+		})
+	}
+	return history
+}
+
+func TestSizeBracket(t *testing.T) {
+	cases := []struct {
+		size, adult float64
+		want        string
+	}{
+		{1, 14, SizeBracketSpiderling},
+		{5, 14, SizeBracketJuvenile},
+		{9, 14, SizeBracketSubAdult},
+		{13, 14, SizeBracketAdult},
+		{0, 14, ""},
+		{5, 0, ""},
+	}
+	for _, c := range cases {
+		if got := SizeBracket(c.size, c.adult); got != c.want {
+			t.Errorf("SizeBracket(%v, %v) = %q, want %q", c.size, c.adult, got, c.want)
+		}
+	}
+}
+
+func TestMoltIntervalsSkipsDuplicates(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	history := moltHistory(1, 1, start, []int{60, 2, 80}, []float64{2, 3, 3, 4})
+	got := MoltIntervals(history)
+	if len(got) != 2 || got[0] != 60 || got[1] != 80 {
+		t.Fatalf("MoltIntervals = %v, want [60 80]", got)
+	}
+}
+
+func TestPredictUsesPooledSpeciesData(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	var observations []models.MoltObservation
+	for id := int32(1); id <= 5; id++ {
+		observations = append(observations, moltHistory(id, 1, start, []int{100, 100}, []float64{5, 5.5, 6})...)
+	}
+	model := BuildMoltModel(observations)
+
+	estimate := model.Predict(nil, 1, 5, 14, 400)
+	if math.Abs(estimate.MedianDays-100) > 1 {
+		t.Errorf("median = %.1f, want ~100 from pooled data", estimate.MedianDays)
+	}
+	if estimate.PooledIntervals != 10 {
+		t.Errorf("pooled = %d, want 10", estimate.PooledIntervals)
+	}
+	if !(estimate.LowerDays < estimate.MedianDays && estimate.MedianDays < estimate.UpperDays) {
+		t.Errorf("window %.1f..%.1f does not contain median %.1f", estimate.LowerDays, estimate.UpperDays, estimate.MedianDays)
+	}
+}
+
+func TestPredictFallsBackToHeuristic(t *testing.T) {
+	model := BuildMoltModel(nil)
+	estimate := model.Predict(nil, 1, 5, 14, 120)
+	if math.Abs(estimate.MedianDays-120) > 1 {
+		t.Errorf("median = %.1f, want fallback 120", estimate.MedianDays)
+	}
+	if estimate.PooledIntervals != 0 || estimate.IndividualIntervals != 0 {
+		t.Errorf("expected no data behind fallback estimate, got %+v", estimate)
+	}
+}
+
+func TestPredictLengthensWithGrowth(t *testing.T) {
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	var observations []models.MoltObservation
+	for id := int32(1); id <= 4; id++ {
+		observations = append(observations, moltHistory(id, 2, start, []int{60, 72, 86, 103}, []float64{1, 1.5, 2, 2.5, 3})...)
+	}
+	model := BuildMoltModel(observations)
+
+	own := moltHistory(99, 2, start, []int{60, 72}, []float64{1, 1.5, 2})
+	estimate := model.Predict(own, 2, 2, 14, 60)
+	if estimate.MedianDays <= 72 {
+		t.Errorf("median = %.1f, want longer than the last interval of 72 days", estimate.MedianDays)
+	}
+	if estimate.IndividualIntervals != 2 {
+		t.Errorf("individual intervals = %d, want 2", estimate.IndividualIntervals)
+	}
+}
+
+func TestPredictLeavesOwnIntervalsOutOfPrior(t *testing.T) {
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	var others []models.MoltObservation
+	for id := int32(1); id <= 3; id++ {
+		others = append(others, moltHistory(id, 3, start, []int{100, 100}, []float64{5, 5.5, 6})...)
+	}
+	own := moltHistory(9, 3, start, []int{300, 300}, []float64{5, 5.5, 6})
+
+	with := BuildMoltModel(append(append([]models.MoltObservation{}, others...), own...)).Predict(own, 3, 5, 14, 100)
+	without := BuildMoltModel(others).Predict(own, 3, 5, 14, 100)
+	if math.Abs(with.MedianDays-without.MedianDays) > 0.01 || with.PooledIntervals != without.PooledIntervals {
+		t.Errorf("own intervals counted in the prior: median %.2f (pooled %d), want %.2f (pooled %d)",
+			with.MedianDays, with.PooledIntervals, without.MedianDays, without.PooledIntervals)
+	}
+}
+
+func TestPredictUsesEveryInterval(t *testing.T) {
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	model := BuildMoltModel(nil)
+
+	last := model.Predict(moltHistory(1, 1, start, []int{120}, []float64{2, 2.5}), 1, 2.5, 14, 120)
+	all := model.Predict(moltHistory(1, 1, start.AddDate(0, 0, -60), []int{60, 120}, []float64{1.5, 2, 2.5}), 1, 2.5, 14, 120)
+	if all.MedianDays >= last.MedianDays {
+		t.Errorf("median with an earlier 60 day interval = %.1f, want shorter than %.1f from the last interval alone",
+			all.MedianDays, last.MedianDays)
+	}
+	if all.IndividualIntervals != 2 {
+		t.Errorf("individual intervals = %d, want 2", all.IndividualIntervals)
+	}
+}
+
+func TestBacktestMoltsUsesOnlyPriorData(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	observations := moltHistory(1, 1, start, []int{90, 90, 90}, []float64{5, 5.5, 6, 6.5})
+
+	report := BacktestMolts(observations, map[int32]string{1: "G. pulchra"}, func(models.MoltObservation) int { return 90 })
+
+	if report.Overall.Predictions != 3 {
+		t.Fatalf("predictions = %d, want 3", report.Overall.Predictions)
+	}
+	if len(report.Groups) != 1 || report.Groups[0].SpeciesName != "G. pulchra" {
+		t.Fatalf("unexpected groups: %+v", report.Groups)
+	}
+	// The first prediction has only the 90 day fallback to go on, so it
+	// should be exact; later ones are pulled longer by the growth prior.
+	if report.Overall.Bias() < 0 {
+		t.Errorf("bias = %.1f, want >= 0", report.Overall.Bias())
+	}
+	if report.Overall.HitRate() != 1 {
+		t.Errorf("hit rate = %.2f, want 1", report.Overall.HitRate())
+	}
+}
+
+func TestBacktestMoltsIgnoresLaterMolts(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	fallback := func(models.MoltObservation) int { return 90 }
+	species := func(report *MoltBacktestReport, id int32) MoltBacktestStats {
+		for _, g := range report.Groups {
+			if g.SpeciesID == id {
+				return g
+			}
+		}
+		t.Fatalf("no backtest group for species %d", id)
+		return MoltBacktestStats{}
+	}
+
+	// Juveniles of another species with much longer intervals would pull the
+	// cross-species prior for species 1 if they were known in time
+	own := moltHistory(1, 1, start, []int{90, 90, 90}, []float64{5, 5, 5, 5})
+	others := func(from time.Time) []models.MoltObservation {
+		var observations []models.MoltObservation
+		for id := int32(10); id < 13; id++ {
+			observations = append(observations, moltHistory(id, 2, from, []int{300, 300}, []float64{5, 5, 5})...)
+		}
+		return observations
+	}
+
+	alone := species(BacktestMolts(own, nil, fallback), 1)
+	later := species(BacktestMolts(append(others(start.AddDate(3, 0, 0)), own...), nil, fallback), 1)
+	earlier := species(BacktestMolts(append(others(start.AddDate(-3, 0, 0)), own...), nil, fallback), 1)
+
+	if later.MAE() != alone.MAE() || later.Bias() != alone.Bias() {
+		t.Errorf("molts recorded afterwards changed the predictions: MAE %.2f, want %.2f", later.MAE(), alone.MAE())
+	}
+	if earlier.MAE() == alone.MAE() {
+		t.Errorf("molts recorded beforehand should inform the predictions, MAE stayed %.2f", earlier.MAE())
+	}
+}
@@ -0,0 +1,144 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"tarantulago/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Sitters look after a collection while its keeper is away: they log
+// feedings, watering and observations, and the owner gets a digest of it all
+// once their access runs out.
+
+// RecordCareLog saves a care log for one of the owner's tarantulas
+func (db *TarantulaDB) RecordCareLog(ctx context.Context, log models.CareLog) error {
+	return db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var tarantula models.Tarantula
+		if err := tx.Where("id = ? AND user_id = ?", log.TarantulaID, log.UserID).First(&tarantula).Error; err != nil {
+			return lookupError(err, "tarantula")
+		}
+
+		if err := tx.Create(&log).Error; err != nil {
+			return fmt.Errorf("failed to record care log: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetCareSummary gathers what a sitter needs for each tarantula: its feeding
+// schedule for its current size, when it was last fed and whether it's
+// fasting for a molt
+func (db *TarantulaDB) GetCareSummary(ctx context.Context, ownerID int64) ([]models.CareSummaryItem, error) {
+	var tarantulas []models.Tarantula
+
+	result := db.db.WithContext(ctx).
+		Preload("Species").
+		Preload("CurrentMoltStage").
+		Where("user_id = ?", ownerID).
+		Order("name").
+		Find(&tarantulas)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get tarantulas: %w", result.Error)
+	}
+
+	items := make([]models.CareSummaryItem, 0, len(tarantulas))
+	for _, tarantula := range tarantulas {
+		schedule, err := db.GetFeedingSchedule(ctx, int64(tarantula.SpeciesID), float32(tarantula.CurrentSize))
+		if err != nil {
+			return nil, err
+		}
+
+		lastFeeding, err := db.GetLastFeeding(ctx, int32(tarantula.ID), ownerID)
+		if err != nil {
+			return nil, err
+		}
+
+		item := models.CareSummaryItem{
+			Tarantula: tarantula,
+			Schedule:  schedule,
+			Fasting:   isFasting(tarantula.CurrentMoltStageID),
+		}
+		if lastFeeding != nil {
+			item.LastFedDate = &lastFeeding.FeedingDate
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// GetExpiredSitters returns sitters whose access has run out and whose owner
+// hasn't had a digest yet
+func (db *TarantulaDB) GetExpiredSitters(ctx context.Context, now time.Time) ([]models.CollectionMember, error) {
+	var sitters []models.CollectionMember
+
+	result := db.db.WithContext(ctx).
+		Preload("Owner").
+		Preload("Member").
+		Where("role = ? AND expires_at <= ? AND digest_sent_at IS NULL", models.CollectionRoleSitter, now).
+		Find(&sitters)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get expired sitters: %w", result.Error)
+	}
+
+	return sitters, nil
+}
+
+// GetSitterDigest collects everything the sitter logged in the owner's
+// collection during their stint
+func (db *TarantulaDB) GetSitterDigest(ctx context.Context, sitter models.CollectionMember) (*models.SitterDigest, error) {
+	digest := models.SitterDigest{
+		Sitter: sitter.Member,
+		From:   sitter.JoinedAt,
+		Until:  time.Now(),
+	}
+	if sitter.ExpiresAt != nil {
+		digest.Until = *sitter.ExpiresAt
+	}
+
+	result := db.db.WithContext(ctx).
+		Preload("Tarantula").
+		Preload("TarantulaColony").
+		Preload("FeederSpecies").
+		Where("user_id = ? AND recorded_by = ?", sitter.OwnerID, sitter.MemberID).
+		Where("created_at BETWEEN ? AND ?", digest.From, digest.Until).
+		Order("feeding_date").
+		Find(&digest.Feedings)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get sitter feedings: %w", result.Error)
+	}
+
+	result = db.db.WithContext(ctx).
+		Preload("Tarantula").
+		Where("user_id = ? AND recorded_by = ?", sitter.OwnerID, sitter.MemberID).
+		Where("created_at BETWEEN ? AND ?", digest.From, digest.Until).
+		Order("logged_at").
+		Find(&digest.CareLogs)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get sitter care logs: %w", result.Error)
+	}
+
+	return &digest, nil
+}
+
+func (db *TarantulaDB) MarkSitterDigestSent(ctx context.Context, memberID int, sentAt time.Time) error {
+	result := db.db.WithContext(ctx).Model(&models.CollectionMember{}).
+		Where("id = ?", memberID).
+		Updates(map[string]interface{}{
+			"digest_sent_at": sentAt,
+			"on_duty":        false,
+		})
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to mark sitter digest sent: %w", result.Error)
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+// Package logging sets up the bot's slog logger: text or JSON output, a
+// correlation ID carried in the context of each update, and Telegram user IDs
+// hashed so logs can be shared without identifying anyone.
+package logging
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+)
+
+// Output formats
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// CorrelationKey is the attribute every record logged with a correlated
+// context carries
+const CorrelationKey = "correlation_id"
+
+// userIDKeys are the attributes holding Telegram user or private chat IDs
+var userIDKeys = map[string]bool{
+	"user_id":   true,
+	"chat_id":   true,
+	"owner_id":  true,
+	"member_id": true,
+	"sitter_id": true,
+	"admin_id":  true,
+}
+
+type Options struct {
+	Format string
+	Level  slog.Level
+	// UserIDKey keys the user ID hash; without one a random key is used, so
+	// hashes only match within one run
+	UserIDKey string
+}
+
+// New builds a logger writing to w
+func New(w io.Writer, opts Options) (*slog.Logger, error) {
+	key := []byte(opts.UserIDKey)
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("failed to generate user ID hash key: %w", err)
+		}
+	}
+
+	handlerOpts := &slog.HandlerOptions{
+		Level: opts.Level,
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if userIDKeys[a.Key] {
+				return slog.String(a.Key, hashUserID(key, a.Value))
+			}
+			return a
+		},
+	}
+
+	var handler slog.Handler
+	switch opts.Format {
+	case FormatJSON:
+		handler = slog.NewJSONHandler(w, handlerOpts)
+	case FormatText, "":
+		handler = slog.NewTextHandler(w, handlerOpts)
+	default:
+		return nil, fmt.Errorf("unknown log format %q", opts.Format)
+	}
+	return slog.New(correlationHandler{handler}), nil
+}
+
+// hashUserID replaces an ID with a short keyed hash that still lets one
+// user's records be followed through the logs
+func hashUserID(key []byte, v slog.Value) string {
+	var id string
+	switch v.Kind() {
+	case slog.KindInt64:
+		id = strconv.FormatInt(v.Int64(), 10)
+	case slog.KindUint64:
+		id = strconv.FormatUint(v.Uint64(), 10)
+	default:
+		id = v.String()
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(id))
+	return hex.EncodeToString(mac.Sum(nil))[:12]
+}
+
+type correlationKey struct{}
+
+// WithCorrelationID tags ctx so records logged with it can be tied together
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationKey{}, id)
+}
+
+// CorrelationID is the ID ctx was tagged with, if any
+func CorrelationID(ctx context.Context) string {
+	id, _ := ctx.Value(correlationKey{}).(string)
+	return id
+}
+
+// correlationHandler adds the context's correlation ID to each record
+type correlationHandler struct {
+	slog.Handler
+}
+
+func (h correlationHandler) Handle(ctx context.Context, r slog.Record) error {
+	if ctx != nil {
+		if id := CorrelationID(ctx); id != "" {
+			r.AddAttrs(slog.String(CorrelationKey, id))
+		}
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h correlationHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return correlationHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h correlationHandler) WithGroup(name string) slog.Handler {
+	return correlationHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,51 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func TestLoggerHashesUserIDsAndAddsCorrelation(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, Options{Format: FormatJSON, Level: slog.LevelInfo, UserIDKey: "k"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := WithCorrelationID(context.Background(), "upd-42")
+	logger.InfoContext(ctx, "Fed", "user_id", int64(123456789), "tarantula_id", 7)
+	logger.InfoContext(ctx, "Fed again", "user_id", int64(123456789))
+
+	var first, second map[string]any
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != 2 {
+		t.Fatalf("got %d records, want 2", len(lines))
+	}
+	if err := json.Unmarshal(lines[0], &first); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(lines[1], &second); err != nil {
+		t.Fatal(err)
+	}
+
+	if first[CorrelationKey] != "upd-42" {
+		t.Errorf("correlation id = %v, want upd-42", first[CorrelationKey])
+	}
+	if id, _ := first["user_id"].(string); id == "" || id == "123456789" {
+		t.Errorf("user_id = %v, want a hash", first["user_id"])
+	}
+	if first["user_id"] != second["user_id"] {
+		t.Error("the same user hashed differently")
+	}
+	if first["tarantula_id"] != float64(7) {
+		t.Errorf("tarantula_id = %v, want it left alone", first["tarantula_id"])
+	}
+
+	debug, _ := New(&buf, Options{Level: slog.LevelInfo})
+	if debug.Enabled(ctx, slog.LevelDebug) {
+		t.Error("debug enabled at info level")
+	}
+}
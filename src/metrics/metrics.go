@@ -0,0 +1,104 @@
+// Package metrics holds the bot's Prometheus collectors and the HTTP server
+// exposing them alongside health checks.
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// Registry holds every collector the bot exposes on /metrics
+var Registry = prometheus.NewRegistry()
+
+var (
+	updatesHandled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "tarantulago_updates_total",
+		Help: "Telegram updates handled, by kind and outcome.",
+	}, []string{"kind", "outcome"})
+
+	handlerDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "tarantulago_handler_duration_seconds",
+		Help:    "Time spent handling a Telegram update.",
+		Buckets: []float64{.01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+	}, []string{"kind"})
+
+	dbQueries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "tarantulago_db_queries_total",
+		Help: "Database statements run, by operation and outcome.",
+	}, []string{"operation", "outcome"})
+
+	dbQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "tarantulago_db_query_duration_seconds",
+		Help:    "Time spent on a database statement.",
+		Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation"})
+
+	notificationsSent = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "tarantulago_notifications_total",
+		Help: "Notification messages sent, by kind and outcome.",
+	}, []string{"kind", "outcome"})
+
+	activeSessions = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "tarantulago_active_sessions",
+		Help: "Users in the middle of a form.",
+	}, countActiveSessions)
+
+	sessionCountersMu sync.Mutex
+	sessionCounters   []func() int
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		updatesHandled, handlerDuration,
+		dbQueries, dbQueryDuration,
+		notificationsSent,
+		activeSessions,
+	)
+}
+
+func outcome(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "ok"
+}
+
+// ObserveUpdate records a handled update of the given kind
+func ObserveUpdate(kind string, took time.Duration, err error) {
+	updatesHandled.WithLabelValues(kind, outcome(err)).Inc()
+	handlerDuration.WithLabelValues(kind).Observe(took.Seconds())
+}
+
+// ObserveQuery records a database statement
+func ObserveQuery(operation string, took time.Duration, err error) {
+	dbQueries.WithLabelValues(operation, outcome(err)).Inc()
+	dbQueryDuration.WithLabelValues(operation).Observe(took.Seconds())
+}
+
+// ObserveNotification records a notification message sent to one chat
+func ObserveNotification(kind string, err error) {
+	notificationsSent.WithLabelValues(kind, outcome(err)).Inc()
+}
+
+// RegisterActiveSessions adds a bot's count of users in the middle of a form
+// to the active sessions gauge. It can be called once per bot.
+func RegisterActiveSessions(count func() int) {
+	sessionCountersMu.Lock()
+	defer sessionCountersMu.Unlock()
+	sessionCounters = append(sessionCounters, count)
+}
+
+func countActiveSessions() float64 {
+	sessionCountersMu.Lock()
+	defer sessionCountersMu.Unlock()
+	total := 0
+	for _, count := range sessionCounters {
+		total += count()
+	}
+	return float64(total)
+}
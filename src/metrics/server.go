@@ -0,0 +1,91 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// checkTimeout bounds each health check so a hung dependency fails the probe
+// instead of hanging it
+const checkTimeout = 5 * time.Second
+
+// Check is a dependency probed by the health endpoints. Liveness checks run
+// on /healthz and /readyz; the rest only on /readyz, so an unreachable
+// Telegram takes the bot out of rotation without getting it restarted.
+type Check struct {
+	Name     string
+	Liveness bool
+	Run      func(ctx context.Context) error
+}
+
+// NewHandler serves /metrics, /healthz and /readyz
+func NewHandler(checks ...Check) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(Registry, promhttp.HandlerOpts{}))
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		serveChecks(w, r, checks, true)
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		serveChecks(w, r, checks, false)
+	})
+	return mux
+}
+
+func serveChecks(w http.ResponseWriter, r *http.Request, checks []Check, livenessOnly bool) {
+	ctx, cancel := context.WithTimeout(r.Context(), checkTimeout)
+	defer cancel()
+
+	status := http.StatusOK
+	results := make(map[string]string)
+	for _, check := range checks {
+		if livenessOnly && !check.Liveness {
+			continue
+		}
+		// The endpoints are unauthenticated, so the error, which may carry
+		// hosts or credentials, only goes to the log
+		if err := check.Run(ctx); err != nil {
+			slog.Warn("Health check failed", "check", check.Name, "error", err)
+			status = http.StatusServiceUnavailable
+			results[check.Name] = "unavailable"
+			continue
+		}
+		results[check.Name] = "ok"
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(results); err != nil {
+		slog.Error("Failed to write health check result", "error", err)
+	}
+}
+
+// Serve runs the metrics and health server until ctx is cancelled
+func Serve(ctx context.Context, addr string, checks ...Check) error {
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           NewHandler(checks...),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	served := make(chan error, 1)
+	go func() { served <- server.ListenAndServe() }()
+	slog.Info("Serving metrics and health checks", "listen", addr)
+
+	select {
+	case err := <-served:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), checkTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}
+}
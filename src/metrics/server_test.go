@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHealthEndpoints(t *testing.T) {
+	telegramDown := errors.New("telegram unreachable")
+	handler := NewHandler(
+		Check{Name: "database", Liveness: true, Run: func(context.Context) error { return nil }},
+		Check{Name: "telegram", Run: func(context.Context) error { return telegramDown }},
+	)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	if rec := get("/healthz"); rec.Code != http.StatusOK || strings.Contains(rec.Body.String(), "telegram") {
+		t.Errorf("/healthz = %d %s, want 200 with only liveness checks", rec.Code, rec.Body)
+	}
+	if rec := get("/readyz"); rec.Code != http.StatusServiceUnavailable || !strings.Contains(rec.Body.String(), `"telegram":"unavailable"`) {
+		t.Errorf("/readyz = %d %s, want 503 naming the failed check", rec.Code, rec.Body)
+	} else if strings.Contains(rec.Body.String(), "telegram unreachable") {
+		t.Errorf("/readyz = %s, want the error kept out of the response", rec.Body)
+	}
+
+	// Every bot registers its sessions; a second one mustn't panic
+	RegisterActiveSessions(func() int { return 2 })
+	RegisterActiveSessions(func() int { return 3 })
+
+	ObserveUpdate("command", 20*time.Millisecond, nil)
+	ObserveQuery("query", time.Millisecond, errors.New("boom"))
+	rec := get("/metrics")
+	for _, want := range []string{
+		`tarantulago_updates_total{kind="command",outcome="ok"}`,
+		`tarantulago_db_queries_total{operation="query",outcome="error"}`,
+		`tarantulago_active_sessions 5`,
+	} {
+		if !strings.Contains(rec.Body.String(), want) {
+			t.Errorf("/metrics missing %s", want)
+		}
+	}
+}
@@ -286,3 +286,78 @@ func ColonyMaintenanceTypeFromID(id int32) ColonyMaintenanceTypeEnum {
 		return ColonyMaintenanceCount
 	}
 }
+
+// Species catalogue statuses. Built-in species are approved; user-defined
+// species stay private to their owner unless proposed for the shared catalogue.
+const (
+	SpeciesStatusApproved = "approved"
+	SpeciesStatusPrivate  = "private"
+	SpeciesStatusPending  = "pending"
+)
+
+// Care sheet values for TarantulaSpecies
+const (
+	HabitatTerrestrial = "Terrestrial"
+	HabitatArboreal    = "Arboreal"
+	HabitatFossorial   = "Fossorial"
+
+	VenomMild     = "Mild"
+	VenomModerate = "Moderate"
+	VenomStrong   = "Strong"
+
+	GrowthSlow   = "Slow"
+	GrowthMedium = "Medium"
+	GrowthFast   = "Fast"
+)
+
+// Prey size classes, shared by every feeder species
+const (
+	PreySizePinhead = "Pinhead"
+	PreySizeSmall   = "Small"
+	PreySizeMedium  = "Medium"
+	PreySizeLarge   = "Large"
+	PreySizeAdult   = "Adult"
+)
+
+var PreySizes = []string{PreySizePinhead, PreySizeSmall, PreySizeMedium, PreySizeLarge, PreySizeAdult}
+
+// Collection roles
+const (
+	CollectionRoleOwner  = "owner"
+	CollectionRoleEditor = "editor"
+	CollectionRoleViewer = "viewer"
+	CollectionRoleSitter = "sitter"
+)
+
+// Care log kinds
+const (
+	CareLogWater       = "water"
+	CareLogObservation = "observation"
+)
+
+// Expense categories
+const (
+	ExpenseEnclosure = "Enclosure"
+	ExpenseSubstrate = "Substrate"
+	ExpenseVet       = "Vet"
+	ExpenseAnimal    = "Animal purchase"
+	ExpenseEquipment = "Equipment"
+	ExpenseOther     = "Other"
+)
+
+var ExpenseCategories = []string{ExpenseEnclosure, ExpenseSubstrate, ExpenseVet, ExpenseAnimal, ExpenseEquipment, ExpenseOther}
+
+// Events recorded for a member of a communal colony
+const (
+	MemberEventSeen         = "Seen"
+	MemberEventMolted       = "Molted"
+	MemberEventMissing      = "Missing"
+	MemberEventCannibalized = "Cannibalized"
+	MemberEventRemoved      = "Removed"
+)
+
+var MemberEventTypes = []string{MemberEventSeen, MemberEventMolted, MemberEventMissing, MemberEventCannibalized, MemberEventRemoved}
+
+// DefaultFeederSpeciesID is the house cricket seeded by migration 0010, used
+// for feedings and colonies recorded without a feeder species.
+const DefaultFeederSpeciesID = 1
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kinds of failure caused by the request rather than by the bot; match them
+// with errors.Is
+var (
+	ErrNotFound          = errors.New("not found")
+	ErrAccessDenied      = errors.New("access denied")
+	ErrValidation        = errors.New("invalid request")
+	ErrInsufficientStock = errors.New("insufficient stock")
+	ErrConflict          = errors.New("conflict")
+)
+
+// DomainError is a failure the user can do something about. Message is
+// written for them; Err is the underlying cause, if any, and only belongs in
+// logs.
+type DomainError struct {
+	Kind    error
+	Message string
+	Err     error
+}
+
+func (e *DomainError) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *DomainError) Unwrap() []error {
+	if e.Err != nil {
+		return []error{e.Kind, e.Err}
+	}
+	return []error{e.Kind}
+}
+
+func newDomainError(kind error, format string, args []any) *DomainError {
+	return &DomainError{Kind: kind, Message: fmt.Sprintf(format, args...)}
+}
+
+func NotFound(format string, args ...any) error {
+	return newDomainError(ErrNotFound, format, args)
+}
+
+func AccessDenied(format string, args ...any) error {
+	return newDomainError(ErrAccessDenied, format, args)
+}
+
+func Invalid(format string, args ...any) error {
+	return newDomainError(ErrValidation, format, args)
+}
+
+func InsufficientStock(format string, args ...any) error {
+	return newDomainError(ErrInsufficientStock, format, args)
+}
+
+func Conflict(format string, args ...any) error {
+	return newDomainError(ErrConflict, format, args)
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"fmt"
 	"time"
 
 	"gorm.io/gorm"
@@ -22,17 +23,39 @@ type TelegramUser struct {
 	IsActive   bool      `json:"is_active" gorm:"default:true"`
 	CreatedAt  time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
 	LastActive time.Time `json:"last_active" gorm:"default:CURRENT_TIMESTAMP"`
+	// ActiveCollection is the owner whose collection the user is working in;
+	// nil means their own
+	ActiveCollection *int64 `json:"active_collection,omitempty"`
+}
+
+// DisplayName is how other collection members see the user
+func (u TelegramUser) DisplayName() string {
+	if u.FirstName != "" {
+		return u.FirstName
+	}
+	if u.Username != "" {
+		return "@" + u.Username
+	}
+	return fmt.Sprintf("user %d", u.TelegramID)
 }
 
 type TarantulaSpecies struct {
 	ID                            int     `json:"id" gorm:"primaryKey"`
-	ScientificName                string  `json:"scientific_name" gorm:"unique;not null"`
+	ScientificName                string  `json:"scientific_name" gorm:"not null"` // Unique among approved species and within each keeper's own
 	CommonName                    string  `json:"common_name" gorm:"index"`
 	AdultSizeCM                   float64 `json:"adult_size_cm"`
 	Temperament                   string  `json:"temperament"`
 	HumidityRequirementPercent    int     `json:"humidity_requirement_percent"`
 	TemperatureRequirementCelsius float64 `json:"temperature_requirement_celsius"`
 	IsCommunal                    bool    `json:"is_communal" gorm:"default:false;index"`
+	Synonyms                      string  `json:"synonyms"` // Comma-separated former or alternative names
+	HabitatType                   string  `json:"habitat_type"`
+	UrticatingHairs               bool    `json:"urticating_hairs"`
+	VenomPotency                  string  `json:"venom_potency"`
+	GrowthRate                    string  `json:"growth_rate"`
+	Origin                        string  `json:"origin"`
+	OwnerUserID                   *int64  `json:"owner_user_id" gorm:"index"`
+	Status                        string  `json:"status" gorm:"default:approved;not null;index"`
 }
 
 type MoltStage struct {
@@ -41,16 +64,95 @@ type MoltStage struct {
 	Description string `json:"description"`
 }
 
+// FeederSpecies is a kind of feeder insect. Masses are typical live weights
+// per size class, so feedings of different prey can be compared.
+type FeederSpecies struct {
+	ID               int     `json:"id" gorm:"primaryKey"`
+	Name             string  `json:"name" gorm:"unique;not null"`
+	ScientificName   string  `json:"scientific_name"`
+	PinheadMassGrams float64 `json:"pinhead_mass_grams"`
+	SmallMassGrams   float64 `json:"small_mass_grams"`
+	MediumMassGrams  float64 `json:"medium_mass_grams"`
+	LargeMassGrams   float64 `json:"large_mass_grams"`
+	AdultMassGrams   float64 `json:"adult_mass_grams"`
+	UnitCost         float64 `json:"unit_cost"` // Typical price per insect
+
+	// Development at typical breeding temperatures. Hatchlings are pinheads;
+	// DaysTo* count from hatching until the cohort reaches that size class.
+	IncubationDays    int `json:"incubation_days"`
+	DaysToSmall       int `json:"days_to_small"`
+	DaysToMedium      int `json:"days_to_medium"`
+	DaysToLarge       int `json:"days_to_large"`
+	DaysToAdult       int `json:"days_to_adult"`
+	TypicalHatchCount int `json:"typical_hatch_count"` // Per clutch, used until a hatch is counted
+}
+
+// MassGrams returns the typical mass of one insect of the given size class,
+// treating unknown sizes as medium.
+func (f FeederSpecies) MassGrams(size string) float64 {
+	switch size {
+	case PreySizePinhead:
+		return f.PinheadMassGrams
+	case PreySizeSmall:
+		return f.SmallMassGrams
+	case PreySizeLarge:
+		return f.LargeMassGrams
+	case PreySizeAdult:
+		return f.AdultMassGrams
+	default:
+		return f.MediumMassGrams
+	}
+}
+
+// SizeAtAge returns the size class of a cohort the given number of days after
+// hatching, or CricketSizeUnknown if it hasn't hatched or development is unknown.
+func (f FeederSpecies) SizeAtAge(days int) CricketSizeEnum {
+	switch {
+	case days < 0 || f.DaysToSmall <= 0:
+		return CricketSizeUnknown
+	case days < f.DaysToSmall:
+		return CricketSizePinhead
+	case days < f.DaysToMedium:
+		return CricketSizeSmall
+	case days < f.DaysToLarge:
+		return CricketSizeMedium
+	case days < f.DaysToAdult:
+		return CricketSizeLarge
+	default:
+		return CricketSizeAdult
+	}
+}
+
+// DaysToSize returns the age in days at which a cohort reaches the size class
+func (f FeederSpecies) DaysToSize(size CricketSizeEnum) int {
+	switch size {
+	case CricketSizeSmall:
+		return f.DaysToSmall
+	case CricketSizeMedium:
+		return f.DaysToMedium
+	case CricketSizeLarge:
+		return f.DaysToLarge
+	case CricketSizeAdult:
+		return f.DaysToAdult
+	default:
+		return 0
+	}
+}
+
+// CricketColony is a feeder colony; despite the name it may hold any feeder species.
 type CricketColony struct {
-	ID            int          `json:"id" gorm:"primaryKey"`
-	ColonyName    string       `json:"colony_name"`
-	CurrentCount  int          `json:"current_count"`
-	LastCountDate time.Time    `json:"last_count_date" gorm:"index"`
-	Notes         string       `json:"notes"`
-	CreatedAt     time.Time    `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
-	UpdatedAt     time.Time    `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
-	UserID        int64        `json:"user_id" gorm:"index"`
-	User          TelegramUser `json:"user" gorm:"foreignKey:UserID;references:TelegramID"`
+	ID              int           `json:"id" gorm:"primaryKey"`
+	ColonyName      string        `json:"colony_name"`
+	FeederSpeciesID int           `json:"feeder_species_id" gorm:"index;not null;default:1"`
+	PreySize        string        `json:"prey_size"` // Size class held, empty for mixed sizes
+	CurrentCount    int           `json:"current_count"`
+	LastCountDate   time.Time     `json:"last_count_date" gorm:"index"`
+	Notes           string        `json:"notes"`
+	CreatedAt       time.Time     `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt       time.Time     `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UserID          int64         `json:"user_id" gorm:"index"`
+	FeederSpecies   FeederSpecies `json:"feeder_species" gorm:"foreignKey:FeederSpeciesID"`
+	User            TelegramUser  `json:"user" gorm:"foreignKey:UserID;references:TelegramID"`
 }
 
 type Enclosure struct {
@@ -134,23 +236,117 @@ type FeedingSchedule struct {
 	Frequency FeedingFrequency `json:"frequency" gorm:"foreignKey:FrequencyID"`
 }
 
+// FeederClutch is one breeding cycle of a feeder colony: egg-laying substrate
+// goes in, comes out to incubate, and the eggs hatch into a cohort that grows
+// through the size classes until it is added to the colony.
+type FeederClutch struct {
+	ID               int        `json:"id" gorm:"primaryKey"`
+	ColonyID         int        `json:"colony_id" gorm:"index;not null"`
+	SubstrateInDate  time.Time  `json:"substrate_in_date" gorm:"not null"`
+	SubstrateOutDate *time.Time `json:"substrate_out_date"`
+	HatchDate        *time.Time `json:"hatch_date"`
+	HatchlingCount   int        `json:"hatchling_count"`
+	MergedDate       *time.Time `json:"merged_date" gorm:"index"`
+	Notes            string     `json:"notes"`
+	UserID           int64      `json:"user_id" gorm:"index"`
+	CreatedAt        time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	Colony CricketColony `json:"colony" gorm:"foreignKey:ColonyID"`
+	User   TelegramUser  `json:"user" gorm:"foreignKey:UserID;references:TelegramID"`
+}
+
+// FeederPurchase records feeders bought from a supplier. Price and shipping
+// are what was paid for the whole order.
+type FeederPurchase struct {
+	ID              int       `json:"id" gorm:"primaryKey"`
+	FeederSpeciesID int       `json:"feeder_species_id" gorm:"index;not null"`
+	PreySize        string    `json:"prey_size"` // Empty for mixed sizes
+	Quantity        int       `json:"quantity" gorm:"not null"`
+	Price           float64   `json:"price"`
+	Shipping        float64   `json:"shipping"`
+	Supplier        string    `json:"supplier"`
+	PurchaseDate    time.Time `json:"purchase_date" gorm:"index;not null"`
+	ColonyID        *int      `json:"colony_id" gorm:"index"` // Feeder colony the insects went into, if any
+	Notes           string    `json:"notes"`
+	UserID          int64     `json:"user_id" gorm:"index;not null"`
+	CreatedAt       time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	FeederSpecies FeederSpecies  `json:"feeder_species" gorm:"foreignKey:FeederSpeciesID"`
+	Colony        *CricketColony `json:"colony,omitempty" gorm:"foreignKey:ColonyID"`
+	User          TelegramUser   `json:"user" gorm:"foreignKey:UserID;references:TelegramID"`
+}
+
+// UnitCost is the price per insect including shipping
+func (p FeederPurchase) UnitCost() float64 {
+	if p.Quantity <= 0 {
+		return 0
+	}
+	return (p.Price + p.Shipping) / float64(p.Quantity)
+}
+
+// Expense is any other cost of keeping: enclosures, substrate, vet visits or
+// the price paid for an animal. TarantulaID is set when it was for one spider.
+type Expense struct {
+	ID          int       `json:"id" gorm:"primaryKey"`
+	Category    string    `json:"category" gorm:"index;not null"`
+	Description string    `json:"description"`
+	Amount      float64   `json:"amount" gorm:"not null"`
+	ExpenseDate time.Time `json:"expense_date" gorm:"index;not null"`
+	TarantulaID *int      `json:"tarantula_id" gorm:"index"`
+	UserID      int64     `json:"user_id" gorm:"index;not null"`
+	CreatedAt   time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	Tarantula *Tarantula   `json:"tarantula,omitempty" gorm:"foreignKey:TarantulaID"`
+	User      TelegramUser `json:"user" gorm:"foreignKey:UserID;references:TelegramID"`
+}
+
+// Breeding stages of a FeederClutch
+const (
+	ClutchStageLaying     = "Laying"
+	ClutchStageIncubating = "Incubating"
+	ClutchStageGrowing    = "Growing"
+	ClutchStageMerged     = "Merged"
+)
+
+func (c FeederClutch) Stage() string {
+	switch {
+	case c.MergedDate != nil:
+		return ClutchStageMerged
+	case c.HatchDate != nil:
+		return ClutchStageGrowing
+	case c.SubstrateOutDate != nil:
+		return ClutchStageIncubating
+	default:
+		return ClutchStageLaying
+	}
+}
+
+// FeedingEvent records prey offered to a tarantula or colony. CricketColonyID is
+// the feeder colony the prey came from, or nil when it was bought in.
 type FeedingEvent struct {
-	ID                 int       `json:"id" gorm:"primaryKey"`
-	TarantulaID        *int      `json:"tarantula_id" gorm:"index"`
-	TarantulaColonyID  *int      `json:"tarantula_colony_id" gorm:"index"`
-	FeedingDate        time.Time `json:"feeding_date" gorm:"index;not null"`
-	CricketColonyID    int       `json:"cricket_colony_id" gorm:"index"`
-	NumberOfCrickets   int       `json:"number_of_crickets" gorm:"not null"`
-	FeedingStatusID    int       `json:"feeding_status_id" gorm:"index"`
-	Notes              string    `json:"notes"`
-	CreatedAt          time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
-	UserID             int64     `json:"user_id" gorm:"index"`
+	ID                int       `json:"id" gorm:"primaryKey"`
+	TarantulaID       *int      `json:"tarantula_id" gorm:"index"`
+	TarantulaColonyID *int      `json:"tarantula_colony_id" gorm:"index"`
+	FeedingDate       time.Time `json:"feeding_date" gorm:"index;not null"`
+	CricketColonyID   *int      `json:"cricket_colony_id" gorm:"index"`
+	FeederSpeciesID   int       `json:"feeder_species_id" gorm:"index;not null;default:1"`
+	PreySize          string    `json:"prey_size"`
+	PreyCount         int       `json:"prey_count" gorm:"not null"`
+	PreKilled         bool      `json:"pre_killed" gorm:"default:false"`
+	PreyMassGrams     float64   `json:"prey_mass_grams"`
+	FeedingStatusID   int       `json:"feeding_status_id" gorm:"index"`
+	Notes             string    `json:"notes"`
+	CreatedAt         time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UserID            int64     `json:"user_id" gorm:"index"`
+	RecordedBy        *int64    `json:"recorded_by,omitempty" gorm:"index"`
 
-	Tarantula        *Tarantula        `json:"tarantula,omitempty" gorm:"foreignKey:TarantulaID"`
-	TarantulaColony  *TarantulaColony  `json:"tarantula_colony,omitempty" gorm:"foreignKey:TarantulaColonyID"`
-	CricketColony    CricketColony     `json:"cricket_colony" gorm:"foreignKey:CricketColonyID"`
-	FeedingStatus    FeedingStatus     `json:"feeding_status" gorm:"foreignKey:FeedingStatusID"`
-	User             TelegramUser      `json:"user" gorm:"foreignKey:UserID;references:TelegramID"`
+	Tarantula       *Tarantula       `json:"tarantula,omitempty" gorm:"foreignKey:TarantulaID"`
+	TarantulaColony *TarantulaColony `json:"tarantula_colony,omitempty" gorm:"foreignKey:TarantulaColonyID"`
+	CricketColony   *CricketColony   `json:"cricket_colony,omitempty" gorm:"foreignKey:CricketColonyID"`
+	FeederSpecies   FeederSpecies    `json:"feeder_species" gorm:"foreignKey:FeederSpeciesID"`
+	FeedingStatus   FeedingStatus    `json:"feeding_status" gorm:"foreignKey:FeedingStatusID"`
+	User            TelegramUser     `json:"user" gorm:"foreignKey:UserID;references:TelegramID"`
+	Recorder        *TelegramUser    `json:"recorder,omitempty" gorm:"foreignKey:RecordedBy;references:TelegramID"`
 }
 
 type HealthCheckRecord struct {
@@ -196,10 +392,12 @@ type MoltRecord struct {
 	Notes            string    `json:"notes"`
 	CreatedAt        time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
 	UserID           int64     `json:"user_id" gorm:"index"`
+	RecordedBy       *int64    `json:"recorded_by,omitempty" gorm:"index"`
 
-	Tarantula Tarantula    `json:"tarantula" gorm:"foreignKey:TarantulaID"`
-	MoltStage MoltStage    `json:"molt_stage" gorm:"foreignKey:MoltStageID"`
-	User      TelegramUser `json:"user" gorm:"foreignKey:UserID;references:TelegramID"`
+	Tarantula Tarantula     `json:"tarantula" gorm:"foreignKey:TarantulaID"`
+	MoltStage MoltStage     `json:"molt_stage" gorm:"foreignKey:MoltStageID"`
+	User      TelegramUser  `json:"user" gorm:"foreignKey:UserID;references:TelegramID"`
+	Recorder  *TelegramUser `json:"recorder,omitempty" gorm:"foreignKey:RecordedBy;references:TelegramID"`
 }
 
 type HealthAlert struct {
@@ -215,12 +413,14 @@ func (HealthAlert) TableName() string {
 }
 
 type ColonyStatus struct {
-	ID                int32    `json:"id" gorm:"column:id"`
-	ColonyName        string   `json:"colony_name" gorm:"column:colony_name"`
-	CurrentCount      int32    `json:"current_count" gorm:"column:current_count"`
-	SizeName          string   `json:"size_name" gorm:"column:size_name"` // Changed from SizeType
-	CricketsUsed7Days int32    `json:"crickets_used_7_days" gorm:"column:crickets_used_7_days"`
-	WeeksRemaining    *float32 `json:"weeks_remaining,omitempty" gorm:"column:weeks_remaining"`
+	ID              int32    `json:"id" gorm:"column:id"`
+	ColonyName      string   `json:"colony_name" gorm:"column:colony_name"`
+	FeederSpeciesID int32    `json:"feeder_species_id" gorm:"column:feeder_species_id"`
+	FeederName      string   `json:"feeder_name" gorm:"column:feeder_name"`
+	CurrentCount    int32    `json:"current_count" gorm:"column:current_count"`
+	SizeName        string   `json:"size_name" gorm:"column:size_name"` // Changed from SizeType
+	UsedLast7Days   int32    `json:"used_last_7_days" gorm:"column:used_last_7_days"`
+	WeeksRemaining  *float32 `json:"weeks_remaining,omitempty" gorm:"column:weeks_remaining"`
 }
 
 func (ColonyStatus) TableName() string {
@@ -254,6 +454,7 @@ type TarantulaListItem struct {
 	FrequencyID      int32   `json:"frequency_id"`
 	MinDays          int32   `json:"min_days"`
 	MaxDays          int32   `json:"max_days"`
+	EnclosureName    string  `json:"enclosure_name"`
 }
 
 type UserSettings struct {
@@ -396,7 +597,8 @@ type FeedingPattern struct {
 	LastFeedingDate      *time.Time `json:"last_feeding_date"`
 	DaysSinceLastFeeding int32      `json:"days_since_last_feeding"`
 	FeedingRegularity    string     `json:"feeding_regularity"` // "Regular", "Irregular", "Inconsistent"
-	CricketsPerWeek      float64    `json:"crickets_per_week"`
+	PreyItemsPerWeek     float64    `json:"prey_items_per_week"`
+	PreyMassPerWeek      float64    `json:"prey_mass_grams_per_week"`
 }
 
 type GrowthData struct {
@@ -427,10 +629,11 @@ type AnnualReport struct {
 	TarantulaName string `json:"tarantula_name"`
 
 	// Feeding statistics
-	TotalFeedings   int32   `json:"total_feedings"`
-	TotalCrickets   int32   `json:"total_crickets_consumed"`
-	AcceptanceRate  float64 `json:"acceptance_rate"`
-	AverageInterval float64 `json:"average_feeding_interval"`
+	TotalFeedings      int32   `json:"total_feedings"`
+	TotalPreyItems     int32   `json:"total_prey_items"`
+	TotalPreyMassGrams float64 `json:"total_prey_mass_grams"`
+	AcceptanceRate     float64 `json:"acceptance_rate"`
+	AverageInterval    float64 `json:"average_feeding_interval"`
 
 	// Growth statistics
 	StartWeight *float64 `json:"start_weight_grams"`
@@ -448,8 +651,38 @@ type AnnualReport struct {
 	// Milestones
 	Milestones []string `json:"milestones"`
 
-	// Cost estimates
-	EstimatedCost float64 `json:"estimated_cricket_cost"`
+	// Costs, with prey priced from the keeper's own feeder purchases
+	PreyCost    float64 `json:"prey_cost"`
+	ExpenseCost float64 `json:"expense_cost"`
+}
+
+// SpendReport totals what a keeper spent in a year. Prey cost per tarantula
+// is what its feedings would have cost at the average purchase price.
+type SpendReport struct {
+	Year            int                `json:"year"`
+	FeederSpend     float64            `json:"feeder_spend"`
+	FeederItems     int                `json:"feeder_items"`
+	ExpenseSpend    map[string]float64 `json:"expense_spend"` // By category
+	Total           float64            `json:"total"`
+	FeederUnitCosts []FeederUnitCost   `json:"feeder_unit_costs"`
+	Tarantulas      []TarantulaSpend   `json:"tarantulas"`
+}
+
+// FeederUnitCost is the average price paid per insect of a feeder species and
+// size, across all purchases
+type FeederUnitCost struct {
+	FeederSpeciesID int     `json:"feeder_species_id"`
+	FeederName      string  `json:"feeder_name"`
+	PreySize        string  `json:"prey_size"`
+	Quantity        int     `json:"quantity"`
+	UnitCost        float64 `json:"unit_cost"`
+}
+
+type TarantulaSpend struct {
+	TarantulaID   int32   `json:"tarantula_id"`
+	TarantulaName string  `json:"tarantula_name"`
+	PreyCost      float64 `json:"prey_cost"`
+	ExpenseCost   float64 `json:"expense_cost"`
 }
 
 type MoltPrediction struct {
@@ -463,9 +696,12 @@ type MoltPrediction struct {
 	MoltCount         int32      `json:"total_molts"`
 
 	// Prediction
-	PredictedMoltDate *time.Time `json:"predicted_molt_date"`
-	ConfidenceLevel   string     `json:"confidence_level"` // "High", "Medium", "Low"
-	DaysUntilMolt     *int32     `json:"days_until_predicted_molt"`
+	PredictedMoltDate  *time.Time `json:"predicted_molt_date"`
+	EarliestMoltDate   *time.Time `json:"earliest_molt_date"`
+	LatestMoltDate     *time.Time `json:"latest_molt_date"`
+	ConfidenceInterval float64    `json:"confidence_interval"` // Coverage of the earliest..latest window, e.g. 0.8
+	SampleSize         int32      `json:"sample_size"`         // Pooled intervals behind the estimate
+	DaysUntilMolt      *int32     `json:"days_until_predicted_molt"`
 
 	// Indicators
 	PreMoltSigns    []string `json:"pre_molt_signs"`
@@ -477,6 +713,71 @@ type MoltPrediction struct {
 	Recommendation  string `json:"recommendation"`
 }
 
+// MoltObservation is a single molt with the context needed to pool molt
+// intervals across tarantulas of the same species and size
+type MoltObservation struct {
+	TarantulaID int32     `json:"tarantula_id"`
+	SpeciesID   int32     `json:"species_id"`
+	MoltDate    time.Time `json:"molt_date"`
+	SizeCM      float64   `json:"size_cm"`
+	AdultSizeCM float64   `json:"adult_size_cm"`
+
+	EstimatedAgeMonths int32 `json:"estimated_age_months"` // Only filled in for backtests
+}
+
+// ScheduledFeeding is the feeding schedule that applies to a tarantula at its
+// current size, used to estimate feeder demand
+type ScheduledFeeding struct {
+	TarantulaID   int32  `json:"tarantula_id"`
+	TarantulaName string `json:"tarantula_name"`
+	PreySize      string `json:"prey_size"`
+	MinDays       int    `json:"min_days"`
+	MaxDays       int    `json:"max_days"`
+	MoltStageID   int    `json:"molt_stage_id"`
+}
+
+// FeederProjection compares the feeders breeding clutches should provide on a
+// future date with what the collection's feeding schedules call for
+type FeederProjection struct {
+	Weeks    int                    `json:"weeks"`
+	Date     time.Time              `json:"date"`
+	Clutches []ClutchProjection     `json:"clutches"`
+	Sizes    []FeederSizeProjection `json:"sizes"`
+}
+
+type ClutchProjection struct {
+	Clutch        FeederClutch    `json:"clutch"`
+	ExpectedHatch time.Time       `json:"expected_hatch"`
+	Count         int             `json:"count"`
+	CurrentSize   CricketSizeEnum `json:"current_size"`
+	ProjectedSize CricketSizeEnum `json:"projected_size"`
+}
+
+type FeederSizeProjection struct {
+	Size         CricketSizeEnum `json:"size"`
+	Supply       int             `json:"supply"`
+	WeeklyDemand float64         `json:"weekly_demand"`
+	PeriodDemand float64         `json:"period_demand"`
+}
+
+// FeederForecast projects weekly feeder demand by size class from feeding
+// schedules and molts, and when current stock runs out
+type FeederForecast struct {
+	Start      time.Time            `json:"start"`
+	Weeks      int                  `json:"weeks"`
+	MixedStock int                  `json:"mixed_stock"` // Colonies of mixed sizes, usable for any size
+	Sizes      []FeederSizeForecast `json:"sizes"`
+}
+
+type FeederSizeForecast struct {
+	Size          CricketSizeEnum `json:"size"`
+	WeeklyDemand  []float64       `json:"weekly_demand"`
+	Stock         int             `json:"stock"`
+	RunOutDate    *time.Time      `json:"run_out_date,omitempty"`
+	OrderByDate   *time.Time      `json:"order_by_date,omitempty"`
+	OrderQuantity int             `json:"order_quantity"`
+}
+
 // TarantulaColony represents a group of communal tarantulas
 type TarantulaColony struct {
 	ID            int                       `json:"id" gorm:"primaryKey"`
@@ -484,6 +785,7 @@ type TarantulaColony struct {
 	SpeciesID     int                       `json:"species_id" gorm:"index;not null"`
 	FormationDate time.Time                 `json:"formation_date" gorm:"not null"`
 	EnclosureID   *int                      `json:"enclosure_id" gorm:"index"`
+	DissolvedDate *time.Time                `json:"dissolved_date"` // Set once merged into another colony
 	Notes         string                    `json:"notes"`
 	UserID        int64                     `json:"user_id" gorm:"index;not null"`
 	CreatedAt     time.Time                 `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
@@ -497,18 +799,199 @@ type TarantulaColony struct {
 
 // TarantulaColonyMember represents membership of a tarantula in a colony
 type TarantulaColonyMember struct {
-	ID          int        `json:"id" gorm:"primaryKey"`
-	ColonyID    int        `json:"colony_id" gorm:"index;not null"`
-	TarantulaID int        `json:"tarantula_id" gorm:"index;not null"`
-	JoinedDate  time.Time  `json:"joined_date" gorm:"not null"`
-	LeftDate    *time.Time `json:"left_date"`
-	IsActive    bool       `json:"is_active" gorm:"default:true;index"`
-	Notes       string     `json:"notes"`
-	UserID      int64      `json:"user_id" gorm:"index;not null"`
-	CreatedAt   time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
-	UpdatedAt   time.Time  `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+	ID           int        `json:"id" gorm:"primaryKey"`
+	ColonyID     int        `json:"colony_id" gorm:"index;not null"`
+	TarantulaID  int        `json:"tarantula_id" gorm:"index;not null"`
+	JoinedDate   time.Time  `json:"joined_date" gorm:"not null"`
+	LeftDate     *time.Time `json:"left_date"`
+	LastSeenDate *time.Time `json:"last_seen_date"`
+	MissingSince *time.Time `json:"missing_since"` // Reported missing and not seen since
+	IsActive     bool       `json:"is_active" gorm:"default:true;index"`
+	Notes        string     `json:"notes"`
+	UserID       int64      `json:"user_id" gorm:"index;not null"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt    time.Time  `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
 
 	Colony    TarantulaColony `json:"colony" gorm:"foreignKey:ColonyID"`
 	Tarantula Tarantula       `json:"tarantula" gorm:"foreignKey:TarantulaID"`
 	User      TelegramUser    `json:"user" gorm:"foreignKey:UserID;references:TelegramID"`
 }
+
+// ColonyCensus is a headcount of a communal colony: how many tarantulas were
+// visible against how many active members there were at the time
+type ColonyCensus struct {
+	ID            int       `json:"id" gorm:"primaryKey"`
+	ColonyID      int       `json:"colony_id" gorm:"index;not null"`
+	CensusDate    time.Time `json:"census_date" gorm:"index;not null"`
+	VisibleCount  int       `json:"visible_count"`
+	ExpectedCount int       `json:"expected_count"`
+	Notes         string    `json:"notes"`
+	UserID        int64     `json:"user_id" gorm:"index;not null"`
+	CreatedAt     time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	Colony TarantulaColony `json:"colony" gorm:"foreignKey:ColonyID"`
+	User   TelegramUser    `json:"user" gorm:"foreignKey:UserID;references:TelegramID"`
+}
+
+// ColonyMemberEvent records something observed about one member of a
+// communal colony, such as a molt or a member going missing
+type ColonyMemberEvent struct {
+	ID          int       `json:"id" gorm:"primaryKey"`
+	ColonyID    int       `json:"colony_id" gorm:"index;not null"`
+	MemberID    int       `json:"member_id" gorm:"index;not null"`
+	TarantulaID int       `json:"tarantula_id" gorm:"index;not null"`
+	EventType   string    `json:"event_type" gorm:"not null"`
+	EventDate   time.Time `json:"event_date" gorm:"index;not null"`
+	Notes       string    `json:"notes"`
+	UserID      int64     `json:"user_id" gorm:"index;not null"`
+	CreatedAt   time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	Member    TarantulaColonyMember `json:"member" gorm:"foreignKey:MemberID"`
+	Tarantula Tarantula             `json:"tarantula" gorm:"foreignKey:TarantulaID"`
+	User      TelegramUser          `json:"user" gorm:"foreignKey:UserID;references:TelegramID"`
+}
+
+// ColonyCensusAlert flags a communal colony whose headcount dropped since the
+// previous census, whose members haven't been seen for a while, or that has
+// members reported missing
+type ColonyCensusAlert struct {
+	ColonyID       int        `json:"colony_id"`
+	ColonyName     string     `json:"colony_name"`
+	CensusDate     *time.Time `json:"census_date,omitempty"`
+	VisibleCount   int        `json:"visible_count"`
+	ExpectedCount  int        `json:"expected_count"`
+	PreviousCount  int        `json:"previous_count"`
+	CountDropped   bool       `json:"count_dropped"`
+	UnseenMembers  []string   `json:"unseen_members,omitempty"`
+	MissingMembers []string   `json:"missing_members,omitempty"`
+}
+
+// ColonyFeedingShare credits one colony member with its part of a colony
+// feeding, so per-tarantula analytics include what it ate communally
+type ColonyFeedingShare struct {
+	ID             int       `json:"id" gorm:"primaryKey"`
+	FeedingEventID int       `json:"feeding_event_id" gorm:"index;not null"`
+	ColonyID       int       `json:"colony_id" gorm:"index;not null"`
+	TarantulaID    int       `json:"tarantula_id" gorm:"index;not null"`
+	FeedingDate    time.Time `json:"feeding_date" gorm:"index;not null"`
+	PreyCount      float64   `json:"prey_count"`
+	PreyMassGrams  float64   `json:"prey_mass_grams"`
+	UserID         int64     `json:"user_id" gorm:"index;not null"`
+	CreatedAt      time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	FeedingEvent FeedingEvent `json:"feeding_event" gorm:"foreignKey:FeedingEventID"`
+	Tarantula    Tarantula    `json:"tarantula" gorm:"foreignKey:TarantulaID"`
+}
+
+// ColonyFeedingPlan is how much a communal colony should be offered, worked
+// out from its members' feeding schedules
+type ColonyFeedingPlan struct {
+	ColonyID       int      `json:"colony_id"`
+	ColonyName     string   `json:"colony_name"`
+	ActiveMembers  int      `json:"active_members"`
+	FeedingMembers int      `json:"feeding_members"`
+	DueMembers     []string `json:"due_members,omitempty"`
+	PreySize       string   `json:"prey_size"`
+	PreyCount      int      `json:"prey_count"`
+}
+
+// IsDue reports whether any member is due a meal
+func (p ColonyFeedingPlan) IsDue() bool {
+	return len(p.DueMembers) > 0
+}
+
+// A collection is everything a keeper owns: rows whose user_id is the owner.
+// The owner can invite others to work in it as editors or viewers, or as a
+// sitter with restricted access until a set date.
+
+// CollectionMember gives a user a role in someone else's collection
+type CollectionMember struct {
+	ID           int        `json:"id" gorm:"primaryKey"`
+	OwnerID      int64      `json:"owner_id" gorm:"uniqueIndex:idx_collection_member;not null"`
+	MemberID     int64      `json:"member_id" gorm:"uniqueIndex:idx_collection_member;index;not null"`
+	Role         string     `json:"role" gorm:"not null"`
+	OnDuty       bool       `json:"on_duty" gorm:"default:false"`
+	JoinedAt     time.Time  `json:"joined_at" gorm:"not null"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty" gorm:"index"` // Sitter access ends here
+	DigestSentAt *time.Time `json:"digest_sent_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	Owner  TelegramUser `json:"owner" gorm:"foreignKey:OwnerID;references:TelegramID"`
+	Member TelegramUser `json:"member" gorm:"foreignKey:MemberID;references:TelegramID"`
+}
+
+// Expired reports whether time-limited access has run out
+func (m CollectionMember) Expired(now time.Time) bool {
+	return m.ExpiresAt != nil && !now.Before(*m.ExpiresAt)
+}
+
+// CollectionInvite is a single-use code that joins a collection with a role
+type CollectionInvite struct {
+	ID          int        `json:"id" gorm:"primaryKey"`
+	Code        string     `json:"code" gorm:"uniqueIndex;not null"`
+	OwnerID     int64      `json:"owner_id" gorm:"index;not null"`
+	Role        string     `json:"role" gorm:"not null"`
+	ExpiresAt   time.Time  `json:"expires_at" gorm:"not null"`
+	AccessUntil *time.Time `json:"access_until,omitempty"` // Sitter invites only
+	UsedBy      *int64     `json:"used_by,omitempty"`
+	UsedAt      *time.Time `json:"used_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// CareLog is routine care recorded between feedings, such as watering or an observation
+type CareLog struct {
+	ID          int       `json:"id" gorm:"primaryKey"`
+	TarantulaID int       `json:"tarantula_id" gorm:"index;not null"`
+	Kind        string    `json:"kind" gorm:"not null"`
+	Notes       string    `json:"notes"`
+	LoggedAt    time.Time `json:"logged_at" gorm:"index;not null"`
+	CreatedAt   time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UserID      int64     `json:"user_id" gorm:"index;not null"`
+	RecordedBy  *int64    `json:"recorded_by,omitempty" gorm:"index"`
+
+	Tarantula Tarantula     `json:"tarantula" gorm:"foreignKey:TarantulaID"`
+	Recorder  *TelegramUser `json:"recorder,omitempty" gorm:"foreignKey:RecordedBy;references:TelegramID"`
+}
+
+// CareSummaryItem is one tarantula's entry in the care summary left for a sitter
+type CareSummaryItem struct {
+	Tarantula   Tarantula
+	Schedule    *FeedingSchedule
+	LastFedDate *time.Time
+	Fasting     bool
+}
+
+// SitterDigest is everything a sitter logged while looking after a collection
+type SitterDigest struct {
+	Sitter   TelegramUser
+	From     time.Time
+	Until    time.Time
+	Feedings []FeedingEvent
+	CareLogs []CareLog
+}
+
+// GroupChat binds a Telegram group to a collection so a household or team can
+// work in it together. Role applies to group members not invited to the collection.
+type GroupChat struct {
+	ChatID    int64        `json:"chat_id" gorm:"primaryKey;autoIncrement:false"`
+	OwnerID   int64        `json:"owner_id" gorm:"index;not null"`
+	Title     string       `json:"title"`
+	Role      string       `json:"role" gorm:"not null"`
+	Reminders bool         `json:"reminders" gorm:"default:true"`
+	BoundBy   int64        `json:"bound_by" gorm:"not null"`
+	CreatedAt time.Time    `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	Owner     TelegramUser `json:"owner,omitempty" gorm:"foreignKey:OwnerID;references:TelegramID"`
+}
+
+// CollectionAccess is which collection a user is working in and their role there
+type CollectionAccess struct {
+	OwnerID   int64      `json:"owner_id"`
+	OwnerName string     `json:"owner_name"`
+	Role      string     `json:"role"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// CanEdit reports whether the role may record and change data
+func (a CollectionAccess) CanEdit() bool {
+	return a.Role == CollectionRoleOwner || a.Role == CollectionRoleEditor
+}